@@ -7,7 +7,7 @@ import (
 	"time"
 )
 
-//go:generate go tool mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit
+//go:generate go tool mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,ChargePlanner,Battery,Tariff,BatteryController,Circuit,VehicleClimateController,SocLimitSetter
 
 // Meter provides total active power in W
 type Meter interface {
@@ -49,6 +49,12 @@ type MaxACPowerGetter interface {
 	MaxACPower() float64
 }
 
+// PVCurtailer limits pv inverter active power output to a percentage of its nominal (max AC) power.
+// A limit of 100% removes the curtailment.
+type PVCurtailer interface {
+	Curtail(percent float64) error
+}
+
 // ChargeState provides current charging status
 type ChargeState interface {
 	Status() (ChargeStatus, error)
@@ -110,6 +116,12 @@ type ChargeRater interface {
 	ChargedEnergy() (float64, error)
 }
 
+// ChargePlanner is implemented by chargers that can follow the loadpoint's charging plan autonomously.
+// Plan slots are charged at maxCurrent, an empty plan removes the schedule.
+type ChargePlanner interface {
+	SetChargePlan(plan Rates, maxCurrent float64) error
+}
+
 // Identifier identifies a vehicle and is implemented by the charger
 type Identifier interface {
 	Identify() (string, error)
@@ -155,6 +167,11 @@ type VehicleClimater interface {
 	Climater() (bool, error)
 }
 
+// VehicleClimateController starts and stops vehicle climatisation
+type VehicleClimateController interface {
+	Climatize(enable bool) error
+}
+
 // VehicleOdometer returns the vehicles milage
 type VehicleOdometer interface {
 	Odometer() (float64, error)
@@ -175,6 +192,23 @@ type SocLimiter interface {
 	GetLimitSoc() (int64, error)
 }
 
+// SocLimitSetter sets the vehicle's onboard soc limit
+type SocLimitSetter interface {
+	SetLimitSoc(int64) error
+}
+
+// ThermalStorage provides the thermal storage model of a heating device
+type ThermalStorage interface {
+	ThermalModel() (ThermalModel, error)
+}
+
+// DeferrableLoad is implemented by one-shot appliances running a fixed program that must not be interrupted once started.
+// A zero program duration means the duration is derived from the planned energy.
+type DeferrableLoad interface {
+	ProgramDuration() time.Duration
+	ProgramRunning() bool
+}
+
 // ChargeController allows to start/stop the charging session on the vehicle side
 type ChargeController interface {
 	ChargeEnable(bool) error
@@ -224,6 +258,11 @@ type CircuitMeasurements interface {
 	GetMaxPhaseCurrent() float64
 }
 
+// CircuitPhaseCurrents provides the currents per physical grid phase L1..L3
+type CircuitPhaseCurrents interface {
+	GetPhaseCurrents() [3]float64
+}
+
 // CircuitLoad represents a loadpoint attached to a circuit
 type CircuitLoad interface {
 	CircuitMeasurements
@@ -233,6 +272,7 @@ type CircuitLoad interface {
 // Circuit defines the load control domain
 type Circuit interface {
 	CircuitMeasurements
+	CircuitPhaseCurrents
 	GetTitle() string
 	SetTitle(string)
 	GetParent() Circuit
@@ -243,8 +283,10 @@ type Circuit interface {
 	GetMaxCurrent() float64
 	SetMaxPower(float64)
 	SetMaxCurrent(float64)
+	Curtailed() bool
+	SetCurtailed(bool)
 	Update([]CircuitLoad) error
-	ValidateCurrent(old, new float64) float64
+	ValidatePhaseCurrents(old, new [3]float64) float64
 	ValidatePower(old, new float64) float64
 }
 
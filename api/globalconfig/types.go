@@ -149,9 +149,10 @@ type Tariffs struct {
 }
 
 type Network struct {
-	Schema string `json:"schema"`
-	Host   string `json:"host"`
-	Port   int    `json:"port"`
+	Schema      string `json:"schema"`
+	Host        string `json:"host"`
+	Port        int    `json:"port"`
+	ExternalUrl string `json:"externalUrl,omitempty"` // url as reachable by devices, e.g. behind a reverse proxy
 }
 
 func (c Network) HostPort() string {
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit)
+// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,ChargePlanner,Battery,Tariff,BatteryController,Circuit,VehicleClimateController,SocLimitSetter)
 //
 // Generated by this command:
 //
-//	mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit
+//	mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,ChargePlanner,Battery,Tariff,BatteryController,Circuit,VehicleClimateController,SocLimitSetter
 //
 
 // Package api is a generated GoMock package.
@@ -638,6 +638,44 @@ func (mr *MockChargeRaterMockRecorder) ChargedEnergy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargedEnergy", reflect.TypeOf((*MockChargeRater)(nil).ChargedEnergy))
 }
 
+// MockChargePlanner is a mock of ChargePlanner interface.
+type MockChargePlanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockChargePlannerMockRecorder
+	isgomock struct{}
+}
+
+// MockChargePlannerMockRecorder is the mock recorder for MockChargePlanner.
+type MockChargePlannerMockRecorder struct {
+	mock *MockChargePlanner
+}
+
+// NewMockChargePlanner creates a new mock instance.
+func NewMockChargePlanner(ctrl *gomock.Controller) *MockChargePlanner {
+	mock := &MockChargePlanner{ctrl: ctrl}
+	mock.recorder = &MockChargePlannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChargePlanner) EXPECT() *MockChargePlannerMockRecorder {
+	return m.recorder
+}
+
+// SetChargePlan mocks base method.
+func (m *MockChargePlanner) SetChargePlan(plan Rates, maxCurrent float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChargePlan", plan, maxCurrent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChargePlan indicates an expected call of SetChargePlan.
+func (mr *MockChargePlannerMockRecorder) SetChargePlan(plan, maxCurrent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChargePlan", reflect.TypeOf((*MockChargePlanner)(nil).SetChargePlan), plan, maxCurrent)
+}
+
 // MockBattery is a mock of Battery interface.
 type MockBattery struct {
 	ctrl     *gomock.Controller
@@ -792,6 +830,20 @@ func (m *MockCircuit) EXPECT() *MockCircuitMockRecorder {
 	return m.recorder
 }
 
+// Curtailed mocks base method.
+func (m *MockCircuit) Curtailed() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Curtailed")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Curtailed indicates an expected call of Curtailed.
+func (mr *MockCircuitMockRecorder) Curtailed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Curtailed", reflect.TypeOf((*MockCircuit)(nil).Curtailed))
+}
+
 // GetChargePower mocks base method.
 func (m *MockCircuit) GetChargePower() float64 {
 	m.ctrl.T.Helper()
@@ -862,6 +914,20 @@ func (mr *MockCircuitMockRecorder) GetParent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParent", reflect.TypeOf((*MockCircuit)(nil).GetParent))
 }
 
+// GetPhaseCurrents mocks base method.
+func (m *MockCircuit) GetPhaseCurrents() [3]float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhaseCurrents")
+	ret0, _ := ret[0].([3]float64)
+	return ret0
+}
+
+// GetPhaseCurrents indicates an expected call of GetPhaseCurrents.
+func (mr *MockCircuitMockRecorder) GetPhaseCurrents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhaseCurrents", reflect.TypeOf((*MockCircuit)(nil).GetPhaseCurrents))
+}
+
 // GetTitle mocks base method.
 func (m *MockCircuit) GetTitle() string {
 	m.ctrl.T.Helper()
@@ -902,6 +968,18 @@ func (mr *MockCircuitMockRecorder) RegisterChild(child any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterChild", reflect.TypeOf((*MockCircuit)(nil).RegisterChild), child)
 }
 
+// SetCurtailed mocks base method.
+func (m *MockCircuit) SetCurtailed(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCurtailed", arg0)
+}
+
+// SetCurtailed indicates an expected call of SetCurtailed.
+func (mr *MockCircuitMockRecorder) SetCurtailed(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurtailed", reflect.TypeOf((*MockCircuit)(nil).SetCurtailed), arg0)
+}
+
 // SetMaxCurrent mocks base method.
 func (m *MockCircuit) SetMaxCurrent(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -952,18 +1030,18 @@ func (mr *MockCircuitMockRecorder) Update(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCircuit)(nil).Update), arg0)
 }
 
-// ValidateCurrent mocks base method.
-func (m *MockCircuit) ValidateCurrent(old, new float64) float64 {
+// ValidatePhaseCurrents mocks base method.
+func (m *MockCircuit) ValidatePhaseCurrents(old, new [3]float64) float64 {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateCurrent", old, new)
+	ret := m.ctrl.Call(m, "ValidatePhaseCurrents", old, new)
 	ret0, _ := ret[0].(float64)
 	return ret0
 }
 
-// ValidateCurrent indicates an expected call of ValidateCurrent.
-func (mr *MockCircuitMockRecorder) ValidateCurrent(old, new any) *gomock.Call {
+// ValidatePhaseCurrents indicates an expected call of ValidatePhaseCurrents.
+func (mr *MockCircuitMockRecorder) ValidatePhaseCurrents(old, new any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCurrent", reflect.TypeOf((*MockCircuit)(nil).ValidateCurrent), old, new)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePhaseCurrents", reflect.TypeOf((*MockCircuit)(nil).ValidatePhaseCurrents), old, new)
 }
 
 // ValidatePower mocks base method.
@@ -993,3 +1071,79 @@ func (mr *MockCircuitMockRecorder) Wrap(parent any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wrap", reflect.TypeOf((*MockCircuit)(nil).Wrap), parent)
 }
+
+// MockVehicleClimateController is a mock of VehicleClimateController interface.
+type MockVehicleClimateController struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleClimateControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleClimateControllerMockRecorder is the mock recorder for MockVehicleClimateController.
+type MockVehicleClimateControllerMockRecorder struct {
+	mock *MockVehicleClimateController
+}
+
+// NewMockVehicleClimateController creates a new mock instance.
+func NewMockVehicleClimateController(ctrl *gomock.Controller) *MockVehicleClimateController {
+	mock := &MockVehicleClimateController{ctrl: ctrl}
+	mock.recorder = &MockVehicleClimateControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleClimateController) EXPECT() *MockVehicleClimateControllerMockRecorder {
+	return m.recorder
+}
+
+// Climatize mocks base method.
+func (m *MockVehicleClimateController) Climatize(enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Climatize", enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Climatize indicates an expected call of Climatize.
+func (mr *MockVehicleClimateControllerMockRecorder) Climatize(enable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Climatize", reflect.TypeOf((*MockVehicleClimateController)(nil).Climatize), enable)
+}
+
+// MockSocLimitSetter is a mock of SocLimitSetter interface.
+type MockSocLimitSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSocLimitSetterMockRecorder
+	isgomock struct{}
+}
+
+// MockSocLimitSetterMockRecorder is the mock recorder for MockSocLimitSetter.
+type MockSocLimitSetterMockRecorder struct {
+	mock *MockSocLimitSetter
+}
+
+// NewMockSocLimitSetter creates a new mock instance.
+func NewMockSocLimitSetter(ctrl *gomock.Controller) *MockSocLimitSetter {
+	mock := &MockSocLimitSetter{ctrl: ctrl}
+	mock.recorder = &MockSocLimitSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSocLimitSetter) EXPECT() *MockSocLimitSetterMockRecorder {
+	return m.recorder
+}
+
+// SetLimitSoc mocks base method.
+func (m *MockSocLimitSetter) SetLimitSoc(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimitSoc", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLimitSoc indicates an expected call of SetLimitSoc.
+func (mr *MockSocLimitSetterMockRecorder) SetLimitSoc(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimitSoc", reflect.TypeOf((*MockSocLimitSetter)(nil).SetLimitSoc), arg0)
+}
@@ -7,4 +7,5 @@ const (
 	ReasonUnknown Reason = iota
 	ReasonWaitingForAuthorization
 	ReasonDisconnectRequired
+	ReasonVehicleLimit
 )
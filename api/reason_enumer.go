@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _ReasonName = "unknownwaitingforauthorizationdisconnectrequired"
+const _ReasonName = "unknownwaitingforauthorizationdisconnectrequiredvehiclelimit"
 
-var _ReasonIndex = [...]uint8{0, 7, 30, 48}
+var _ReasonIndex = [...]uint8{0, 7, 30, 48, 60}
 
-const _ReasonLowerName = "unknownwaitingforauthorizationdisconnectrequired"
+const _ReasonLowerName = "unknownwaitingforauthorizationdisconnectrequiredvehiclelimit"
 
 func (i Reason) String() string {
 	if i < 0 || i >= Reason(len(_ReasonIndex)-1) {
@@ -27,9 +27,10 @@ func _ReasonNoOp() {
 	_ = x[ReasonUnknown-(0)]
 	_ = x[ReasonWaitingForAuthorization-(1)]
 	_ = x[ReasonDisconnectRequired-(2)]
+	_ = x[ReasonVehicleLimit-(3)]
 }
 
-var _ReasonValues = []Reason{ReasonUnknown, ReasonWaitingForAuthorization, ReasonDisconnectRequired}
+var _ReasonValues = []Reason{ReasonUnknown, ReasonWaitingForAuthorization, ReasonDisconnectRequired, ReasonVehicleLimit}
 
 var _ReasonNameToValueMap = map[string]Reason{
 	_ReasonName[0:7]:        ReasonUnknown,
@@ -38,12 +39,15 @@ var _ReasonNameToValueMap = map[string]Reason{
 	_ReasonLowerName[7:30]:  ReasonWaitingForAuthorization,
 	_ReasonName[30:48]:      ReasonDisconnectRequired,
 	_ReasonLowerName[30:48]: ReasonDisconnectRequired,
+	_ReasonName[48:60]:      ReasonVehicleLimit,
+	_ReasonLowerName[48:60]: ReasonVehicleLimit,
 }
 
 var _ReasonNames = []string{
 	_ReasonName[0:7],
 	_ReasonName[7:30],
 	_ReasonName[30:48],
+	_ReasonName[48:60],
 }
 
 // ReasonString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"errors"
+	"time"
+)
+
+// specificHeat is the specific heat capacity of water in kWh/(l*K)
+const specificHeat = 4.186 / 3600
+
+// ThermalModel describes the thermal storage of a heating device
+type ThermalModel struct {
+	Volume   float64 `json:"volume"`   // storage volume in l
+	MinTemp  float64 `json:"minTemp"`  // minimum temperature in °C, maintained by the device
+	MaxTemp  float64 `json:"maxTemp"`  // maximum temperature in °C
+	HeatLoss float64 `json:"heatLoss"` // standby temperature loss in K/h
+	Cop      float64 `json:"cop"`      // coefficient of performance, 1 for heating rods
+}
+
+// Validate checks the model for plausibility
+func (m ThermalModel) Validate() error {
+	if m.Volume <= 0 {
+		return errors.New("missing volume")
+	}
+	if m.MaxTemp <= m.MinTemp {
+		return errors.New("max temp must be above min temp")
+	}
+	if m.HeatLoss < 0 || m.Cop < 0 {
+		return errors.New("heat loss and cop must not be negative")
+	}
+	return nil
+}
+
+// Capacity returns the thermal capacity in kWh/K
+func (m ThermalModel) Capacity() float64 {
+	return m.Volume * specificHeat
+}
+
+// cop returns the coefficient of performance, defaulting to heating rod efficiency
+func (m ThermalModel) cop() float64 {
+	if m.Cop > 0 {
+		return m.Cop
+	}
+	return 1
+}
+
+// StoredEnergy returns the thermal energy in kWh stored above minimum temperature
+func (m ThermalModel) StoredEnergy(temp float64) float64 {
+	return max(0, m.Capacity()*(min(temp, m.MaxTemp)-m.MinTemp))
+}
+
+// Energy returns the electrical energy in kWh required for heating from temp to goal
+func (m ThermalModel) Energy(temp, goal float64) float64 {
+	return max(0, m.Capacity()*(min(goal, m.MaxTemp)-temp)/m.cop())
+}
+
+// Temperature returns the expected temperature after given duration with standby losses.
+// The device is expected to maintain its minimum temperature on its own.
+func (m ThermalModel) Temperature(temp float64, d time.Duration) float64 {
+	if d <= 0 || temp <= m.MinTemp {
+		return temp
+	}
+	return max(m.MinTemp, temp-m.HeatLoss*d.Hours())
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThermalModel(t *testing.T) {
+	m := ThermalModel{Volume: 300, MinTemp: 40, MaxTemp: 60, HeatLoss: 0.5, Cop: 3}
+	assert.NoError(t, m.Validate())
+
+	// 300l water store ~0.35kWh/K
+	assert.InDelta(t, 0.349, m.Capacity(), 1e-3)
+	assert.InDelta(t, 3.49, m.StoredEnergy(50), 1e-2)
+
+	// heating 10K at cop 3, goal limited to max temp
+	assert.InDelta(t, 1.163, m.Energy(50, 60), 1e-3)
+	assert.Equal(t, m.Energy(50, 60), m.Energy(50, 70))
+	assert.Zero(t, m.Energy(60, 55))
+
+	// heating rod
+	m.Cop = 0
+	assert.InDelta(t, 3.488, m.Energy(50, 60), 1e-3)
+
+	// standby losses limited by min temp
+	assert.Equal(t, 48.0, m.Temperature(50, 4*time.Hour))
+	assert.Equal(t, 40.0, m.Temperature(50, 48*time.Hour))
+	assert.Equal(t, 35.0, m.Temperature(35, 4*time.Hour))
+
+	assert.Error(t, ThermalModel{Volume: 300, MinTemp: 60, MaxTemp: 40}.Validate())
+	assert.Error(t, ThermalModel{MinTemp: 40, MaxTemp: 60}.Validate())
+}
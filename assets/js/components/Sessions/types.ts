@@ -14,6 +14,9 @@ export interface Session {
   price: number | null;
   pricePerKWh: number | null;
   co2PerKWh?: number | null;
+  tempStart?: number | null;
+  tempStop?: number | null;
+  thermalEnergy?: number | null;
 }
 
 export interface Legend {
@@ -0,0 +1,194 @@
+package charger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/plugin"
+	"github.com/evcc-io/evcc/util"
+)
+
+func init() {
+	registry.AddCtx("appliance", NewApplianceFromConfig)
+}
+
+// Appliance is a switch socket controlled deferrable one-shot load like a washing machine or dishwasher.
+// Once the appliance program has started, the relay stays on until standby power is detected.
+type Appliance struct {
+	*SwitchSocket
+	log   *util.Logger
+	clock clock.Clock
+
+	power        func() (float64, error)
+	standbyPower float64
+	standbyDelay time.Duration
+	duration     time.Duration
+
+	running bool      // program running
+	active  time.Time // last time power above standby was measured
+	disable bool      // disable requested while program running
+}
+
+//go:generate go tool decorate -f decorateAppliance -b *Appliance -r api.Charger -t "api.MeterEnergy,TotalEnergy,func() (float64, error)"
+
+// NewApplianceFromConfig creates an appliance charger from generic config
+func NewApplianceFromConfig(ctx context.Context, other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		embed        `mapstructure:",squash"`
+		Enabled      plugin.Config
+		Enable       plugin.Config
+		Power        plugin.Config
+		Energy       *plugin.Config
+		StandbyPower float64
+		StandbyDelay time.Duration
+		Duration     time.Duration
+	}{
+		embed: embed{
+			Icon_:     "laundry",
+			Features_: []api.Feature{api.IntegratedDevice},
+		},
+		StandbyPower: 5,
+		StandbyDelay: 5 * time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.StandbyPower <= 0 {
+		return nil, errors.New("appliance requires positive standby power")
+	}
+
+	enabled, err := cc.Enabled.BoolGetter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enable, err := cc.Enable.BoolSetter(ctx, "enable")
+	if err != nil {
+		return nil, err
+	}
+
+	power, err := cc.Power.FloatGetter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	energy, err := cc.Energy.FloatGetter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewAppliance(&cc.embed, enabled, enable, power, cc.StandbyPower, cc.StandbyDelay, cc.Duration)
+
+	return decorateAppliance(res, energy), nil
+}
+
+// NewAppliance creates an appliance charger
+func NewAppliance(
+	embed *embed,
+	enabled func() (bool, error),
+	enable func(bool) error,
+	power func() (float64, error),
+	standbyPower float64,
+	standbyDelay, duration time.Duration,
+) *Appliance {
+	return &Appliance{
+		SwitchSocket: &SwitchSocket{
+			enabled:      enabled,
+			enable:       enable,
+			switchSocket: NewSwitchSocket(embed, enabled, power, standbyPower),
+		},
+		log:          util.NewLogger("appliance"),
+		clock:        clock.New(),
+		power:        power,
+		standbyPower: standbyPower,
+		standbyDelay: standbyDelay,
+		duration:     duration,
+	}
+}
+
+// update tracks the program state and switches the relay off once a deferred disable is due
+func (c *Appliance) update() error {
+	power, err := c.power()
+	if err != nil {
+		return err
+	}
+
+	if power > c.standbyPower {
+		if !c.running {
+			c.log.DEBUG.Println("program started")
+		}
+
+		c.running = true
+		c.active = c.clock.Now()
+
+		return nil
+	}
+
+	if !c.running || c.clock.Since(c.active) < c.standbyDelay {
+		return nil
+	}
+
+	c.log.DEBUG.Println("program finished")
+	c.running = false
+
+	if c.disable {
+		c.disable = false
+		return c.enable(false)
+	}
+
+	return nil
+}
+
+// Status implements the api.Charger interface
+func (c *Appliance) Status() (api.ChargeStatus, error) {
+	if err := c.update(); err != nil {
+		return api.StatusNone, err
+	}
+
+	return c.SwitchSocket.Status()
+}
+
+// Enabled implements the api.Charger interface
+func (c *Appliance) Enabled() (bool, error) {
+	// report requested state while disable is deferred
+	if c.disable {
+		return false, nil
+	}
+
+	return c.SwitchSocket.Enabled()
+}
+
+// Enable implements the api.Charger interface
+func (c *Appliance) Enable(enable bool) error {
+	// never interrupt a running program
+	if !enable && c.running {
+		if !c.disable {
+			c.log.DEBUG.Println("program running- deferring disable until standby")
+		}
+
+		c.disable = true
+
+		return nil
+	}
+
+	c.disable = false
+
+	return c.enable(enable)
+}
+
+var _ api.DeferrableLoad = (*Appliance)(nil)
+
+// ProgramDuration implements the api.DeferrableLoad interface
+func (c *Appliance) ProgramDuration() time.Duration {
+	return c.duration
+}
+
+// ProgramRunning implements the api.DeferrableLoad interface
+func (c *Appliance) ProgramRunning() bool {
+	return c.running
+}
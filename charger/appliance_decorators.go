@@ -0,0 +1,35 @@
+package charger
+
+// Code generated by github.com/evcc-io/evcc/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateAppliance(base *Appliance, meterEnergy func() (float64, error)) api.Charger {
+	switch {
+	case meterEnergy == nil:
+		return base
+
+	case meterEnergy != nil:
+		return &struct {
+			*Appliance
+			api.MeterEnergy
+		}{
+			Appliance: base,
+			MeterEnergy: &decorateApplianceMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateApplianceMeterEnergyImpl struct {
+	meterEnergy func() (float64, error)
+}
+
+func (impl *decorateApplianceMeterEnergyImpl) TotalEnergy() (float64, error) {
+	return impl.meterEnergy()
+}
@@ -0,0 +1,65 @@
+package charger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplianceDeferDisable(t *testing.T) {
+	var (
+		on    bool
+		power float64
+	)
+
+	wb := NewAppliance(new(embed),
+		func() (bool, error) { return on, nil },
+		func(enable bool) error { on = enable; return nil },
+		func() (float64, error) { return power, nil },
+		5, 5*time.Minute, 2*time.Hour,
+	)
+
+	clk := clock.NewMock()
+	wb.clock = clk
+
+	status := func() api.ChargeStatus {
+		t.Helper()
+		res, err := wb.Status()
+		require.NoError(t, err)
+		return res
+	}
+
+	// relay on, program not started yet
+	require.NoError(t, wb.Enable(true))
+	assert.Equal(t, api.StatusB, status())
+	assert.False(t, wb.ProgramRunning())
+
+	// program running, disable deferred
+	power = 2000
+	assert.Equal(t, api.StatusC, status())
+	assert.True(t, wb.ProgramRunning())
+
+	require.NoError(t, wb.Enable(false))
+	assert.True(t, on, "relay on")
+
+	enabled, err := wb.Enabled()
+	require.NoError(t, err)
+	assert.False(t, enabled, "enabled")
+
+	// short standby phase does not finish program
+	power = 2
+	clk.Add(time.Minute)
+	assert.Equal(t, api.StatusB, status())
+	assert.True(t, wb.ProgramRunning())
+	assert.True(t, on, "relay on")
+
+	// standby power detected
+	clk.Add(5 * time.Minute)
+	status()
+	assert.False(t, wb.ProgramRunning())
+	assert.False(t, on, "relay on")
+}
@@ -5,8 +5,9 @@ import (
 )
 
 type embed struct {
-	Icon_     string        `mapstructure:"icon"`
-	Features_ []api.Feature `mapstructure:"features"`
+	Icon_     string            `mapstructure:"icon"`
+	Features_ []api.Feature     `mapstructure:"features"`
+	Thermal_  *api.ThermalModel `mapstructure:"thermal"`
 }
 
 var _ api.IconDescriber = (*embed)(nil)
@@ -22,3 +23,13 @@ var _ api.FeatureDescriber = (*embed)(nil)
 func (v *embed) Features() []api.Feature {
 	return v.Features_
 }
+
+var _ api.ThermalStorage = (*embed)(nil)
+
+// ThermalModel implements the api.ThermalStorage interface
+func (v *embed) ThermalModel() (api.ThermalModel, error) {
+	if v.Thermal_ == nil {
+		return api.ThermalModel{}, api.ErrNotAvailable
+	}
+	return *v.Thermal_, nil
+}
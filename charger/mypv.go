@@ -41,6 +41,7 @@ type MyPv struct {
 	statusC uint16
 	enabled bool
 	regTemp uint16
+	thermal *api.ThermalModel
 }
 
 const (
@@ -70,6 +71,7 @@ func newMyPvFromConfig(ctx context.Context, name string, other map[string]interf
 		modbus.TcpSettings `mapstructure:",squash"`
 		TempSource         int
 		Scale              float64
+		Thermal            *api.ThermalModel
 	}{
 		TcpSettings: modbus.TcpSettings{
 			ID: 1, // default
@@ -82,11 +84,11 @@ func newMyPvFromConfig(ctx context.Context, name string, other map[string]interf
 		return nil, err
 	}
 
-	return NewMyPv(ctx, name, cc.URI, cc.ID, cc.TempSource, statusC, cc.Scale)
+	return NewMyPv(ctx, name, cc.URI, cc.ID, cc.TempSource, statusC, cc.Scale, cc.Thermal)
 }
 
 // NewMyPv creates myPV AC Elwa 2 or Thor charger
-func NewMyPv(ctx context.Context, name, uri string, slaveID uint8, tempSource int, statusC uint16, scale float64) (api.Charger, error) {
+func NewMyPv(ctx context.Context, name, uri string, slaveID uint8, tempSource int, statusC uint16, scale float64, thermal *api.ThermalModel) (api.Charger, error) {
 	conn, err := modbus.NewConnection(ctx, uri, "", "", 0, modbus.Tcp, slaveID)
 	if err != nil {
 		return nil, err
@@ -109,6 +111,7 @@ func NewMyPv(ctx context.Context, name, uri string, slaveID uint8, tempSource in
 		statusC: statusC,
 		scale:   scale,
 		regTemp: elwaTemp[tempSource-1],
+		thermal: thermal,
 	}
 
 	go wb.heartbeat(ctx, 30*time.Second)
@@ -263,6 +266,16 @@ func (wb *MyPv) GetLimitSoc() (int64, error) {
 	return int64(binary.BigEndian.Uint16(b)) / 10, nil
 }
 
+var _ api.ThermalStorage = (*MyPv)(nil)
+
+// ThermalModel implements the api.ThermalStorage interface
+func (wb *MyPv) ThermalModel() (api.ThermalModel, error) {
+	if wb.thermal == nil {
+		return api.ThermalModel{}, api.ErrNotAvailable
+	}
+	return *wb.thermal, nil
+}
+
 var _ loadpoint.Controller = (*MyPv)(nil)
 
 // LoadpointControl implements loadpoint.Controller
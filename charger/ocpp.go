@@ -20,6 +20,7 @@ package charger
 import (
 	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"slices"
@@ -46,6 +47,11 @@ type OCPP struct {
 
 	stackLevelZero bool
 	lp             loadpoint.API
+
+	plan         api.Rates // charge plan slots to be scheduled on the charge point
+	planCurrent  float64   // current for charging plan slots
+	planRefresh  time.Time // next schedule change, profile is refreshed afterwards
+	planRejected bool      // charge point does not accept multi-period schedules
 }
 
 const defaultIdTag = "evcc" // RemoteStartTransaction only
@@ -74,6 +80,7 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 		ForcePowerCtrl bool
 		StackLevelZero *bool
 		RemoteStart    bool
+		Whitelist      bool
 	}{
 		Connector:      1,
 		MeterInterval:  10 * time.Second,
@@ -89,7 +96,7 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 	c, err := NewOCPP(ctx,
 		cc.StationId, cc.Connector, cc.IdTag,
 		cc.MeterValues, cc.MeterInterval,
-		cc.ForcePowerCtrl, stackLevelZero, cc.RemoteStart,
+		cc.ForcePowerCtrl, stackLevelZero, cc.RemoteStart, cc.Whitelist,
 		cc.ConnectTimeout)
 	if err != nil {
 		return c, err
@@ -120,7 +127,8 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 		voltagesG = c.conn.Voltages
 	}
 
-	if c.cp.HasMeasurement(types.MeasurandSoC) {
+	// OCPP 2.0.1 may report soc via ISO 15118 charging needs
+	if c.cp.HasMeasurement(types.MeasurandSoC) || c.cp.IsOCPP201() {
 		socG = c.conn.Soc
 	}
 
@@ -143,7 +151,7 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 func NewOCPP(ctx context.Context,
 	id string, connector int, idTag string,
 	meterValues string, meterInterval time.Duration,
-	forcePowerCtrl, stackLevelZero, remoteStart bool,
+	forcePowerCtrl, stackLevelZero, remoteStart, whitelist bool,
 	connectTimeout time.Duration,
 ) (*OCPP, error) {
 	log := util.NewLogger(fmt.Sprintf("%s-%d", lo.CoalesceOrEmpty(id, "ocpp"), connector))
@@ -163,7 +171,7 @@ func NewOCPP(ctx context.Context,
 			case <-cp.HasConnected():
 			}
 
-			return cp.Setup(ctx, meterValues, meterInterval, forcePowerCtrl)
+			return cp.Setup(ctx, meterValues, meterInterval, forcePowerCtrl, whitelist)
 		},
 	)
 	if err != nil {
@@ -302,7 +310,28 @@ func (c *OCPP) Enable(enable bool) error {
 
 // setCurrent sets the TxDefaultChargingProfile with given current
 func (c *OCPP) setCurrent(current float64) error {
-	err := c.conn.SetChargingProfileRequest(c.createTxDefaultChargingProfile(math.Trunc(10*current) / 10))
+	current = math.Trunc(10*current) / 10
+
+	if len(c.plan) > 0 && !c.planRejected {
+		profile, refresh := c.createTxDefaultChargingSchedule(current)
+
+		err := c.conn.SetChargingProfileRequest(profile)
+		if err == nil {
+			c.planRefresh = refresh
+			return nil
+		}
+
+		if errors.Is(err, api.ErrTimeout) {
+			return fmt.Errorf("set charging profile: %w", err)
+		}
+
+		c.log.WARN.Printf("charging schedule not accepted, falling back to current limit: %v", err)
+		c.planRejected = true
+	}
+
+	c.planRefresh = time.Time{}
+
+	err := c.conn.SetChargingProfileRequest(c.createTxDefaultChargingProfile(current))
 	if err != nil {
 		err = fmt.Errorf("set charging profile: %w", err)
 	}
@@ -310,13 +339,13 @@ func (c *OCPP) setCurrent(current float64) error {
 	return err
 }
 
-// createTxDefaultChargingProfile returns a TxDefaultChargingProfile with given current
-func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingProfile {
+// createSchedulePeriod returns a ChargingSchedulePeriod with given start offset and current
+func (c *OCPP) createSchedulePeriod(start int, current float64) types.ChargingSchedulePeriod {
 	phases := c.phases
-	period := types.NewChargingSchedulePeriod(0, current)
+	period := types.NewChargingSchedulePeriod(start, current)
 
 	if c.cp.ChargingRateUnit == types.ChargingRateUnitWatts {
-		period = types.NewChargingSchedulePeriod(0, math.Trunc(230.0*current*float64(phases)))
+		period = types.NewChargingSchedulePeriod(start, math.Trunc(230.0*current*float64(phases)))
 	} else {
 		// OCPP assumes phases == 3 if not set
 		if phases != 0 {
@@ -325,6 +354,11 @@ func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingPr
 		}
 	}
 
+	return period
+}
+
+// createTxDefaultChargingProfile returns a TxDefaultChargingProfile with given current
+func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingProfile {
 	res := &types.ChargingProfile{
 		ChargingProfileId:      c.cp.ChargingProfileId,
 		ChargingProfilePurpose: types.ChargingProfilePurposeTxDefaultProfile,
@@ -332,7 +366,7 @@ func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingPr
 		ChargingSchedule: &types.ChargingSchedule{
 			StartSchedule:          types.NewDateTime(time.Now().Add(-time.Minute)),
 			ChargingRateUnit:       c.cp.ChargingRateUnit,
-			ChargingSchedulePeriod: []types.ChargingSchedulePeriod{period},
+			ChargingSchedulePeriod: []types.ChargingSchedulePeriod{c.createSchedulePeriod(0, current)},
 		},
 	}
 
@@ -343,6 +377,63 @@ func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingPr
 	return res
 }
 
+// createTxDefaultChargingSchedule returns a TxDefaultChargingProfile starting with given current
+// and following the charge plan afterwards. Plan slots are charged with the plan current, gaps are not charged.
+// The time of the first schedule change is returned alongside.
+func (c *OCPP) createTxDefaultChargingSchedule(current float64) (*types.ChargingProfile, time.Time) {
+	res := c.createTxDefaultChargingProfile(current)
+	schedule := res.ChargingSchedule
+
+	now := time.Now()
+	start := schedule.StartSchedule.Time
+	limit := current
+
+	var refresh time.Time
+
+	add := func(ts time.Time, current float64) {
+		if current == limit {
+			return
+		}
+
+		if c.cp.ChargingScheduleMaxPeriods > 0 && len(schedule.ChargingSchedulePeriod) >= c.cp.ChargingScheduleMaxPeriods {
+			return
+		}
+
+		schedule.ChargingSchedulePeriod = append(schedule.ChargingSchedulePeriod, c.createSchedulePeriod(int(ts.Sub(start).Seconds()), current))
+		limit = current
+
+		if refresh.IsZero() {
+			refresh = ts
+		}
+	}
+
+	for _, slot := range mergeSlots(c.plan) {
+		// active slot is controlled by the current limit
+		if slot.Start.After(now) {
+			add(slot.Start, c.planCurrent)
+		}
+		add(slot.End, 0)
+	}
+
+	return res, refresh
+}
+
+// mergeSlots joins adjacent plan slots
+func mergeSlots(plan api.Rates) api.Rates {
+	var res api.Rates
+
+	for _, slot := range plan {
+		if n := len(res); n > 0 && !slot.Start.After(res[n-1].End) {
+			res[n-1].End = slot.End
+			continue
+		}
+
+		res = append(res, api.Rate{Start: slot.Start, End: slot.End})
+	}
+
+	return res
+}
+
 // MaxCurrent implements the api.Charger interface
 func (c *OCPP) MaxCurrent(current int64) error {
 	return c.MaxCurrentMillis(float64(current))
@@ -376,6 +467,42 @@ func (c *OCPP) phases1p3p(phases int) error {
 	return c.setCurrent(current)
 }
 
+var _ api.ChargePlanner = (*OCPP)(nil)
+
+// SetChargePlan implements the api.ChargePlanner interface
+func (c *OCPP) SetChargePlan(plan api.Rates, maxCurrent float64) error {
+	if c.planRejected {
+		return nil
+	}
+
+	now := time.Now()
+	plan = slices.DeleteFunc(slices.Clone(plan), func(slot api.Rate) bool {
+		return !slot.End.After(now)
+	})
+
+	// refresh profile if the plan has changed or the schedule has progressed
+	if slices.EqualFunc(plan, c.plan, func(a, b api.Rate) bool {
+		return a.Start.Equal(b.Start) && a.End.Equal(b.End)
+	}) && maxCurrent == c.planCurrent && (c.planRefresh.IsZero() || now.Before(c.planRefresh)) {
+		return nil
+	}
+
+	c.plan = plan
+	c.planCurrent = maxCurrent
+
+	enabled, err := c.Enabled()
+	if err != nil {
+		return err
+	}
+
+	var current float64
+	if enabled {
+		current = c.current
+	}
+
+	return c.setCurrent(current)
+}
+
 var _ api.Identifier = (*OCPP)(nil)
 
 // Identify implements the api.Identifier interface
@@ -389,12 +516,12 @@ var _ api.Diagnosis = (*OCPP)(nil)
 func (c *OCPP) Diagnose() {
 	fmt.Printf("\tCharge Point ID: %s\n", c.cp.ID())
 
-	if c.cp.BootNotificationResult != nil {
+	if boot := c.cp.BootNotification(); boot != nil {
 		fmt.Printf("\tBoot Notification:\n")
-		fmt.Printf("\t\tChargePointVendor: %s\n", c.cp.BootNotificationResult.ChargePointVendor)
-		fmt.Printf("\t\tChargePointModel: %s\n", c.cp.BootNotificationResult.ChargePointModel)
-		fmt.Printf("\t\tChargePointSerialNumber: %s\n", c.cp.BootNotificationResult.ChargePointSerialNumber)
-		fmt.Printf("\t\tFirmwareVersion: %s\n", c.cp.BootNotificationResult.FirmwareVersion)
+		fmt.Printf("\t\tChargePointVendor: %s\n", boot.ChargePointVendor)
+		fmt.Printf("\t\tChargePointModel: %s\n", boot.ChargePointModel)
+		fmt.Printf("\t\tChargePointSerialNumber: %s\n", boot.ChargePointSerialNumber)
+		fmt.Printf("\t\tFirmwareVersion: %s\n", boot.FirmwareVersion)
 	}
 
 	fmt.Printf("\tConfiguration:\n")
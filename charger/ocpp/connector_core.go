@@ -1,6 +1,7 @@
 package ocpp
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -89,6 +90,8 @@ func (conn *Connector) OnMeterValues(request *core.MeterValuesRequest) (*core.Me
 }
 
 func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest) (*core.StartTransactionConfirmation, error) {
+	status := conn.cp.authorize(request.IdTag)
+
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
@@ -97,7 +100,7 @@ func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest)
 
 	res := &core.StartTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: status,
 		},
 		TransactionId: conn.txnId,
 	}
@@ -105,6 +108,26 @@ func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest)
 	return res, nil
 }
 
+// setIdTag updates the id tag of a running transaction
+func (conn *Connector) setIdTag(idTag string) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.idTag = idTag
+}
+
+// setSoc stores the vehicle soc reported outside of meter values, e.g. via ISO 15118
+func (conn *Connector) setSoc(soc int) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.measurements[types.MeasurandSoC] = types.SampledValue{
+		Measurand: types.MeasurandSoC,
+		Value:     strconv.Itoa(soc),
+		Unit:      types.UnitOfMeasurePercent,
+	}
+}
+
 func (conn *Connector) assumeMeterStopped() {
 	conn.meterUpdated = conn.clock.Now()
 
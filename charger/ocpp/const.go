@@ -13,9 +13,14 @@ const (
 	KeySupportedFeatureProfiles        = "SupportedFeatureProfiles"
 	KeyWebSocketPingInterval           = "WebSocketPingInterval"
 
+	// LocalAuthListManagement profile keys
+	KeyLocalAuthListEnabled   = "LocalAuthListEnabled"
+	KeyLocalAuthListMaxLength = "LocalAuthListMaxLength"
+
 	// SmartCharging profile keys
 	KeyChargeProfileMaxStackLevel              = "ChargeProfileMaxStackLevel"
 	KeyChargingScheduleAllowedChargingRateUnit = "ChargingScheduleAllowedChargingRateUnit"
+	KeyChargingScheduleMaxPeriods              = "ChargingScheduleMaxPeriods"
 	KeyConnectorSwitch3to1PhaseSupported       = "ConnectorSwitch3to1PhaseSupported"
 	KeyMaxChargingProfilesInstalled            = "MaxChargingProfilesInstalled"
 
@@ -6,7 +6,9 @@ import (
 
 	"github.com/evcc-io/evcc/util"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
 )
 
 // Since ocpp-go interfaces at charge point level, we need to manage multiple connector separately
@@ -17,26 +19,36 @@ type CP struct {
 	onceConnect sync.Once
 	onceBoot    sync.Once
 
-	id string
+	id       string
+	protocol string
 
 	connected bool
 	connectC  chan struct{}
 	meterC    chan struct{}
 
 	// configuration properties
-	PhaseSwitching          bool
-	HasRemoteTriggerFeature bool
-	ChargingRateUnit        types.ChargingRateUnitType
-	ChargingProfileId       int
-	StackLevel              int
-	NumberOfConnectors      int
-	IdTag                   string
+	PhaseSwitching             bool
+	HasRemoteTriggerFeature    bool
+	HasLocalAuthListFeature    bool
+	LocalAuthListMaxLength     int
+	ChargingRateUnit           types.ChargingRateUnitType
+	ChargingProfileId          int
+	StackLevel                 int
+	ChargingScheduleMaxPeriods int
+	NumberOfConnectors         int
+	IdTag                      string
 
 	meterValuesSample        string
 	bootNotificationRequestC chan *core.BootNotificationRequest
 	BootNotificationResult   *core.BootNotificationRequest
 
 	connectors map[int]*Connector
+
+	whitelist     bool   // reject tags not on the local authorization list
+	localListHash uint64 // hash of the local authorization list last sent
+
+	diagnosticsStatus firmware.DiagnosticsStatus
+	firmwareStatus    firmware.FirmwareStatus
 }
 
 func NewChargePoint(log *util.Logger, id string) *CP {
@@ -52,6 +64,7 @@ func NewChargePoint(log *util.Logger, id string) *CP {
 
 		ChargingRateUnit:        "A",
 		HasRemoteTriggerFeature: true, // assume remote trigger feature is available
+		HasLocalAuthListFeature: true, // assume local auth list feature is available
 	}
 }
 
@@ -67,6 +80,14 @@ func (cp *CP) registerConnector(id int, conn *Connector) error {
 	return nil
 }
 
+// BootNotification returns the charge point's boot notification
+func (cp *CP) BootNotification() *core.BootNotificationRequest {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.BootNotificationResult
+}
+
 func (cp *CP) connectorByID(id int) *Connector {
 	cp.mu.RLock()
 	defer cp.mu.RUnlock()
@@ -105,6 +126,21 @@ func (cp *CP) RegisterID(id string) {
 	cp.id = id
 }
 
+func (cp *CP) setProtocol(protocol string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.protocol = protocol
+}
+
+// IsOCPP201 returns true if the charge point is connected using OCPP 2.0.1
+func (cp *CP) IsOCPP201() bool {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.protocol == types201.V201Subprotocol
+}
+
 func (cp *CP) connect(connect bool) {
 	cp.mu.Lock()
 	defer cp.mu.Unlock()
@@ -13,6 +13,20 @@ var (
 	ErrInvalidTransaction = errors.New("invalid transaction")
 )
 
+func (cp *CP) OnAuthorize(request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	res := &core.AuthorizeConfirmation{
+		IdTagInfo: &types.IdTagInfo{
+			Status: cp.authorize(request.IdTag),
+		},
+	}
+
+	return res, nil
+}
+
 func (cp *CP) OnBootNotification(request *core.BootNotificationRequest) (*core.BootNotificationConfirmation, error) {
 	res := &core.BootNotificationConfirmation{
 		CurrentTime: types.Now(),
@@ -68,7 +82,7 @@ func (cp *CP) OnStartTransaction(request *core.StartTransactionRequest) (*core.S
 
 	res := &core.StartTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: cp.authorize(request.IdTag),
 		},
 	}
 
@@ -0,0 +1,49 @@
+package ocpp
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+)
+
+func (cp *CP) OnDiagnosticsStatusNotification(request *firmware.DiagnosticsStatusNotificationRequest) (*firmware.DiagnosticsStatusNotificationConfirmation, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	cp.mu.Lock()
+	cp.diagnosticsStatus = request.Status
+	cp.mu.Unlock()
+
+	cp.log.DEBUG.Printf("diagnostics status: %s", request.Status)
+
+	return new(firmware.DiagnosticsStatusNotificationConfirmation), nil
+}
+
+func (cp *CP) OnFirmwareStatusNotification(request *firmware.FirmwareStatusNotificationRequest) (*firmware.FirmwareStatusNotificationConfirmation, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	cp.mu.Lock()
+	cp.firmwareStatus = request.Status
+	cp.mu.Unlock()
+
+	cp.log.DEBUG.Printf("firmware status: %s", request.Status)
+
+	return new(firmware.FirmwareStatusNotificationConfirmation), nil
+}
+
+// DiagnosticsStatus returns the last reported diagnostics upload status
+func (cp *CP) DiagnosticsStatus() firmware.DiagnosticsStatus {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.diagnosticsStatus
+}
+
+// FirmwareStatus returns the last reported firmware update status
+func (cp *CP) FirmwareStatus() firmware.FirmwareStatus {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.firmwareStatus
+}
@@ -0,0 +1,217 @@
+package ocpp
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	localauth201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/samber/lo"
+)
+
+// localListInterval is the interval for checking the local authorization list for changes
+const localListInterval = time.Minute
+
+// Authorizer provides the id tags of the local authorization list.
+// Tags may contain * placeholders.
+type Authorizer interface {
+	AuthorizedTags() []string
+}
+
+var auth struct {
+	mu         sync.Mutex
+	authorizer Authorizer
+	tags       []string
+	updated    time.Time
+}
+
+// SetAuthorizer sets the provider of the local authorization list
+func SetAuthorizer(authorizer Authorizer) {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	auth.authorizer = authorizer
+	auth.updated = time.Time{}
+}
+
+// authorizedTags returns the tags of the local authorization list.
+// Tags are cached for the local list interval.
+func authorizedTags() []string {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	if auth.authorizer == nil {
+		return nil
+	}
+
+	if time.Since(auth.updated) >= localListInterval {
+		tags := lo.Filter(auth.authorizer.AuthorizedTags(), func(tag string, _ int) bool {
+			return strings.TrimSpace(tag) != ""
+		})
+
+		slices.Sort(tags)
+		auth.tags = slices.Compact(tags)
+		auth.updated = time.Now()
+	}
+
+	return auth.tags
+}
+
+// matchTag checks if the id tag matches any of the authorized tags
+func matchTag(tags []string, idTag string) bool {
+	for _, tag := range tags {
+		if strings.EqualFold(tag, idTag) {
+			return true
+		}
+
+		if strings.Contains(tag, "*") {
+			re, err := regexp.Compile("(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(tag), `\*`, ".*?") + "$")
+			if err == nil && re.MatchString(idTag) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// authorize checks the id tag against the local authorization list if whitelisting is enabled.
+// The connectors' remote start id tags are always accepted.
+func (cp *CP) authorize(idTag string) types.AuthorizationStatus {
+	cp.mu.RLock()
+	whitelist := cp.whitelist
+	connectors := lo.Values(cp.connectors)
+	cp.mu.RUnlock()
+
+	remote := lo.Map(connectors, func(conn *Connector, _ int) string {
+		conn.mu.Lock()
+		defer conn.mu.Unlock()
+		return conn.remoteIdTag
+	})
+
+	if !whitelist || idTag != "" && slices.Contains(remote, idTag) || matchTag(authorizedTags(), idTag) {
+		return types.AuthorizationStatusAccepted
+	}
+
+	cp.log.WARN.Printf("rejecting unknown id tag: %s", idTag)
+
+	return types.AuthorizationStatusInvalid
+}
+
+// syncLocalList sends the local authorization list whenever it has changed.
+// Must be wrapped in a goroutine.
+func (cp *CP) syncLocalList(ctx context.Context) {
+	tick := time.NewTicker(localListInterval)
+	defer tick.Stop()
+
+	for {
+		if cp.Connected() {
+			if err := cp.updateLocalList(); err != nil {
+				cp.log.WARN.Printf("local authorization list: %v", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// updateLocalList sends the local authorization list if it differs from the last one sent
+func (cp *CP) updateLocalList() error {
+	if !cp.hasLocalAuthList() {
+		return nil
+	}
+
+	// placeholders cannot be sent to the charge point
+	tags := lo.Reject(authorizedTags(), func(tag string, _ int) bool {
+		return strings.Contains(tag, "*")
+	})
+
+	if maxLength := cp.LocalAuthListMaxLength; maxLength > 0 && len(tags) > maxLength {
+		cp.log.WARN.Printf("local authorization list exceeds max length, truncating to %d tags", maxLength)
+		tags = tags[:maxLength]
+	}
+
+	h := fnv.New64a()
+	for _, tag := range tags {
+		h.Write([]byte(tag + "\n"))
+	}
+
+	cp.mu.RLock()
+	unchanged := cp.localListHash == h.Sum64()
+	cp.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	if err := cp.SendLocalListRequest(int(time.Now().Unix()), tags); err != nil {
+		if errors.Is(err, api.ErrNotAvailable) {
+			cp.log.DEBUG.Println("local authorization list not supported")
+			cp.setLocalAuthList(false)
+			return nil
+		}
+		return err
+	}
+
+	cp.mu.Lock()
+	cp.localListHash = h.Sum64()
+	cp.mu.Unlock()
+
+	cp.log.DEBUG.Printf("local authorization list: sent %d tags", len(tags))
+
+	return nil
+}
+
+// hasLocalAuthList returns if the charge point supports the local authorization list
+func (cp *CP) hasLocalAuthList() bool {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+	return cp.HasLocalAuthListFeature
+}
+
+// setLocalAuthList sets if the charge point supports the local authorization list
+func (cp *CP) setLocalAuthList(val bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.HasLocalAuthListFeature = val
+}
+
+// localList returns the OCPP 1.6 local authorization list for given tags
+func localList(tags []string) []localauth.AuthorizationData {
+	return lo.FilterMap(tags, func(tag string, _ int) (localauth.AuthorizationData, bool) {
+		return localauth.AuthorizationData{
+			IdTag: tag,
+			IdTagInfo: &types.IdTagInfo{
+				Status: types.AuthorizationStatusAccepted,
+			},
+		}, len(tag) <= 20 // CiString20Type
+	})
+}
+
+// localList201 returns the OCPP 2.0.1 local authorization list for given tags
+func localList201(tags []string) []localauth201.AuthorizationData {
+	return lo.Map(tags, func(tag string, _ int) localauth201.AuthorizationData {
+		return localauth201.AuthorizationData{
+			IdToken: types201.IdToken{
+				IdToken: tag,
+				Type:    types201.IdTokenTypeISO14443,
+			},
+			IdTokenInfo: &types201.IdTokenInfo{
+				Status: types201.AuthorizationStatusAccepted,
+			},
+		}
+	})
+}
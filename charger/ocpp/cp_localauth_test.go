@@ -0,0 +1,35 @@
+package ocpp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAuthorizer struct {
+	tags  []string
+	calls int
+}
+
+func (a *testAuthorizer) AuthorizedTags() []string {
+	a.calls++
+	return a.tags
+}
+
+func TestAuthorizedTags(t *testing.T) {
+	a := &testAuthorizer{tags: []string{"b", "a", " ", "b"}}
+	SetAuthorizer(a)
+	defer SetAuthorizer(nil)
+
+	assert.Equal(t, []string{"a", "b"}, authorizedTags())
+	assert.Equal(t, []string{"a", "b"}, authorizedTags())
+	assert.Equal(t, 1, a.calls, "tags not cached")
+}
+
+func TestMatchTag(t *testing.T) {
+	tags := []string{"AB12", "vin:WVW*"}
+
+	assert.True(t, matchTag(tags, "ab12"))
+	assert.True(t, matchTag(tags, "vin:WVWZZZ123"))
+	assert.False(t, matchTag(tags, "CD34"))
+}
@@ -2,14 +2,22 @@ package ocpp
 
 import (
 	"errors"
+	"time"
 
+	"github.com/evcc-io/evcc/api"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 )
 
 func (cp *CP) ChangeAvailabilityRequest(connectorId int, availabilityType core.AvailabilityType) error {
+	if cp.IsOCPP201() {
+		return cp.changeAvailability201(connectorId, availabilityType)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().ChangeAvailability(cp.id, func(request *core.ChangeAvailabilityConfirmation, err error) {
@@ -24,6 +32,10 @@ func (cp *CP) ChangeAvailabilityRequest(connectorId int, availabilityType core.A
 }
 
 func (cp *CP) GetCompositeScheduleRequest(connectorId int, duration int) (*smartcharging.GetCompositeScheduleConfirmation, error) {
+	if cp.IsOCPP201() {
+		return cp.getCompositeSchedule201(connectorId, duration)
+	}
+
 	var res *smartcharging.GetCompositeScheduleConfirmation
 	rc := make(chan error, 1)
 
@@ -41,6 +53,10 @@ func (cp *CP) GetCompositeScheduleRequest(connectorId int, duration int) (*smart
 }
 
 func (cp *CP) RemoteStartTransactionRequest(connectorId int, idTag string) error {
+	if cp.IsOCPP201() {
+		return cp.requestStartTransaction201(connectorId, idTag)
+	}
+
 	rc := make(chan error, 1)
 	err := Instance().RemoteStartTransaction(cp.id, func(request *core.RemoteStartTransactionConfirmation, err error) {
 		if err == nil && request != nil && request.Status != types.RemoteStartStopStatusAccepted {
@@ -58,6 +74,10 @@ func (cp *CP) RemoteStartTransactionRequest(connectorId int, idTag string) error
 }
 
 func (cp *CP) SetChargingProfileRequest(connectorId int, profile *types.ChargingProfile) error {
+	if cp.IsOCPP201() {
+		return cp.setChargingProfile201(connectorId, profile)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().SetChargingProfile(cp.id, func(request *smartcharging.SetChargingProfileConfirmation, err error) {
@@ -72,6 +92,10 @@ func (cp *CP) SetChargingProfileRequest(connectorId int, profile *types.Charging
 }
 
 func (cp *CP) TriggerMessageRequest(connectorId int, requestedMessage remotetrigger.MessageTrigger) error {
+	if cp.IsOCPP201() {
+		return cp.triggerMessage201(connectorId, requestedMessage)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().TriggerMessage(cp.id, func(request *remotetrigger.TriggerMessageConfirmation, err error) {
@@ -90,6 +114,10 @@ func (cp *CP) TriggerMessageRequest(connectorId int, requestedMessage remotetrig
 }
 
 func (cp *CP) ChangeConfigurationRequest(key, value string) error {
+	if cp.IsOCPP201() {
+		return cp.setVariable201(key, value)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().ChangeConfiguration(cp.id, func(request *core.ChangeConfigurationConfirmation, err error) {
@@ -104,6 +132,10 @@ func (cp *CP) ChangeConfigurationRequest(key, value string) error {
 }
 
 func (cp *CP) GetConfigurationRequest() (*core.GetConfigurationConfirmation, error) {
+	if cp.IsOCPP201() {
+		return cp.getVariables201()
+	}
+
 	rc := make(chan error, 1)
 
 	var res *core.GetConfigurationConfirmation
@@ -115,3 +147,79 @@ func (cp *CP) GetConfigurationRequest() (*core.GetConfigurationConfirmation, err
 
 	return res, wait(err, rc)
 }
+
+func (cp *CP) SendLocalListRequest(version int, tags []string) error {
+	if cp.IsOCPP201() {
+		return cp.sendLocalList201(version, tags)
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().SendLocalList(cp.id, func(request *localauth.SendLocalListConfirmation, err error) {
+		if err == nil && request != nil && request.Status != localauth.UpdateStatusAccepted {
+			err = errors.New(string(request.Status))
+			if request.Status == localauth.UpdateStatusNotSupported {
+				err = api.ErrNotAvailable
+			}
+		}
+
+		rc <- notSupported(err)
+	}, version, localauth.UpdateTypeFull, func(request *localauth.SendLocalListRequest) {
+		request.LocalAuthorizationList = localList(tags)
+	})
+
+	return wait(notSupported(err), rc)
+}
+
+func (cp *CP) ResetRequest(resetType core.ResetType) error {
+	if cp.IsOCPP201() {
+		return cp.reset201(resetType)
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().Reset(cp.id, func(request *core.ResetConfirmation, err error) {
+		if err == nil && request != nil && request.Status != core.ResetStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, resetType)
+
+	return wait(err, rc)
+}
+
+// GetDiagnosticsRequest requests the charge point to upload its diagnostics to given location and returns the file name
+func (cp *CP) GetDiagnosticsRequest(location string) (string, error) {
+	if cp.IsOCPP201() {
+		return "", api.ErrNotAvailable
+	}
+
+	var res string
+	rc := make(chan error, 1)
+
+	err := Instance().GetDiagnostics(cp.id, func(request *firmware.GetDiagnosticsConfirmation, err error) {
+		if err == nil && request != nil {
+			res = request.FileName
+		}
+
+		rc <- notSupported(err)
+	}, location)
+
+	return res, wait(notSupported(err), rc)
+}
+
+// UpdateFirmwareRequest requests the charge point to download and install the firmware from given location
+func (cp *CP) UpdateFirmwareRequest(location string, retrieveDate time.Time) error {
+	if cp.IsOCPP201() {
+		return api.ErrNotAvailable
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().UpdateFirmware(cp.id, func(request *firmware.UpdateFirmwareConfirmation, err error) {
+		rc <- notSupported(err)
+	}, location, types.NewDateTime(retrieveDate))
+
+	return wait(notSupported(err), rc)
+}
@@ -0,0 +1,303 @@
+package ocpp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	localauth201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	smartcharging201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// variables201 maps OCPP 1.6 configuration keys to OCPP 2.0.1 device model variables
+var variables201 = map[string]struct{ component, variable string }{
+	KeyMeterValuesSampledData:            {"SampledDataCtrlr", "TxUpdatedMeasurands"},
+	KeyMeterValueSampleInterval:          {"SampledDataCtrlr", "TxUpdatedInterval"},
+	KeyChargeProfileMaxStackLevel:        {"SmartChargingCtrlr", "ProfileStackLevel"},
+	KeyChargingScheduleMaxPeriods:        {"SmartChargingCtrlr", "PeriodsPerSchedule"},
+	KeyConnectorSwitch3to1PhaseSupported: {"SmartChargingCtrlr", "Phases3to1"},
+	KeyWebSocketPingInterval:             {"OCPPCommCtrlr", "WebSocketPingInterval"},
+}
+
+// evseRef returns the EVSE reference for given connector id
+func evseRef(connectorId int) *types201.EVSE {
+	if connectorId <= 0 {
+		return nil
+	}
+
+	return &types201.EVSE{ID: connectorId}
+}
+
+func (cp *CP) changeAvailability201(connectorId int, availabilityType core.AvailabilityType) error {
+	status := availability.OperationalStatusOperative
+	if availabilityType == core.AvailabilityTypeInoperative {
+		status = availability.OperationalStatusInoperative
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().csms.ChangeAvailability(cp.ID(), func(request *availability.ChangeAvailabilityResponse, err error) {
+		if err == nil && request != nil && request.Status == availability.ChangeAvailabilityStatusRejected {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, status, func(request *availability.ChangeAvailabilityRequest) {
+		request.Evse = evseRef(connectorId)
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) getCompositeSchedule201(connectorId int, duration int) (*smartcharging.GetCompositeScheduleConfirmation, error) {
+	var res *smartcharging.GetCompositeScheduleConfirmation
+	rc := make(chan error, 1)
+
+	err := Instance().csms.GetCompositeSchedule(cp.ID(), func(request *smartcharging201.GetCompositeScheduleResponse, err error) {
+		if err == nil && request != nil && request.Status != smartcharging201.GetCompositeScheduleStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		if err == nil && request != nil {
+			res = &smartcharging.GetCompositeScheduleConfirmation{
+				Status:      smartcharging.GetCompositeScheduleStatusAccepted,
+				ConnectorId: &request.EvseID,
+			}
+
+			if s := request.Schedule; s != nil && s.ChargingSchedule != nil {
+				if s.StartDateTime != nil {
+					res.ScheduleStart = types.NewDateTime(s.StartDateTime.Time)
+				}
+				res.ChargingSchedule = chargingSchedule16(s.ChargingSchedule)
+			}
+		}
+
+		rc <- err
+	}, duration, connectorId)
+
+	return res, wait(err, rc)
+}
+
+func (cp *CP) requestStartTransaction201(connectorId int, idTag string) error {
+	rc := make(chan error, 1)
+
+	token := types201.IdToken{
+		IdToken: idTag,
+		Type:    types201.IdTokenTypeCentral,
+	}
+
+	err := Instance().csms.RequestStartTransaction(cp.ID(), func(request *remotecontrol.RequestStartTransactionResponse, err error) {
+		if err == nil && request != nil && request.Status != remotecontrol.RequestStartStopStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, int(Instance().txnId.Add(1)), token, func(request *remotecontrol.RequestStartTransactionRequest) {
+		if connectorId > 0 {
+			request.EvseID = &connectorId
+		}
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) setChargingProfile201(connectorId int, profile *types.ChargingProfile) error {
+	rc := make(chan error, 1)
+
+	err := Instance().csms.SetChargingProfile(cp.ID(), func(request *smartcharging201.SetChargingProfileResponse, err error) {
+		if err == nil && request != nil && request.Status != smartcharging201.ChargingProfileStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, connectorId, chargingProfile201(profile))
+
+	return wait(err, rc)
+}
+
+func (cp *CP) triggerMessage201(connectorId int, requestedMessage remotetrigger.MessageTrigger) error {
+	rc := make(chan error, 1)
+
+	err := Instance().csms.TriggerMessage(cp.ID(), func(request *remotecontrol.TriggerMessageResponse, err error) {
+		if err == nil && request != nil && request.Status != remotecontrol.TriggerMessageStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, remotecontrol.MessageTrigger(requestedMessage), func(request *remotecontrol.TriggerMessageRequest) {
+		request.Evse = evseRef(connectorId)
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) sendLocalList201(version int, tags []string) error {
+	rc := make(chan error, 1)
+
+	err := Instance().csms.SendLocalList(cp.ID(), func(request *localauth201.SendLocalListResponse, err error) {
+		if err == nil && request != nil && request.Status != localauth201.SendLocalListStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- notSupported(err)
+	}, version, localauth201.UpdateTypeFull, func(request *localauth201.SendLocalListRequest) {
+		request.LocalAuthorizationList = localList201(tags)
+	})
+
+	return wait(notSupported(err), rc)
+}
+
+func (cp *CP) reset201(resetType core.ResetType) error {
+	typ := provisioning.ResetTypeOnIdle
+	if resetType == core.ResetTypeHard {
+		typ = provisioning.ResetTypeImmediate
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().csms.Reset(cp.ID(), func(request *provisioning.ResetResponse, err error) {
+		if err == nil && request != nil && request.Status == provisioning.ResetStatusRejected {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, typ)
+
+	return wait(err, rc)
+}
+
+func (cp *CP) setVariable201(key, value string) error {
+	v, ok := variables201[key]
+	if !ok {
+		return fmt.Errorf("unsupported variable: %s", key)
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().csms.SetVariables(cp.ID(), func(request *provisioning.SetVariablesResponse, err error) {
+		if err == nil && request != nil {
+			for _, res := range request.SetVariableResult {
+				if res.AttributeStatus != provisioning.SetVariableStatusAccepted {
+					err = errors.New(string(res.AttributeStatus))
+				}
+			}
+		}
+
+		rc <- err
+	}, []provisioning.SetVariableData{{
+		AttributeValue: value,
+		Component:      types201.Component{Name: v.component},
+		Variable:       types201.Variable{Name: v.variable},
+	}})
+
+	return wait(err, rc)
+}
+
+// getVariables201 returns the device model variables as OCPP 1.6 configuration
+func (cp *CP) getVariables201() (*core.GetConfigurationConfirmation, error) {
+	keys := make(map[string]string, len(variables201))
+
+	var data []provisioning.GetVariableData
+	for key, v := range variables201 {
+		keys[v.variable] = key
+
+		data = append(data, provisioning.GetVariableData{
+			Component: types201.Component{Name: v.component},
+			Variable:  types201.Variable{Name: v.variable},
+		})
+	}
+
+	res := new(core.GetConfigurationConfirmation)
+	rc := make(chan error, 1)
+
+	err := Instance().csms.GetVariables(cp.ID(), func(request *provisioning.GetVariablesResponse, err error) {
+		if err == nil && request != nil {
+			for _, v := range request.GetVariableResult {
+				key, ok := keys[v.Variable.Name]
+				if !ok || v.AttributeStatus != provisioning.GetVariableStatusAccepted {
+					continue
+				}
+
+				res.ConfigurationKey = append(res.ConfigurationKey, core.ConfigurationKey{
+					Key:   key,
+					Value: &v.AttributeValue,
+				})
+			}
+		}
+
+		rc <- err
+	}, data)
+
+	return res, wait(err, rc)
+}
+
+// chargingProfile201 converts an OCPP 1.6 charging profile
+func chargingProfile201(profile *types.ChargingProfile) *types201.ChargingProfile {
+	purpose := types201.ChargingProfilePurposeType(profile.ChargingProfilePurpose)
+	if profile.ChargingProfilePurpose == types.ChargingProfilePurposeChargePointMaxProfile {
+		purpose = types201.ChargingProfilePurposeChargingStationMaxProfile
+	}
+
+	res := &types201.ChargingProfile{
+		ID:                     profile.ChargingProfileId,
+		StackLevel:             profile.StackLevel,
+		ChargingProfilePurpose: purpose,
+		ChargingProfileKind:    types201.ChargingProfileKindType(profile.ChargingProfileKind),
+		RecurrencyKind:         types201.RecurrencyKindType(profile.RecurrencyKind),
+	}
+
+	if s := profile.ChargingSchedule; s != nil {
+		schedule := types201.ChargingSchedule{
+			ID:               profile.ChargingProfileId,
+			Duration:         s.Duration,
+			ChargingRateUnit: types201.ChargingRateUnitType(s.ChargingRateUnit),
+			MinChargingRate:  s.MinChargingRate,
+		}
+
+		if s.StartSchedule != nil {
+			schedule.StartSchedule = types201.NewDateTime(s.StartSchedule.Time)
+		}
+
+		for _, p := range s.ChargingSchedulePeriod {
+			schedule.ChargingSchedulePeriod = append(schedule.ChargingSchedulePeriod, types201.ChargingSchedulePeriod{
+				StartPeriod:  p.StartPeriod,
+				Limit:        p.Limit,
+				NumberPhases: p.NumberPhases,
+			})
+		}
+
+		res.ChargingSchedule = []types201.ChargingSchedule{schedule}
+	}
+
+	return res
+}
+
+// chargingSchedule16 converts an OCPP 2.0.1 charging schedule
+func chargingSchedule16(schedule *types201.ChargingSchedule) *types.ChargingSchedule {
+	res := &types.ChargingSchedule{
+		Duration:         schedule.Duration,
+		ChargingRateUnit: types.ChargingRateUnitType(schedule.ChargingRateUnit),
+		MinChargingRate:  schedule.MinChargingRate,
+	}
+
+	if schedule.StartSchedule != nil {
+		res.StartSchedule = types.NewDateTime(schedule.StartSchedule.Time)
+	}
+
+	for _, p := range schedule.ChargingSchedulePeriod {
+		res.ChargingSchedulePeriod = append(res.ChargingSchedulePeriod, types.ChargingSchedulePeriod{
+			StartPeriod:  p.StartPeriod,
+			Limit:        p.Limit,
+			NumberPhases: p.NumberPhases,
+		})
+	}
+
+	return res
+}
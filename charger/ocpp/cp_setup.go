@@ -7,13 +7,18 @@ import (
 	"time"
 
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/samber/lo"
 )
 
-func (cp *CP) Setup(ctx context.Context, meterValues string, meterInterval time.Duration, forcePowerCtrl bool) error {
+func (cp *CP) Setup(ctx context.Context, meterValues string, meterInterval time.Duration, forcePowerCtrl, whitelist bool) error {
+	cp.mu.Lock()
+	cp.whitelist = whitelist
+	cp.mu.Unlock()
+
 	if err := cp.ChangeAvailabilityRequest(0, core.AvailabilityTypeOperative); err != nil {
 		cp.log.DEBUG.Printf("failed configuring availability: %v", err)
 	}
@@ -55,6 +60,11 @@ func (cp *CP) Setup(ctx context.Context, meterValues string, meterInterval time.
 				cp.PhaseSwitching = true // assume phase switching is available for power-based charging
 			}
 
+		case match(KeyChargingScheduleMaxPeriods):
+			if val, err := strconv.Atoi(*opt.Value); err == nil {
+				cp.ChargingScheduleMaxPeriods = val
+			}
+
 		case match(KeyConnectorSwitch3to1PhaseSupported) || match(KeyChargeAmpsPhaseSwitchingSupported):
 			var val bool
 			if val, err = strconv.ParseBool(*opt.Value); err == nil {
@@ -66,6 +76,11 @@ func (cp *CP) Setup(ctx context.Context, meterValues string, meterInterval time.
 				cp.ChargingProfileId = val
 			}
 
+		case match(KeyLocalAuthListMaxLength):
+			if val, err := strconv.Atoi(*opt.Value); err == nil {
+				cp.LocalAuthListMaxLength = val
+			}
+
 		case match(KeyMeterValuesSampledData):
 			if opt.Readonly {
 				meterValuesSampledDataMaxLength = 0
@@ -91,6 +106,7 @@ func (cp *CP) Setup(ctx context.Context, meterValues string, meterInterval time.
 			// correct the availability assumption of RemoteTrigger only in case of a valid looking FeatureProfile list
 			if hasProperty(*opt.Value, core.ProfileName) {
 				cp.HasRemoteTriggerFeature = hasProperty(*opt.Value, remotetrigger.ProfileName)
+				cp.setLocalAuthList(hasProperty(*opt.Value, localauth.ProfileName))
 			}
 
 		// vendor-specific keys
@@ -115,7 +131,9 @@ func (cp *CP) Setup(ctx context.Context, meterValues string, meterInterval time.
 		case <-time.After(Timeout):
 			cp.log.DEBUG.Printf("BootNotification timeout")
 		case res := <-cp.bootNotificationRequestC:
+			cp.mu.Lock()
 			cp.BootNotificationResult = res
+			cp.mu.Unlock()
 		}
 	}
 
@@ -164,6 +182,17 @@ func (cp *CP) Setup(ctx context.Context, meterValues string, meterInterval time.
 		cp.PhaseSwitching = true // assume phase switching is available for power-based charging
 	}
 
+	// keep local authorization list in sync
+	if whitelist {
+		if cp.hasLocalAuthList() {
+			if err := cp.ChangeConfigurationRequest(KeyLocalAuthListEnabled, "true"); err != nil {
+				cp.log.DEBUG.Printf("failed configuring %s: %v", KeyLocalAuthListEnabled, err)
+			}
+		}
+
+		go cp.syncLocalList(ctx)
+	}
+
 	return nil
 }
 
@@ -3,19 +3,23 @@ package ocpp
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 
 	"github.com/evcc-io/evcc/util"
 	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
 )
 
 type registration struct {
-	mu     sync.RWMutex
-	setup  sync.RWMutex                            // serialises chargepoint setup
-	cp     *CP                                     // guarded by setup and CS mutexes
-	status map[int]*core.StatusNotificationRequest // guarded by mu mutex
+	mu       sync.RWMutex
+	setup    sync.RWMutex                            // serialises chargepoint setup
+	cp       *CP                                     // guarded by setup and CS mutexes
+	protocol string                                  // guarded by CS mutex
+	status   map[int]*core.StatusNotificationRequest // guarded by mu mutex
 }
 
 func newRegistration() *registration {
@@ -24,6 +28,8 @@ func newRegistration() *registration {
 
 type CS struct {
 	ocpp16.CentralSystem
+	csms  ocpp201.CSMS
+	mux   *wsMux
 	mu    sync.Mutex
 	log   *util.Logger
 	regs  map[string]*registration // guarded by mu mutex
@@ -51,6 +57,22 @@ func (cs *CS) ChargepointByID(id string) (*CP, error) {
 	return reg.cp, nil
 }
 
+// Chargepoints returns the ids of all configured charge points
+func (cs *CS) Chargepoints() []string {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	var res []string
+	for id, reg := range cs.regs {
+		if reg.cp != nil {
+			res = append(res, id)
+		}
+	}
+
+	slices.Sort(res)
+	return res
+}
+
 func (cs *CS) WithConnectorStatus(id string, connector int, fun func(status *core.StatusNotificationRequest)) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
@@ -100,6 +122,7 @@ func (cs *CS) RegisterChargepoint(id string, newfun func() *CP, init func(*CP) e
 
 	cs.mu.Lock()
 	reg.cp = cp
+	cp.setProtocol(reg.protocol)
 	cs.mu.Unlock()
 
 	if registered {
@@ -111,16 +134,28 @@ func (cs *CS) RegisterChargepoint(id string, newfun func() *CP, init func(*CP) e
 
 // NewChargePoint implements ocpp16.ChargePointConnectionHandler
 func (cs *CS) NewChargePoint(chargePoint ocpp16.ChargePointConnection) {
+	cs.connect(chargePoint.ID(), types.V16Subprotocol)
+}
+
+// ChargePointDisconnected implements ocpp16.ChargePointConnectionHandler
+func (cs *CS) ChargePointDisconnected(chargePoint ocpp16.ChargePointConnection) {
+	cs.disconnect(chargePoint.ID())
+}
+
+// connect associates a connected charge point with its registration
+func (cs *CS) connect(id, protocol string) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
 
 	// check for configured charge point
-	reg, ok := cs.regs[chargePoint.ID()]
+	reg, ok := cs.regs[id]
 	if ok {
-		cs.log.DEBUG.Printf("charge point connected: %s", chargePoint.ID())
+		cs.log.DEBUG.Printf("charge point connected: %s (%s)", id, protocol)
+		reg.protocol = protocol
 
 		// trigger initial connection if charge point is already setup
 		if cp := reg.cp; cp != nil {
+			cp.setProtocol(protocol)
 			cp.connect(true)
 		}
 
@@ -131,30 +166,34 @@ func (cs *CS) NewChargePoint(chargePoint ocpp16.ChargePointConnection) {
 	reg, ok = cs.regs[""]
 	if ok && reg.cp != nil {
 		cp := reg.cp
-		cs.log.INFO.Printf("charge point connected, registering: %s", chargePoint.ID())
+		cs.log.INFO.Printf("charge point connected, registering: %s (%s)", id, protocol)
 
 		// update id
-		cp.RegisterID(chargePoint.ID())
-		cs.regs[chargePoint.ID()] = reg
+		cp.RegisterID(id)
+		cs.regs[id] = reg
 		delete(cs.regs, "")
 
+		reg.protocol = protocol
+		cp.setProtocol(protocol)
 		cp.connect(true)
 
 		return
 	}
 
-	cs.log.WARN.Printf("unknown charge point connected: %s", chargePoint.ID())
+	cs.log.WARN.Printf("unknown charge point connected: %s (%s)", id, protocol)
 
 	// register unknown charge point
 	// when charge point setup is complete, it will eventually be associated with the connected id
-	cs.regs[chargePoint.ID()] = newRegistration()
+	reg = newRegistration()
+	reg.protocol = protocol
+	cs.regs[id] = reg
 }
 
-// ChargePointDisconnected implements ocpp16.ChargePointConnectionHandler
-func (cs *CS) ChargePointDisconnected(chargePoint ocpp16.ChargePointConnection) {
-	cs.log.DEBUG.Printf("charge point disconnected: %s", chargePoint.ID())
+// disconnect marks a charge point as disconnected
+func (cs *CS) disconnect(id string) {
+	cs.log.DEBUG.Printf("charge point disconnected: %s", id)
 
-	if cp, err := cs.ChargepointByID(chargePoint.ID()); err == nil {
+	if cp, err := cs.ChargepointByID(id); err == nil {
 		cp.connect(false)
 	}
 }
@@ -0,0 +1,399 @@
+package ocpp
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	smartcharging201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// OCPP 2.0.1 charging stations are mapped onto the OCPP 1.6 charge point model:
+// EVSEs become connectors, connector status and transaction charging state are merged
+// into 1.6 status notifications and string transaction ids are mapped to numeric ones.
+
+type evseKey struct {
+	id   string
+	evse int
+}
+
+type txnKey struct {
+	id  string
+	txn string
+}
+
+// evse201 is the combined state of an OCPP 2.0.1 EVSE
+type evse201 struct {
+	connector availability.ConnectorStatus
+	charging  transactions.ChargingState
+	finished  bool // transaction ended while still occupied
+}
+
+// status returns the equivalent OCPP 1.6 charge point status
+func (e *evse201) status() core.ChargePointStatus {
+	switch e.connector {
+	case availability.ConnectorStatusAvailable:
+		return core.ChargePointStatusAvailable
+	case availability.ConnectorStatusReserved:
+		return core.ChargePointStatusReserved
+	case availability.ConnectorStatusUnavailable:
+		return core.ChargePointStatusUnavailable
+	case availability.ConnectorStatusFaulted:
+		return core.ChargePointStatusFaulted
+	case "":
+		// no status notification received yet
+		if e.charging == "" || e.charging == transactions.ChargingStateIdle {
+			return core.ChargePointStatusAvailable
+		}
+	}
+
+	switch e.charging {
+	case transactions.ChargingStateCharging:
+		return core.ChargePointStatusCharging
+	case transactions.ChargingStateSuspendedEV:
+		return core.ChargePointStatusSuspendedEV
+	case transactions.ChargingStateSuspendedEVSE:
+		return core.ChargePointStatusSuspendedEVSE
+	}
+
+	if e.finished {
+		return core.ChargePointStatusFinishing
+	}
+
+	return core.ChargePointStatusPreparing
+}
+
+type txn201 struct {
+	evse int
+	txn  int
+}
+
+// csms201 handles OCPP 2.0.1 charging station messages
+type csms201 struct {
+	cs    *CS
+	mu    sync.Mutex
+	evses map[evseKey]*evse201
+	txns  map[txnKey]txn201
+}
+
+func newCSMS201(cs *CS) *csms201 {
+	return &csms201{
+		cs:    cs,
+		evses: make(map[evseKey]*evse201),
+		txns:  make(map[txnKey]txn201),
+	}
+}
+
+// NewChargingStation implements ocpp201.ChargingStationConnectionHandler
+func (h *csms201) NewChargingStation(chargingStation ocpp201.ChargingStationConnection) {
+	h.cs.connect(chargingStation.ID(), types201.V201Subprotocol)
+}
+
+// ChargingStationDisconnected implements ocpp201.ChargingStationConnectionHandler
+func (h *csms201) ChargingStationDisconnected(chargingStation ocpp201.ChargingStationConnection) {
+	h.cs.disconnect(chargingStation.ID())
+}
+
+// evse returns the EVSE state.
+// Must only be called while holding lock.
+func (h *csms201) evse(id string, evse int) *evse201 {
+	key := evseKey{id, evse}
+
+	res, ok := h.evses[key]
+	if !ok {
+		res = new(evse201)
+		h.evses[key] = res
+	}
+
+	return res
+}
+
+// updateStatus forwards the EVSE state as status notification
+func (h *csms201) updateStatus(id string, evse int, timestamp *types201.DateTime, status core.ChargePointStatus) error {
+	request := &core.StatusNotificationRequest{
+		ConnectorId: evse,
+		ErrorCode:   core.NoError,
+		Status:      status,
+	}
+
+	if status == core.ChargePointStatusFaulted {
+		request.ErrorCode = core.OtherError
+	}
+
+	if timestamp != nil {
+		request.Timestamp = types.NewDateTime(timestamp.Time)
+	}
+
+	_, err := h.cs.OnStatusNotification(id, request)
+	return err
+}
+
+// updateMeterValues forwards meter values
+func (h *csms201) updateMeterValues(id string, evse int, txn *int, values []types201.MeterValue) error {
+	request := &core.MeterValuesRequest{
+		ConnectorId:   evse,
+		TransactionId: txn,
+		MeterValue:    meterValues16(values),
+	}
+
+	_, err := h.cs.OnMeterValues(id, request)
+	return err
+}
+
+// meterValues16 converts OCPP 2.0.1 meter values
+func meterValues16(values []types201.MeterValue) []types.MeterValue {
+	res := make([]types.MeterValue, 0, len(values))
+
+	for _, mv := range values {
+		samples := make([]types.SampledValue, 0, len(mv.SampledValue))
+
+		for _, sv := range mv.SampledValue {
+			value, unit := sv.Value, ""
+
+			if u := sv.UnitOfMeasure; u != nil {
+				unit = u.Unit
+				if u.Multiplier != nil {
+					value *= math.Pow10(*u.Multiplier)
+				}
+			}
+
+			samples = append(samples, types.SampledValue{
+				Value:     strconv.FormatFloat(value, 'f', -1, 64),
+				Context:   types.ReadingContext(sv.Context),
+				Measurand: types.Measurand(sv.Measurand),
+				Phase:     types.Phase(sv.Phase),
+				Location:  types.Location(sv.Location),
+				Unit:      types.UnitOfMeasure(unit),
+			})
+		}
+
+		res = append(res, types.MeterValue{
+			Timestamp:    types.NewDateTime(mv.Timestamp.Time),
+			SampledValue: samples,
+		})
+	}
+
+	return res
+}
+
+// authorization
+
+func (h *csms201) OnAuthorize(id string, request *authorization.AuthorizeRequest) (*authorization.AuthorizeResponse, error) {
+	res := &authorization.AuthorizeResponse{
+		IdTokenInfo: *types201.NewIdTokenInfo(h.authorize(id, request.IdToken.IdToken)),
+	}
+
+	return res, nil
+}
+
+// availability
+
+func (h *csms201) OnHeartbeat(id string, request *availability.HeartbeatRequest) (*availability.HeartbeatResponse, error) {
+	res := &availability.HeartbeatResponse{
+		CurrentTime: *types201.Now(),
+	}
+
+	return res, nil
+}
+
+func (h *csms201) OnStatusNotification(id string, request *availability.StatusNotificationRequest) (*availability.StatusNotificationResponse, error) {
+	h.mu.Lock()
+	evse := h.evse(id, request.EvseID)
+	evse.connector = request.ConnectorStatus
+	if evse.connector == availability.ConnectorStatusAvailable {
+		evse.charging = ""
+		evse.finished = false
+	}
+	status := evse.status()
+	h.mu.Unlock()
+
+	return new(availability.StatusNotificationResponse), h.updateStatus(id, request.EvseID, request.Timestamp, status)
+}
+
+// data
+
+func (h *csms201) OnDataTransfer(id string, request *data.DataTransferRequest) (*data.DataTransferResponse, error) {
+	return data.NewDataTransferResponse(data.DataTransferStatusAccepted), nil
+}
+
+// meter
+
+func (h *csms201) OnMeterValues(id string, request *meter.MeterValuesRequest) (*meter.MeterValuesResponse, error) {
+	return new(meter.MeterValuesResponse), h.updateMeterValues(id, request.EvseID, nil, request.MeterValue)
+}
+
+// provisioning
+
+func (h *csms201) OnBootNotification(id string, request *provisioning.BootNotificationRequest) (*provisioning.BootNotificationResponse, error) {
+	conf, err := h.cs.OnBootNotification(id, &core.BootNotificationRequest{
+		ChargePointModel:        request.ChargingStation.Model,
+		ChargePointVendor:       request.ChargingStation.VendorName,
+		ChargePointSerialNumber: request.ChargingStation.SerialNumber,
+		FirmwareVersion:         request.ChargingStation.FirmwareVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &provisioning.BootNotificationResponse{
+		CurrentTime: types201.Now(),
+		Interval:    conf.Interval,
+		Status:      provisioning.RegistrationStatus(conf.Status),
+	}
+
+	return res, nil
+}
+
+func (h *csms201) OnNotifyReport(id string, request *provisioning.NotifyReportRequest) (*provisioning.NotifyReportResponse, error) {
+	return new(provisioning.NotifyReportResponse), nil
+}
+
+// smart charging
+
+func (h *csms201) OnClearedChargingLimit(id string, request *smartcharging201.ClearedChargingLimitRequest) (*smartcharging201.ClearedChargingLimitResponse, error) {
+	return new(smartcharging201.ClearedChargingLimitResponse), nil
+}
+
+func (h *csms201) OnNotifyChargingLimit(id string, request *smartcharging201.NotifyChargingLimitRequest) (*smartcharging201.NotifyChargingLimitResponse, error) {
+	return new(smartcharging201.NotifyChargingLimitResponse), nil
+}
+
+// OnNotifyEVChargingNeeds receives the ISO 15118 vehicle soc
+func (h *csms201) OnNotifyEVChargingNeeds(id string, request *smartcharging201.NotifyEVChargingNeedsRequest) (*smartcharging201.NotifyEVChargingNeedsResponse, error) {
+	if dc := request.ChargingNeeds.DCChargingParameters; dc != nil && dc.StateOfCharge != nil {
+		h.withConnector(id, request.EvseID, func(conn *Connector) {
+			conn.setSoc(*dc.StateOfCharge)
+		})
+	}
+
+	return smartcharging201.NewNotifyEVChargingNeedsResponse(smartcharging201.EVChargingNeedsStatusAccepted), nil
+}
+
+func (h *csms201) OnNotifyEVChargingSchedule(id string, request *smartcharging201.NotifyEVChargingScheduleRequest) (*smartcharging201.NotifyEVChargingScheduleResponse, error) {
+	return smartcharging201.NewNotifyEVChargingScheduleResponse(types201.GenericStatusAccepted), nil
+}
+
+func (h *csms201) OnReportChargingProfiles(id string, request *smartcharging201.ReportChargingProfilesRequest) (*smartcharging201.ReportChargingProfilesResponse, error) {
+	return new(smartcharging201.ReportChargingProfilesResponse), nil
+}
+
+// transactions
+
+func (h *csms201) OnTransactionEvent(id string, request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	res := new(transactions.TransactionEventResponse)
+
+	var idTag string
+	if request.IDToken != nil {
+		idTag = request.IDToken.IdToken
+		res.IDTokenInfo = types201.NewIdTokenInfo(h.authorize(id, idTag))
+	}
+
+	info := request.TransactionInfo
+	key := txnKey{id, info.TransactionID}
+
+	h.mu.Lock()
+	txn, ok := h.txns[key]
+	h.mu.Unlock()
+
+	// evse is only required for the first event of a transaction
+	if request.Evse != nil {
+		txn.evse = request.Evse.ID
+	}
+
+	var timestamp time.Time
+	if request.Timestamp != nil {
+		timestamp = request.Timestamp.Time
+	}
+
+	// start or recover transaction
+	if !ok && request.EventType != transactions.TransactionEventEnded {
+		conf, err := h.cs.OnStartTransaction(id, &core.StartTransactionRequest{
+			ConnectorId: txn.evse,
+			IdTag:       idTag,
+			Timestamp:   types.NewDateTime(timestamp),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		txn.txn = conf.TransactionId
+
+		if request.EventType != transactions.TransactionEventStarted {
+			h.cs.log.DEBUG.Printf("recovered transaction: %s", info.TransactionID)
+		}
+	} else if idTag != "" {
+		// authorized after transaction start
+		h.withConnector(id, txn.evse, func(conn *Connector) {
+			conn.setIdTag(idTag)
+		})
+	}
+
+	h.mu.Lock()
+	if request.EventType == transactions.TransactionEventEnded {
+		delete(h.txns, key)
+	} else {
+		h.txns[key] = txn
+	}
+
+	evse := h.evse(id, txn.evse)
+	if info.ChargingState != "" {
+		evse.charging = info.ChargingState
+	}
+	evse.finished = request.EventType == transactions.TransactionEventEnded
+	if evse.finished && info.ChargingState == "" {
+		evse.charging = ""
+	}
+	status := evse.status()
+	h.mu.Unlock()
+
+	if len(request.MeterValue) > 0 {
+		if err := h.updateMeterValues(id, txn.evse, &txn.txn, request.MeterValue); err != nil {
+			return nil, err
+		}
+	}
+
+	if request.EventType == transactions.TransactionEventEnded {
+		if !ok {
+			h.cs.log.DEBUG.Printf("unknown transaction ended: %s", info.TransactionID)
+			return res, h.updateStatus(id, txn.evse, request.Timestamp, status)
+		}
+
+		if _, err := h.cs.OnStopTransaction(id, &core.StopTransactionRequest{
+			TransactionId: txn.txn,
+			Timestamp:     types.NewDateTime(timestamp),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, h.updateStatus(id, txn.evse, request.Timestamp, status)
+}
+
+// authorize checks the id token against the charge point's local authorization list
+func (h *csms201) authorize(id, idTag string) types201.AuthorizationStatus {
+	if cp, err := h.cs.ChargepointByID(id); err == nil && cp.authorize(idTag) != types.AuthorizationStatusAccepted {
+		return types201.AuthorizationStatusInvalid
+	}
+
+	return types201.AuthorizationStatusAccepted
+}
+
+// withConnector executes fun if the charging station's connector is configured
+func (h *csms201) withConnector(id string, evse int, fun func(conn *Connector)) {
+	if cp, err := h.cs.ChargepointByID(id); err == nil {
+		if conn := cp.connectorByID(evse); conn != nil {
+			fun(conn)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package ocpp
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+)
+
+func (cs *CS) OnDiagnosticsStatusNotification(id string, request *firmware.DiagnosticsStatusNotificationRequest) (*firmware.DiagnosticsStatusNotificationConfirmation, error) {
+	if cp, err := cs.ChargepointByID(id); err == nil {
+		return cp.OnDiagnosticsStatusNotification(request)
+	}
+
+	return new(firmware.DiagnosticsStatusNotificationConfirmation), nil
+}
+
+func (cs *CS) OnFirmwareStatusNotification(id string, request *firmware.FirmwareStatusNotificationRequest) (*firmware.FirmwareStatusNotificationConfirmation, error) {
+	if cp, err := cs.ChargepointByID(id); err == nil {
+		return cp.OnFirmwareStatusNotification(request)
+	}
+
+	return new(firmware.FirmwareStatusNotificationConfirmation), nil
+}
@@ -0,0 +1,48 @@
+package ocpp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fileServer is the http server providing firmware and diagnostics files to the stations
+var fileServer struct {
+	mu   sync.Mutex
+	url  string // external url
+	port int    // http server port
+}
+
+// SetFileServer sets the http server's external url and port.
+// Without external url, the stations are provided the address they use for connecting to the OCPP server.
+func SetFileServer(url string, port int) {
+	fileServer.mu.Lock()
+	defer fileServer.mu.Unlock()
+
+	fileServer.url = strings.TrimSuffix(url, "/")
+	fileServer.port = port
+}
+
+// FileServerUrl returns the http server's url as reachable by the given station
+func (cs *CS) FileServerUrl(id string) (string, error) {
+	fileServer.mu.Lock()
+	defer fileServer.mu.Unlock()
+
+	if fileServer.url != "" {
+		return fileServer.url, nil
+	}
+
+	host := cs.mux.Host(id)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	if host == "" || fileServer.port == 0 {
+		return "", errors.New("station address unknown, configure network external url")
+	}
+
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, strconv.Itoa(fileServer.port))), nil
+}
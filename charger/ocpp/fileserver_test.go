@@ -0,0 +1,38 @@
+package ocpp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/lorenzodonini/ocpp-go/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileServerUrl(t *testing.T) {
+	mux := newWsMux(ws.NewServer(), "ocpp1.6")
+	mux.Route("ocpp1.6")
+
+	cs := &CS{mux: mux}
+	t.Cleanup(func() { SetFileServer("", 0) })
+
+	SetFileServer("", 7070)
+
+	// station not connected
+	_, err := cs.FileServerUrl("cp")
+	assert.Error(t, err)
+
+	require.True(t, mux.checkClient("cp", &http.Request{Header: http.Header{}, Host: "192.0.2.1:8887"}))
+	mux.clientConnected(&testChannel{id: "cp"})
+
+	// address used by the station for connecting to the ocpp server
+	uri, err := cs.FileServerUrl("cp")
+	require.NoError(t, err)
+	assert.Equal(t, "http://192.0.2.1:7070", uri)
+
+	// external url
+	SetFileServer("https://evcc.example.com/", 7070)
+	uri, err = cs.FileServerUrl("cp")
+	require.NoError(t, err)
+	assert.Equal(t, "https://evcc.example.com", uri)
+}
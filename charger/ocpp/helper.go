@@ -27,6 +27,14 @@ func wait(err error, rc chan error) error {
 	return err
 }
 
+// notSupported maps errors for unsupported features to api.ErrNotAvailable
+func notSupported(err error) error {
+	if oe := new(ocpp.Error); errors.As(err, &oe) && (oe.Code == ocppj.NotSupported || oe.Code == ocppj.NotImplemented) {
+		return api.ErrNotAvailable
+	}
+	return err
+}
+
 func sortByAge(values []types.MeterValue) []types.MeterValue {
 	return slices.SortedFunc(slices.Values(values), func(a, b types.MeterValue) int {
 		var at, bt time.Time
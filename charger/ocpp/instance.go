@@ -3,14 +3,29 @@ package ocpp
 import (
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/evcc-io/evcc/util"
 	"github.com/lorenzodonini/ocpp-go/ocpp"
 	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/data"
+	localauth201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	smartcharging201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
 	"github.com/lorenzodonini/ocpp-go/ocppj"
 	"github.com/lorenzodonini/ocpp-go/ws"
 )
@@ -18,6 +33,7 @@ import (
 var (
 	once     sync.Once
 	instance *CS
+	started  atomic.Bool
 )
 
 func Instance() *CS {
@@ -27,21 +43,38 @@ func Instance() *CS {
 		server := ws.NewServer()
 		server.SetCheckOriginHandler(func(r *http.Request) bool { return true })
 
-		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
-		dispatcher.SetTimeout(Timeout)
+		// share the websocket server between OCPP 1.6 and 2.0.1 by negotiated subprotocol, defaulting to OCPP 1.6
+		mux := newWsMux(server, types.V16Subprotocol)
 
-		endpoint := ocppj.NewServer(server, dispatcher, nil, core.Profile, remotetrigger.Profile, smartcharging.Profile)
-		endpoint.SetInvalidMessageHook(func(client ws.Channel, err *ocpp.Error, rawMessage string, parsedFields []interface{}) *ocpp.Error {
+		invalidMessageHook := func(client ws.Channel, err *ocpp.Error, rawMessage string, parsedFields []interface{}) *ocpp.Error {
 			log.ERROR.Printf("%v (%s)", err, rawMessage)
 			return nil
-		})
+		}
+
+		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
+		dispatcher.SetTimeout(Timeout)
+
+		endpoint := ocppj.NewServer(mux.Route(types.V16Subprotocol), dispatcher, nil, core.Profile, firmware.Profile, localauth.Profile, remotetrigger.Profile, smartcharging.Profile)
+		endpoint.SetInvalidMessageHook(invalidMessageHook)
+
+		cs := ocpp16.NewCentralSystem(endpoint, mux.Route(types.V16Subprotocol))
+
+		dispatcher201 := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
+		dispatcher201.SetTimeout(Timeout)
 
-		cs := ocpp16.NewCentralSystem(endpoint, server)
+		endpoint201 := ocppj.NewServer(mux.Route(types201.V201Subprotocol), dispatcher201, nil,
+			authorization.Profile, availability.Profile, data.Profile, localauth201.Profile, meter.Profile, provisioning.Profile,
+			remotecontrol.Profile, smartcharging201.Profile, transactions.Profile)
+		endpoint201.SetInvalidMessageHook(invalidMessageHook)
+
+		csms := ocpp201.NewCSMS(endpoint201, mux.Route(types201.V201Subprotocol))
 
 		instance = &CS{
 			log:           log,
 			regs:          make(map[string]*registration),
 			CentralSystem: cs,
+			csms:          csms,
+			mux:           mux,
 		}
 
 		instance.txnId.Store(time.Now().UTC().Unix())
@@ -49,19 +82,47 @@ func Instance() *CS {
 		ocppj.SetLogger(instance)
 
 		cs.SetCoreHandler(instance)
+		cs.SetFirmwareManagementHandler(instance)
 		cs.SetNewChargePointHandler(instance.NewChargePoint)
 		cs.SetChargePointDisconnectedHandler(instance.ChargePointDisconnected)
 
+		handler := newCSMS201(instance)
+
+		csms.SetAuthorizationHandler(handler)
+		csms.SetAvailabilityHandler(handler)
+		csms.SetDataHandler(handler)
+		csms.SetMeterHandler(handler)
+		csms.SetProvisioningHandler(handler)
+		csms.SetSmartChargingHandler(handler)
+		csms.SetTransactionsHandler(handler)
+		csms.SetNewChargingStationHandler(handler.NewChargingStation)
+		csms.SetChargingStationDisconnectedHandler(handler.ChargingStationDisconnected)
+
 		go instance.errorHandler(cs.Errors())
+		go instance.errorHandler(csms.Errors())
+
+		// the shared websocket server starts once both endpoints are started
+		go csms.Start(8887, "/{ws}")
 		go cs.Start(8887, "/{ws}")
 
 		// wait for server to start
 		for range time.Tick(10 * time.Millisecond) {
-			if dispatcher.IsRunning() {
+			if dispatcher.IsRunning() && dispatcher201.IsRunning() {
 				break
 			}
 		}
+
+		started.Store(true)
 	})
 
 	return instance
 }
+
+// Started returns the central system if it has been started by any charger
+func Started() (*CS, bool) {
+	if !started.Load() {
+		return nil, false
+	}
+
+	return Instance(), true
+}
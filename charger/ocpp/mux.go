@@ -0,0 +1,210 @@
+package ocpp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+// wsMux shares a single websocket server between multiple ocppj endpoints.
+// Incoming connections are routed by their negotiated subprotocol. Clients
+// not requesting a subprotocol are routed to the default subprotocol.
+type wsMux struct {
+	server   ws.Server
+	started  atomic.Bool
+	mu       sync.RWMutex
+	fallback string // default subprotocol
+	routes   map[string]*wsRoute
+	pending  map[string]wsClient     // negotiated connection by client id until connected
+	channels map[ws.Channel]wsClient // negotiated connection by channel
+}
+
+// wsClient is a client's negotiated connection
+type wsClient struct {
+	protocol string // negotiated subprotocol
+	host     string // host the client connected to
+}
+
+func newWsMux(server ws.Server, fallback string) *wsMux {
+	mux := &wsMux{
+		server:   server,
+		fallback: fallback,
+		routes:   make(map[string]*wsRoute),
+		pending:  make(map[string]wsClient),
+		channels: make(map[ws.Channel]wsClient),
+	}
+
+	server.SetCheckClientHandler(mux.checkClient)
+	server.SetNewClientHandler(mux.clientConnected)
+	server.SetDisconnectedClientHandler(mux.clientDisconnected)
+	server.SetMessageHandler(mux.message)
+
+	return mux
+}
+
+// Route returns the websocket server facade for given subprotocol
+func (mux *wsMux) Route(protocol string) ws.Server {
+	mux.mu.Lock()
+	defer mux.mu.Unlock()
+
+	route, ok := mux.routes[protocol]
+	if !ok {
+		route = &wsRoute{Server: mux.server, mux: mux}
+		mux.routes[protocol] = route
+	}
+
+	return route
+}
+
+func (mux *wsMux) route(ch ws.Channel) *wsRoute {
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
+
+	client, ok := mux.channels[ch]
+	if !ok {
+		return nil
+	}
+
+	return mux.routes[client.protocol]
+}
+
+// Host returns the host the given client connected to
+func (mux *wsMux) Host(id string) string {
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
+
+	for ch, client := range mux.channels {
+		if ch.ID() == id {
+			return client.host
+		}
+	}
+
+	return ""
+}
+
+// negotiate mirrors the websocket server's subprotocol negotiation
+func (mux *wsMux) negotiate(r *http.Request) string {
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
+
+	headers := r.Header.Values("Sec-Websocket-Protocol")
+	if len(headers) == 0 {
+		return mux.fallback
+	}
+
+	for _, header := range headers {
+		for proto := range strings.SplitSeq(header, ",") {
+			if proto = strings.TrimSpace(proto); mux.routes[proto] != nil {
+				return proto
+			}
+		}
+	}
+
+	return ""
+}
+
+func (mux *wsMux) checkClient(id string, r *http.Request) bool {
+	protocol := mux.negotiate(r)
+
+	mux.mu.RLock()
+	route, ok := mux.routes[protocol]
+	mux.mu.RUnlock()
+
+	// the websocket server would accept the connection without subprotocol
+	if !ok {
+		return false
+	}
+
+	if route.checkClient != nil && !route.checkClient(id, r) {
+		return false
+	}
+
+	mux.mu.Lock()
+	mux.pending[id] = wsClient{protocol: protocol, host: r.Host}
+	mux.mu.Unlock()
+
+	return true
+}
+
+func (mux *wsMux) clientConnected(ch ws.Channel) {
+	mux.mu.Lock()
+	client, ok := mux.pending[ch.ID()]
+	if ok {
+		delete(mux.pending, ch.ID())
+		mux.channels[ch] = client
+	}
+	mux.mu.Unlock()
+
+	if route := mux.route(ch); route != nil && route.connected != nil {
+		route.connected(ch)
+	}
+}
+
+// clientDisconnected removes the connection's subprotocol. A reconnected client's
+// new connection is not affected as connections are tracked by channel.
+func (mux *wsMux) clientDisconnected(ch ws.Channel) {
+	if route := mux.route(ch); route != nil && route.disconnected != nil {
+		route.disconnected(ch)
+	}
+
+	mux.mu.Lock()
+	delete(mux.channels, ch)
+	mux.mu.Unlock()
+}
+
+func (mux *wsMux) message(ch ws.Channel, data []byte) error {
+	route := mux.route(ch)
+	if route == nil || route.handler == nil {
+		return errors.New("no message handler set")
+	}
+
+	return route.handler(ch, data)
+}
+
+// wsRoute is the websocket server as seen by a single ocppj endpoint
+type wsRoute struct {
+	ws.Server
+	mux *wsMux
+
+	checkClient  ws.CheckClientHandler
+	connected    ws.ConnectedHandler
+	disconnected func(ws.Channel)
+	handler      ws.MessageHandler
+	started      bool // guarded by mux mutex
+}
+
+func (r *wsRoute) SetCheckClientHandler(handler ws.CheckClientHandler) {
+	r.checkClient = handler
+}
+
+func (r *wsRoute) SetNewClientHandler(handler ws.ConnectedHandler) {
+	r.connected = handler
+}
+
+func (r *wsRoute) SetDisconnectedClientHandler(handler func(ws.Channel)) {
+	r.disconnected = handler
+}
+
+func (r *wsRoute) SetMessageHandler(handler ws.MessageHandler) {
+	r.handler = handler
+}
+
+// Start starts the shared websocket server once all routes have been started.
+// Only the last call blocks while the server is running.
+func (r *wsRoute) Start(port int, listenPath string) {
+	r.mux.mu.Lock()
+	r.started = true
+	ready := true
+	for _, route := range r.mux.routes {
+		ready = ready && route.started
+	}
+	r.mux.mu.Unlock()
+
+	if ready && r.mux.started.CompareAndSwap(false, true) {
+		r.Server.Start(port, listenPath)
+	}
+}
@@ -0,0 +1,77 @@
+package ocpp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/lorenzodonini/ocpp-go/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testChannel struct {
+	ws.Channel
+	id string
+}
+
+func (ch testChannel) ID() string {
+	return ch.id
+}
+
+func TestMuxNegotiate(t *testing.T) {
+	mux := newWsMux(ws.NewServer(), "ocpp1.6")
+	mux.Route("ocpp1.6")
+	mux.Route("ocpp2.0.1")
+
+	for _, tc := range []struct {
+		header   []string
+		expected string
+	}{
+		{nil, "ocpp1.6"},
+		{[]string{"ocpp2.0.1"}, "ocpp2.0.1"},
+		{[]string{"ocpp2.1, ocpp2.0.1"}, "ocpp2.0.1"},
+		{[]string{"foo"}, ""},
+	} {
+		r := &http.Request{Header: http.Header{"Sec-Websocket-Protocol": tc.header}}
+
+		// unsupported subprotocols are rejected
+		assert.Equal(t, tc.expected != "", mux.checkClient("cp", r), tc.header)
+		assert.Equal(t, tc.expected, mux.pending["cp"].protocol, tc.header)
+
+		ch := &testChannel{id: "cp"}
+		mux.clientConnected(ch)
+		assert.Empty(t, mux.pending)
+
+		mux.clientDisconnected(ch)
+		assert.Empty(t, mux.channels)
+	}
+}
+
+func TestMuxReconnect(t *testing.T) {
+	mux := newWsMux(ws.NewServer(), "ocpp1.6")
+	route := mux.Route("ocpp1.6")
+
+	var received []ws.Channel
+	route.SetMessageHandler(func(ch ws.Channel, data []byte) error {
+		received = append(received, ch)
+		return nil
+	})
+
+	r := &http.Request{Header: http.Header{}, Host: "192.0.2.1:8887"}
+
+	require.True(t, mux.checkClient("cp", r))
+	old := &testChannel{id: "cp"}
+	mux.clientConnected(old)
+
+	// station reconnects before the old connection's disconnect is handled
+	require.True(t, mux.checkClient("cp", r))
+	ch := &testChannel{id: "cp"}
+	mux.clientConnected(ch)
+	mux.clientDisconnected(old)
+
+	require.NoError(t, mux.message(ch, nil))
+	assert.Equal(t, []ws.Channel{ch}, received)
+
+	assert.Error(t, mux.message(old, nil))
+	assert.Equal(t, "192.0.2.1:8887", mux.Host("cp"))
+}
@@ -0,0 +1,199 @@
+package charger
+
+import (
+	"context"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ws"
+	"github.com/samber/lo"
+)
+
+func (suite *ocppTestSuite) startChargingStation(id string, evseId int) (ocpp201.ChargingStation, *ChargingStationHandler) {
+	// set a handler for all callback functions
+	handler := &ChargingStationHandler{
+		triggerC: make(chan remotecontrol.MessageTrigger, 1),
+		profileC: make(chan *types.ChargingProfile, 1),
+	}
+
+	// ocppj endpoint with handler
+	client := ws.NewClient()
+	client.SetRequestedSubProtocol(types.V201Subprotocol)
+	dispatcher := ocppj.NewDefaultClientDispatcher(ocppj.NewFIFOClientQueue(0))
+	endpoint := ocppj.NewClient(id, client, dispatcher, nil, availability.Profile, meter.Profile, provisioning.Profile, remotecontrol.Profile, smartcharging.Profile, transactions.Profile)
+
+	// create charging station with handler
+	cs := ocpp201.NewChargingStation(id, endpoint, client)
+	cs.SetAvailabilityHandler(handler)
+	cs.SetProvisioningHandler(handler)
+	cs.SetRemoteControlHandler(handler)
+	cs.SetSmartChargingHandler(handler)
+
+	// let cs handle the trigger messages
+	go func() {
+		for msg := range handler.triggerC {
+			suite.handleTrigger201(cs, evseId, msg)
+		}
+	}()
+
+	return cs, handler
+}
+
+func (suite *ocppTestSuite) handleTrigger201(cs ocpp201.ChargingStation, evseId int, msg remotecontrol.MessageTrigger) {
+	switch msg {
+	case remotecontrol.MessageTriggerBootNotification:
+		if _, err := cs.BootNotification(provisioning.BootReasonTriggered, "model", "vendor"); err != nil {
+			suite.T().Log("BootNotification:", err)
+		}
+
+	case remotecontrol.MessageTriggerStatusNotification:
+		if _, err := cs.StatusNotification(types.Now(), availability.ConnectorStatusOccupied, evseId, 1); err != nil {
+			suite.T().Log("StatusNotification:", err)
+		}
+
+	case remotecontrol.MessageTriggerMeterValues:
+		if _, err := cs.MeterValues(evseId, []types.MeterValue{
+			{
+				Timestamp: *types.Now(),
+				SampledValue: []types.SampledValue{
+					{Measurand: types.MeasurandPowerActiveImport, Value: 0},
+				},
+			},
+		}); err != nil {
+			suite.T().Log("MeterValues:", err)
+		}
+	}
+}
+
+func (suite *ocppTestSuite) TestOcpp201() {
+	// charging station - remote
+	cs, handler := suite.startChargingStation("test-201", 1)
+	suite.Require().NoError(cs.Start(ocppTestUrl))
+	suite.Require().True(cs.IsConnected())
+
+	// charging station - local
+	c, err := NewOCPP(context.TODO(), "test-201", 1, "", "", 0, false, false, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+	suite.True(c.cp.IsOCPP201())
+
+	// device model variables mapped to configuration
+	suite.Equal(1, c.cp.StackLevel)
+	suite.True(c.cp.HasMeasurement("Power.Active.Import"))
+
+	// occupied without transaction
+	status, err := c.Status()
+	suite.Require().NoError(err)
+	suite.Equal(api.StatusB, status)
+
+	// start transaction
+	{
+		_, err := cs.TransactionEvent(transactions.TransactionEventStarted, types.Now(), transactions.TriggerReasonAuthorized, 0,
+			transactions.Transaction{
+				TransactionID: "txn-1",
+				ChargingState: transactions.ChargingStateCharging,
+			},
+			func(request *transactions.TransactionEventRequest) {
+				request.Evse = &types.EVSE{ID: 1, ConnectorID: lo.ToPtr(1)}
+				request.IDToken = &types.IdToken{IdToken: "tag", Type: types.IdTokenTypeISO14443}
+				request.MeterValue = []types.MeterValue{{
+					Timestamp: *types.Now(),
+					SampledValue: []types.SampledValue{
+						{Measurand: types.MeasurandPowerActiveImport, Value: 11, UnitOfMeasure: &types.UnitOfMeasure{Unit: "kW"}},
+						{Measurand: types.MeasurandEnergyActiveImportRegister, Value: 12, UnitOfMeasure: &types.UnitOfMeasure{Unit: "Wh", Multiplier: lo.ToPtr(3)}},
+					},
+				}}
+			})
+		suite.Require().NoError(err)
+
+		status, err := c.Status()
+		suite.Require().NoError(err)
+		suite.Equal(api.StatusC, status)
+
+		id, err := c.Identify()
+		suite.Require().NoError(err)
+		suite.Equal("tag", id)
+
+		txn, err := c.Connector().TransactionID()
+		suite.Require().NoError(err)
+		suite.NotZero(txn)
+
+		power, err := c.Connector().CurrentPower()
+		suite.Require().NoError(err)
+		suite.Equal(11e3, power)
+
+		energy, err := c.Connector().TotalEnergy()
+		suite.Require().NoError(err)
+		suite.Equal(12.0, energy)
+	}
+
+	// ISO 15118 soc
+	{
+		_, err := cs.NotifyEVChargingNeeds(1, smartcharging.ChargingNeeds{
+			RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC,
+			DCChargingParameters: &smartcharging.DCChargingParameters{
+				EVMaxCurrent:  100,
+				EVMaxVoltage:  400,
+				StateOfCharge: lo.ToPtr(42),
+			},
+		})
+		suite.Require().NoError(err)
+
+		soc, err := c.Connector().Soc()
+		suite.Require().NoError(err)
+		suite.Equal(42.0, soc)
+	}
+
+	// charging profile
+	{
+		suite.Require().NoError(c.MaxCurrent(16))
+
+		select {
+		case profile := <-handler.profileC:
+			suite.Require().Len(profile.ChargingSchedule, 1)
+			suite.Equal(types.ChargingProfilePurposeTxDefaultProfile, profile.ChargingProfilePurpose)
+			suite.Equal(16.0, profile.ChargingSchedule[0].ChargingSchedulePeriod[0].Limit)
+		case <-time.After(time.Second):
+			suite.Fail("charging profile timeout")
+		}
+	}
+
+	// end transaction
+	{
+		_, err := cs.TransactionEvent(transactions.TransactionEventEnded, types.Now(), transactions.TriggerReasonEVCommunicationLost, 1,
+			transactions.Transaction{
+				TransactionID: "txn-1",
+				ChargingState: transactions.ChargingStateEVConnected,
+			})
+		suite.Require().NoError(err)
+
+		status, err := c.Status()
+		suite.Require().NoError(err)
+		suite.Equal(api.StatusB, status)
+
+		txn, err := c.Connector().TransactionID()
+		suite.Require().NoError(err)
+		suite.Zero(txn)
+
+		reason, err := c.StatusReason()
+		suite.Require().NoError(err)
+		suite.Equal(api.ReasonDisconnectRequired, reason)
+	}
+
+	// 1.6 and 2.0.1 charge points share the same endpoint
+	cp, _ := suite.startChargePoint("test-201-16", 1)
+	suite.Require().NoError(cp.Start(ocppTestUrl))
+	suite.Require().True(cp.IsConnected())
+
+	c16, err := NewOCPP(context.TODO(), "test-201-16", 1, "", "", 0, false, false, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+	suite.False(c16.cp.IsOCPP201())
+}
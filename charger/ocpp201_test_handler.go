@@ -0,0 +1,118 @@
+package charger
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+type ChargingStationHandler struct {
+	triggerC chan remotecontrol.MessageTrigger
+	profileC chan *types.ChargingProfile
+}
+
+// provisioning
+
+func (handler *ChargingStationHandler) OnGetBaseReport(request *provisioning.GetBaseReportRequest) (*provisioning.GetBaseReportResponse, error) {
+	return provisioning.NewGetBaseReportResponse(types.GenericDeviceModelStatusNotSupported), nil
+}
+
+func (handler *ChargingStationHandler) OnGetReport(request *provisioning.GetReportRequest) (*provisioning.GetReportResponse, error) {
+	return provisioning.NewGetReportResponse(types.GenericDeviceModelStatusNotSupported), nil
+}
+
+func (handler *ChargingStationHandler) OnGetVariables(request *provisioning.GetVariablesRequest) (*provisioning.GetVariablesResponse, error) {
+	values := map[string]string{
+		"TxUpdatedMeasurands": "Power.Active.Import,Energy.Active.Import.Register",
+		"ProfileStackLevel":   "1",
+	}
+
+	var res []provisioning.GetVariableResult
+	for _, data := range request.GetVariableData {
+		result := provisioning.GetVariableResult{
+			AttributeStatus: provisioning.GetVariableStatusUnknownVariable,
+			Component:       data.Component,
+			Variable:        data.Variable,
+		}
+
+		if value, ok := values[data.Variable.Name]; ok {
+			result.AttributeStatus = provisioning.GetVariableStatusAccepted
+			result.AttributeValue = value
+		}
+
+		res = append(res, result)
+	}
+
+	return provisioning.NewGetVariablesResponse(res), nil
+}
+
+func (handler *ChargingStationHandler) OnReset(request *provisioning.ResetRequest) (*provisioning.ResetResponse, error) {
+	return provisioning.NewResetResponse(provisioning.ResetStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnSetNetworkProfile(request *provisioning.SetNetworkProfileRequest) (*provisioning.SetNetworkProfileResponse, error) {
+	return provisioning.NewSetNetworkProfileResponse(provisioning.SetNetworkProfileStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnSetVariables(request *provisioning.SetVariablesRequest) (*provisioning.SetVariablesResponse, error) {
+	var res []provisioning.SetVariableResult
+	for _, data := range request.SetVariableData {
+		res = append(res, provisioning.SetVariableResult{
+			AttributeStatus: provisioning.SetVariableStatusAccepted,
+			Component:       data.Component,
+			Variable:        data.Variable,
+		})
+	}
+
+	return provisioning.NewSetVariablesResponse(res), nil
+}
+
+// availability
+
+func (handler *ChargingStationHandler) OnChangeAvailability(request *availability.ChangeAvailabilityRequest) (*availability.ChangeAvailabilityResponse, error) {
+	defer func() { handler.triggerC <- remotecontrol.MessageTriggerStatusNotification }()
+	return availability.NewChangeAvailabilityResponse(availability.ChangeAvailabilityStatusAccepted), nil
+}
+
+// remote control
+
+func (handler *ChargingStationHandler) OnRequestStartTransaction(request *remotecontrol.RequestStartTransactionRequest) (*remotecontrol.RequestStartTransactionResponse, error) {
+	return remotecontrol.NewRequestStartTransactionResponse(remotecontrol.RequestStartStopStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnRequestStopTransaction(request *remotecontrol.RequestStopTransactionRequest) (*remotecontrol.RequestStopTransactionResponse, error) {
+	return remotecontrol.NewRequestStopTransactionResponse(remotecontrol.RequestStartStopStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnTriggerMessage(request *remotecontrol.TriggerMessageRequest) (*remotecontrol.TriggerMessageResponse, error) {
+	defer func() { handler.triggerC <- request.RequestedMessage }()
+	return remotecontrol.NewTriggerMessageResponse(remotecontrol.TriggerMessageStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnUnlockConnector(request *remotecontrol.UnlockConnectorRequest) (*remotecontrol.UnlockConnectorResponse, error) {
+	return remotecontrol.NewUnlockConnectorResponse(remotecontrol.UnlockStatusUnlocked), nil
+}
+
+// smart charging
+
+func (handler *ChargingStationHandler) OnClearChargingProfile(request *smartcharging.ClearChargingProfileRequest) (*smartcharging.ClearChargingProfileResponse, error) {
+	return smartcharging.NewClearChargingProfileResponse(smartcharging.ClearChargingProfileStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnGetChargingProfiles(request *smartcharging.GetChargingProfilesRequest) (*smartcharging.GetChargingProfilesResponse, error) {
+	return smartcharging.NewGetChargingProfilesResponse(smartcharging.GetChargingProfileStatusNoProfiles), nil
+}
+
+func (handler *ChargingStationHandler) OnGetCompositeSchedule(request *smartcharging.GetCompositeScheduleRequest) (*smartcharging.GetCompositeScheduleResponse, error) {
+	return smartcharging.NewGetCompositeScheduleResponse(smartcharging.GetCompositeScheduleStatusRejected, request.EvseID), nil
+}
+
+func (handler *ChargingStationHandler) OnSetChargingProfile(request *smartcharging.SetChargingProfileRequest) (*smartcharging.SetChargingProfileResponse, error) {
+	select {
+	case handler.profileC <- request.ChargingProfile:
+	default:
+	}
+	return smartcharging.NewSetChargingProfileResponse(smartcharging.ChargingProfileStatusAccepted), nil
+}
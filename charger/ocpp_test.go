@@ -9,6 +9,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/charger/ocpp"
+	"github.com/evcc-io/evcc/util"
 	ocppapi "github.com/lorenzodonini/ocpp-go/ocpp"
 	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
@@ -20,6 +21,7 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/lorenzodonini/ocpp-go/ocppj"
 	"github.com/lorenzodonini/ocpp-go/ws"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -34,7 +36,9 @@ func TestOcpp(t *testing.T) {
 
 type ocppTestSuite struct {
 	suite.Suite
-	clock *clock.Mock
+	clock      *clock.Mock
+	localListC chan *localauth.SendLocalListRequest
+	firmwareC  chan string
 }
 
 func (suite *ocppTestSuite) SetupSuite() {
@@ -45,13 +49,17 @@ func (suite *ocppTestSuite) SetupSuite() {
 	ocppj.SetLogger(&ocppLogger{suite.T()})
 
 	suite.clock = clock.NewMock()
+	suite.localListC = make(chan *localauth.SendLocalListRequest, 1)
+	suite.firmwareC = make(chan string, 1)
 	suite.NotNil(ocpp.Instance())
 }
 
 func (suite *ocppTestSuite) startChargePoint(id string, connectorId int) (ocpp16.ChargePoint, *ocppj.Client) {
 	// set a handler for all callback functions
 	handler := &ChargePointHandler{
-		triggerC: make(chan remotetrigger.MessageTrigger, 1),
+		triggerC:   make(chan remotetrigger.MessageTrigger, 1),
+		localListC: suite.localListC,
+		firmwareC:  suite.firmwareC,
 	}
 
 	// ocppj endpoint with handler
@@ -63,6 +71,8 @@ func (suite *ocppTestSuite) startChargePoint(id string, connectorId int) (ocpp16
 	// create charge point with handler
 	cp := ocpp16.NewChargePoint(id, endpoint, client)
 	cp.SetCoreHandler(handler)
+	cp.SetFirmwareManagementHandler(handler)
+	cp.SetLocalAuthListHandler(handler)
 	cp.SetRemoteTriggerHandler(handler)
 	cp.SetSmartChargingHandler(handler)
 
@@ -113,7 +123,7 @@ func (suite *ocppTestSuite) TestConnect() {
 	suite.Require().True(cp1.IsConnected())
 
 	// 1st charge point- local
-	c1, err := NewOCPP(context.TODO(), "test-1", 1, "", "", 0, false, false, true, ocppTestConnectTimeout)
+	c1, err := NewOCPP(context.TODO(), "test-1", 1, "", "", 0, false, false, true, false, ocppTestConnectTimeout)
 	suite.Require().NoError(err)
 
 	// status and meter values
@@ -162,7 +172,7 @@ func (suite *ocppTestSuite) TestConnect() {
 	suite.Require().True(cp2.IsConnected())
 
 	// 2nd charge point - local
-	c2, err := NewOCPP(context.TODO(), "test-2", 1, "", "", 0, false, false, true, ocppTestConnectTimeout)
+	c2, err := NewOCPP(context.TODO(), "test-2", 1, "", "", 0, false, false, true, false, ocppTestConnectTimeout)
 	suite.Require().NoError(err)
 
 	{
@@ -204,7 +214,7 @@ func (suite *ocppTestSuite) TestAutoStart() {
 	suite.Require().True(cp1.IsConnected())
 
 	// 1st charge point- local
-	c1, err := NewOCPP(context.TODO(), "test-3", 1, "", "", 0, false, false, false, ocppTestConnectTimeout)
+	c1, err := NewOCPP(context.TODO(), "test-3", 1, "", "", 0, false, false, false, false, ocppTestConnectTimeout)
 	suite.Require().NoError(err)
 
 	// status and meter values
@@ -251,7 +261,127 @@ func (suite *ocppTestSuite) TestTimeout() {
 	})
 
 	// 1st charge point- local
-	_, err := NewOCPP(context.TODO(), "test-4", 1, "", "", 0, false, false, false, ocppTestConnectTimeout)
+	_, err := NewOCPP(context.TODO(), "test-4", 1, "", "", 0, false, false, false, false, ocppTestConnectTimeout)
 
 	suite.Require().NoError(err)
 }
+
+type whitelistAuthorizer []string
+
+func (a whitelistAuthorizer) AuthorizedTags() []string {
+	return a
+}
+
+func (suite *ocppTestSuite) TestWhitelist() {
+	ocpp.SetAuthorizer(whitelistAuthorizer{"tag-1", "tag-2*"})
+	defer ocpp.SetAuthorizer(nil)
+
+	// charge point- remote
+	cp, _ := suite.startChargePoint("test-whitelist", 1)
+	suite.Require().NoError(cp.Start(ocppTestUrl))
+	suite.Require().True(cp.IsConnected())
+
+	// charge point- local
+	_, err := NewOCPP(context.TODO(), "test-whitelist", 1, "", "", 0, false, false, false, true, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+
+	// placeholders are not sent to the charge point
+	select {
+	case req := <-suite.localListC:
+		suite.Equal(localauth.UpdateTypeFull, req.UpdateType)
+		suite.Require().Len(req.LocalAuthorizationList, 1)
+		suite.Equal("tag-1", req.LocalAuthorizationList[0].IdTag)
+	case <-time.After(ocpp.Timeout):
+		suite.Fail("local list timeout")
+	}
+
+	for tag, status := range map[string]types.AuthorizationStatus{
+		"tag-1":   types.AuthorizationStatusAccepted,
+		"TAG-1":   types.AuthorizationStatusAccepted,
+		"tag-234": types.AuthorizationStatusAccepted,
+		"unknown": types.AuthorizationStatusInvalid,
+	} {
+		res, err := cp.Authorize(tag)
+		suite.Require().NoError(err)
+		suite.Equal(status, res.IdTagInfo.Status, tag)
+	}
+}
+
+func TestOcppChargingSchedule(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	c := &OCPP{
+		cp:     ocpp.NewChargePoint(util.NewLogger("foo"), "test"),
+		phases: 3,
+		plan: api.Rates{
+			{Start: now.Add(-30 * time.Minute), End: now.Add(30 * time.Minute)},
+			{Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour)},
+			{Start: now.Add(3 * time.Hour), End: now.Add(4 * time.Hour)},
+		},
+		planCurrent: 16,
+	}
+
+	profile, refresh := c.createTxDefaultChargingSchedule(10)
+	start := profile.ChargingSchedule.StartSchedule.Time
+
+	offset := func(d time.Duration) int {
+		return int(now.Add(d).Sub(start).Seconds())
+	}
+
+	type period struct {
+		start int
+		limit float64
+	}
+
+	var res []period
+	for _, p := range profile.ChargingSchedule.ChargingSchedulePeriod {
+		res = append(res, period{p.StartPeriod, p.Limit})
+	}
+
+	require.Equal(t, []period{
+		{0, 10},
+		{offset(30 * time.Minute), 0},
+		{offset(2 * time.Hour), 16},
+		{offset(4 * time.Hour), 0},
+	}, res)
+	require.True(t, refresh.Equal(now.Add(30*time.Minute)), refresh)
+
+	// limited number of periods
+	c.cp.ChargingScheduleMaxPeriods = 2
+	profile, _ = c.createTxDefaultChargingSchedule(10)
+	require.Len(t, profile.ChargingSchedule.ChargingSchedulePeriod, 2)
+}
+
+func (suite *ocppTestSuite) TestStationManagement() {
+	// charge point- remote
+	cp, _ := suite.startChargePoint("test-station", 1)
+	suite.Require().NoError(cp.Start(ocppTestUrl))
+	suite.Require().True(cp.IsConnected())
+
+	// charge point- local
+	c, err := NewOCPP(context.TODO(), "test-station", 1, "", "", 0, false, false, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+
+	cs, ok := ocpp.Started()
+	suite.Require().True(ok)
+	suite.Contains(cs.Chargepoints(), "test-station")
+
+	suite.Require().NoError(c.cp.ResetRequest(core.ResetTypeSoft))
+
+	fileName, err := c.cp.GetDiagnosticsRequest("http://localhost/diagnostics")
+	suite.Require().NoError(err)
+	suite.Equal("diagnostics.log", fileName)
+
+	suite.Require().NoError(c.cp.UpdateFirmwareRequest("http://localhost/firmware.bin", time.Now()))
+
+	select {
+	case location := <-suite.firmwareC:
+		suite.Equal("http://localhost/firmware.bin", location)
+	case <-time.After(time.Second):
+		suite.Fail("firmware update timeout")
+	}
+
+	_, err = cp.FirmwareStatusNotification(firmware.FirmwareStatusInstalled)
+	suite.Require().NoError(err)
+	suite.Equal(firmware.FirmwareStatusInstalled, c.cp.FirmwareStatus())
+}
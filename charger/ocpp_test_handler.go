@@ -2,13 +2,17 @@ package charger
 
 import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 )
 
 type ChargePointHandler struct {
-	triggerC chan remotetrigger.MessageTrigger
+	triggerC   chan remotetrigger.MessageTrigger
+	localListC chan *localauth.SendLocalListRequest
+	firmwareC  chan string
 }
 
 // core
@@ -60,6 +64,38 @@ func (handler *ChargePointHandler) OnUnlockConnector(request *core.UnlockConnect
 	return core.NewUnlockConnectorConfirmation(core.UnlockStatusUnlocked), nil
 }
 
+// firmware
+
+func (handler *ChargePointHandler) OnGetDiagnostics(request *firmware.GetDiagnosticsRequest) (confirmation *firmware.GetDiagnosticsConfirmation, err error) {
+	res := firmware.NewGetDiagnosticsConfirmation()
+	res.FileName = "diagnostics.log"
+	return res, nil
+}
+
+func (handler *ChargePointHandler) OnUpdateFirmware(request *firmware.UpdateFirmwareRequest) (confirmation *firmware.UpdateFirmwareConfirmation, err error) {
+	select {
+	case handler.firmwareC <- request.Location:
+	default:
+	}
+	return firmware.NewUpdateFirmwareConfirmation(), nil
+}
+
+// local auth list
+
+func (handler *ChargePointHandler) OnGetLocalListVersion(request *localauth.GetLocalListVersionRequest) (confirmation *localauth.GetLocalListVersionConfirmation, err error) {
+	return localauth.NewGetLocalListVersionConfirmation(0), nil
+}
+
+func (handler *ChargePointHandler) OnSendLocalList(request *localauth.SendLocalListRequest) (confirmation *localauth.SendLocalListConfirmation, err error) {
+	select {
+	case handler.localListC <- request:
+	default:
+	}
+	return localauth.NewSendLocalListConfirmation(localauth.UpdateStatusAccepted), nil
+}
+
+// remote trigger
+
 func (handler *ChargePointHandler) OnTriggerMessage(request *remotetrigger.TriggerMessageRequest) (confirmation *remotetrigger.TriggerMessageConfirmation, err error) {
 	defer func() { handler.triggerC <- request.RequestedMessage }()
 	return remotetrigger.NewTriggerMessageConfirmation(remotetrigger.TriggerMessageStatusAccepted), nil
@@ -31,9 +31,12 @@ import (
 // SgReady charger implementation
 type SgReady struct {
 	*embed
-	mode  int64
-	modeS func(int64) error
-	modeG func() (int64, error)
+	log     *util.Logger
+	mode    int64
+	modeS   func(int64) error
+	modeG   func() (int64, error)
+	enabled bool // requested boost state
+	dimmed  bool // dimm mode entered due to circuit curtailment
 
 	// optional power setter for devices that support SGReady with power envelope
 	power     int64
@@ -111,6 +114,7 @@ func NewSgReadyFromConfig(ctx context.Context, other map[string]interface{}) (ap
 func NewSgReady(ctx context.Context, embed *embed, modeS func(int64) error, modeG func() (int64, error), maxPowerS func(int64) error) (*SgReady, error) {
 	res := &SgReady{
 		embed:     embed,
+		log:       util.NewLogger("sgready"),
 		mode:      Normal,
 		modeS:     modeS,
 		modeG:     modeG,
@@ -127,8 +131,67 @@ func (wb *SgReady) getMode() (int64, error) {
 	return wb.modeG()
 }
 
+// curtailed returns true if the loadpoint's circuit is curtailed by the grid operator
+func (wb *SgReady) curtailed() bool {
+	if wb.lp == nil {
+		return false
+	}
+
+	c := wb.lp.GetCircuit()
+	return c != nil && c.Curtailed()
+}
+
+// update drives the sgready mode from grid operator curtailment and the requested boost state.
+// Boost hysteresis is left to the loadpoint's enable and disable delays.
+func (wb *SgReady) update(force bool) error {
+	mode := Normal
+	if wb.enabled {
+		mode = Boost
+	}
+
+	curtailed := wb.curtailed()
+
+	if curtailed {
+		if wb.dimmed {
+			return nil
+		}
+		mode = Dimm
+	}
+
+	if mode == wb.mode && curtailed == wb.dimmed && !force {
+		return nil
+	}
+
+	err := wb.modeS(mode)
+	if mode == Dimm && errors.Is(err, api.ErrNotAvailable) {
+		// device does not support dimm mode, fall back to normal operation
+		mode = Normal
+		err = wb.modeS(mode)
+	}
+	if err != nil {
+		return err
+	}
+
+	if curtailed != wb.dimmed {
+		if curtailed {
+			wb.log.DEBUG.Println("circuit curtailed: entering dimm mode")
+		} else {
+			wb.log.DEBUG.Println("circuit curtailment released")
+		}
+	}
+
+	wb.mode = mode
+	wb.dimmed = curtailed
+
+	return wb.setMaxPower(wb.power)
+}
+
 // Status implements the api.Charger interface
 func (wb *SgReady) Status() (api.ChargeStatus, error) {
+	if err := wb.update(false); err != nil {
+		return api.StatusNone, err
+	}
+
 	if wb.lp != nil && wb.lp.GetMode() == api.ModeOff {
 		return api.StatusA, nil
 	}
@@ -139,6 +202,10 @@ func (wb *SgReady) Status() (api.ChargeStatus, error) {
 	}
 
 	if mode == Dimm {
+		// dimm mode entered due to circuit curtailment
+		if wb.dimmed {
+			return api.StatusB, nil
+		}
 		return api.StatusNone, errors.New("dimm mode")
 	}
 
@@ -148,21 +215,19 @@ func (wb *SgReady) Status() (api.ChargeStatus, error) {
 
 // Enabled implements the api.Charger interface
 func (wb *SgReady) Enabled() (bool, error) {
+	// report requested state while dimmed
+	if wb.dimmed {
+		return wb.enabled, nil
+	}
+
 	mode, err := wb.getMode()
 	return mode == Boost, err
 }
 
 // Enable implements the api.Charger interface
 func (wb *SgReady) Enable(enable bool) error {
-	mode := map[bool]int64{false: Normal, true: Boost}[enable]
-
-	if err := wb.modeS(mode); err != nil {
-		return err
-	}
-
-	wb.mode = mode
-
-	return wb.setMaxPower(wb.power)
+	wb.enabled = enable
+	return wb.update(true)
 }
 
 // MaxCurrent implements the api.Charger interface
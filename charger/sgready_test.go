@@ -0,0 +1,101 @@
+package charger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSgReadyCurtailment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mode := Normal
+	modeS := func(m int64) error {
+		mode = m
+		return nil
+	}
+
+	wb, err := NewSgReady(context.TODO(), new(embed), modeS, nil, nil)
+	require.NoError(t, err)
+
+	var curtailed bool
+	circ := api.NewMockCircuit(ctrl)
+	circ.EXPECT().Curtailed().DoAndReturn(func() bool { return curtailed }).AnyTimes()
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetCircuit().Return(circ).AnyTimes()
+	lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+	wb.LoadpointControl(lp)
+
+	status := func() api.ChargeStatus {
+		t.Helper()
+		res, err := wb.Status()
+		require.NoError(t, err)
+		return res
+	}
+
+	// boost is applied immediately, hysteresis is handled by the loadpoint
+	require.NoError(t, wb.Enable(true))
+	assert.Equal(t, Boost, mode)
+	assert.Equal(t, api.StatusC, status())
+
+	require.NoError(t, wb.Enable(false))
+	assert.Equal(t, Normal, mode)
+	assert.Equal(t, api.StatusB, status())
+
+	// curtailment enters dimm immediately
+	require.NoError(t, wb.Enable(true))
+	curtailed = true
+	assert.Equal(t, api.StatusB, status())
+	assert.Equal(t, Dimm, mode)
+
+	enabled, err := wb.Enabled()
+	require.NoError(t, err)
+	assert.True(t, enabled, "enabled")
+
+	// release returns to requested mode
+	curtailed = false
+	assert.Equal(t, api.StatusC, status())
+	assert.Equal(t, Boost, mode)
+}
+
+func TestSgReadyDimmNotAvailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	modes := []int64{}
+	modeS := func(m int64) error {
+		if m == Dimm {
+			return api.ErrNotAvailable
+		}
+		modes = append(modes, m)
+		return nil
+	}
+
+	wb, err := NewSgReady(context.TODO(), new(embed), modeS, nil, nil)
+	require.NoError(t, err)
+
+	var curtailed bool
+	circ := api.NewMockCircuit(ctrl)
+	circ.EXPECT().Curtailed().DoAndReturn(func() bool { return curtailed }).AnyTimes()
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetCircuit().Return(circ).AnyTimes()
+	lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+	wb.LoadpointControl(lp)
+
+	require.NoError(t, wb.Enable(true))
+	assert.Equal(t, []int64{Boost}, modes)
+
+	// dimm not supported, fall back to normal once
+	curtailed = true
+	for range 2 {
+		_, err := wb.Status()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int64{Boost, Normal}, modes)
+}
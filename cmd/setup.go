@@ -17,6 +17,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/charger"
+	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/core/circuit"
@@ -902,6 +903,12 @@ func configureDevices(conf globalconfig.All) error {
 	if err := configureMeters(conf.Meters, references.meter...); err != nil {
 		return &ClassError{ClassMeter, err}
 	}
+	// authorize charger id tags against vehicles and users
+	ocpp.SetAuthorizer(core.NewAuthorizer(db.Instance))
+
+	// provide firmware and diagnostics files to the stations
+	ocpp.SetFileServer(conf.Network.ExternalUrl, conf.Network.Port)
+
 	if err := configureChargers(conf.Chargers, references.charger...); err != nil {
 		return &ClassError{ClassCharger, err}
 	}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/util/config"
+	"gorm.io/gorm"
+)
+
+// Authorizer provides the id tags authorized for charging, i.e. the configured
+// vehicles' identifiers and the users' RFID tags
+type Authorizer struct {
+	db *gorm.DB
+}
+
+// NewAuthorizer creates an id tag authorizer
+func NewAuthorizer(db *gorm.DB) *Authorizer {
+	return &Authorizer{db: db}
+}
+
+// AuthorizedTags returns the authorized id tags. Vehicle identifiers may contain * placeholders.
+func (a *Authorizer) AuthorizedTags() []string {
+	var res []string
+
+	for _, dev := range config.Vehicles().Devices() {
+		res = append(res, dev.Instance().Identifiers()...)
+	}
+
+	if a.db != nil {
+		var tags []session.UserTag
+		if err := a.db.Find(&tags).Error; err == nil {
+			for _, t := range tags {
+				res = append(res, t.Tag)
+			}
+		}
+	}
+
+	return res
+}
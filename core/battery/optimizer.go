@@ -0,0 +1,196 @@
+package battery
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+const (
+	maxIterations = 10    // max optimization passes
+	minGain       = 0.001 // min cost improvement per mode change
+)
+
+// Forecast is the expected energy flow and prices of a single slot
+type Forecast struct {
+	Start  time.Time
+	End    time.Time
+	Grid   float64 // grid price per kWh
+	FeedIn float64 // feed-in price per kWh
+	Solar  float64 // solar production in Wh
+	Load   float64 // household consumption in Wh
+}
+
+// Battery describes the battery's state and capabilities
+type Battery struct {
+	Capacity    float64 // capacity in kWh
+	Soc         float64 // current soc in %
+	MinSoc      float64 // minimum soc in %
+	ChargePower float64 // max grid charge power in W
+	Efficiency  float64 // charge efficiency
+}
+
+// Slot is a single battery schedule slot
+type Slot struct {
+	Start    time.Time       `json:"start"`
+	End      time.Time       `json:"end"`
+	Mode     api.BatteryMode `json:"mode"`
+	Soc      float64         `json:"soc"`      // expected soc at end of slot
+	Price    float64         `json:"price"`    // grid price
+	Override bool            `json:"override"` // mode set via api
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Slot) MarshalJSON() ([]byte, error) {
+	type slot Slot
+	return json.Marshal(struct {
+		slot
+		Mode string `json:"mode"`
+	}{
+		slot: slot(s),
+		Mode: s.Mode.String(),
+	})
+}
+
+// Schedule is a battery schedule sorted by time
+type Schedule []Slot
+
+// At returns the slot for the given time
+func (s Schedule) At(ts time.Time) (Slot, bool) {
+	for _, slot := range s {
+		if !slot.Start.After(ts) && slot.End.After(ts) {
+			return slot, true
+		}
+	}
+	return Slot{}, false
+}
+
+// MarshalMQTT implements server.MQTTMarshaler
+func (s Schedule) MarshalMQTT() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// simulate returns the total cost and resulting soc for the given modes
+func simulate(b Battery, fc []Forecast, modes []api.BatteryMode) (float64, []float64) {
+	capacity := b.Capacity * 1e3
+	minLevel := b.MinSoc / 100 * capacity
+	level := b.Soc / 100 * capacity
+
+	// energy left at the end is valued at the lowest grid price
+	residualPrice := fc[0].Grid
+	for _, f := range fc {
+		residualPrice = min(residualPrice, f.Grid)
+	}
+
+	var cost float64
+	socs := make([]float64, len(fc))
+
+	for i, f := range fc {
+		surplus := max(0, f.Solar-f.Load)
+		deficit := max(0, f.Load-f.Solar)
+
+		// surplus is always used for charging
+		charged := min(surplus*b.Efficiency, max(0, capacity-level))
+		level += charged
+		export := surplus - charged/b.Efficiency
+		imprt := deficit
+
+		switch modes[i] {
+		case api.BatteryNormal:
+			discharged := min(deficit, max(0, level-minLevel))
+			level -= discharged
+			imprt -= discharged
+
+		case api.BatteryCharge:
+			charged := min(b.ChargePower*f.End.Sub(f.Start).Hours()*b.Efficiency, max(0, capacity-level))
+			level += charged
+			imprt += charged / b.Efficiency
+		}
+
+		cost += (imprt*f.Grid - export*f.FeedIn) / 1e3
+		socs[i] = 100 * level / capacity
+	}
+
+	cost -= max(0, level-minLevel) * max(0, residualPrice) / 1e3
+
+	return cost, socs
+}
+
+// Optimize creates a lowest-cost battery schedule for the given forecast.
+// Starting from normal operation, slot modes are changed to hold and then charge as long as this reduces total cost.
+// Overrides are applied as given and not changed.
+func Optimize(b Battery, fc []Forecast, overrides map[time.Time]api.BatteryMode) Schedule {
+	if b.Capacity <= 0 || len(fc) == 0 {
+		return nil
+	}
+
+	modes := make([]api.BatteryMode, len(fc))
+	fixed := make([]bool, len(fc))
+
+	for i, f := range fc {
+		modes[i] = api.BatteryNormal
+		if mode, ok := overrides[f.Start]; ok {
+			modes[i] = mode
+			fixed[i] = true
+		}
+	}
+
+	best, _ := simulate(b, fc, modes)
+
+	// improve applies all single slot mode changes that reduce cost
+	improve := func(candidates []api.BatteryMode) bool {
+		var improved bool
+
+		for i := range fc {
+			if fixed[i] {
+				continue
+			}
+
+			for _, mode := range candidates {
+				if mode == modes[i] {
+					continue
+				}
+
+				prev := modes[i]
+				modes[i] = mode
+
+				if cost, _ := simulate(b, fc, modes); cost < best-minGain {
+					best = cost
+					improved = true
+				} else {
+					modes[i] = prev
+				}
+			}
+		}
+
+		return improved
+	}
+
+	for _, candidates := range [][]api.BatteryMode{
+		{api.BatteryHold, api.BatteryNormal},                    // save stored energy for expensive slots first
+		{api.BatteryCharge, api.BatteryHold, api.BatteryNormal}, // then add grid charging
+	} {
+		for range maxIterations {
+			if !improve(candidates) {
+				break
+			}
+		}
+	}
+
+	_, socs := simulate(b, fc, modes)
+
+	res := make(Schedule, 0, len(fc))
+	for i, f := range fc {
+		res = append(res, Slot{
+			Start:    f.Start,
+			End:      f.End,
+			Mode:     modes[i],
+			Soc:      socs[i],
+			Price:    f.Grid,
+			Override: fixed[i],
+		})
+	}
+
+	return res
+}
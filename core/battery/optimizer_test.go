@@ -0,0 +1,95 @@
+package battery
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func forecast(start time.Time, prices []float64, load float64) []Forecast {
+	res := make([]Forecast, 0, len(prices))
+	for i, p := range prices {
+		ts := start.Add(time.Duration(i) * time.Hour)
+		res = append(res, Forecast{
+			Start: ts,
+			End:   ts.Add(time.Hour),
+			Grid:  p,
+			Load:  load,
+		})
+	}
+	return res
+}
+
+func modes(s Schedule) []api.BatteryMode {
+	return lo.Map(s, func(s Slot, _ int) api.BatteryMode { return s.Mode })
+}
+
+func TestOptimizeHold(t *testing.T) {
+	clock := clock.NewMock()
+
+	b := Battery{Capacity: 10, Soc: 20, ChargePower: 5e3, Efficiency: 0.9}
+	fc := forecast(clock.Now(), []float64{0.1, 0.1, 0.4, 0.4}, 1e3)
+
+	// save stored energy for expensive slots
+	s := Optimize(b, fc, nil)
+	assert.Equal(t, []api.BatteryMode{api.BatteryHold, api.BatteryHold, api.BatteryNormal, api.BatteryNormal}, modes(s))
+	assert.Equal(t, 0.0, s[3].Soc)
+}
+
+func TestOptimizeCharge(t *testing.T) {
+	clock := clock.NewMock()
+
+	b := Battery{Capacity: 10, Soc: 0, ChargePower: 5e3, Efficiency: 0.9}
+	fc := forecast(clock.Now(), []float64{0.1, 0.4, 0.4}, 1e3)
+
+	// charge from grid for expensive slots
+	s := Optimize(b, fc, nil)
+	assert.Equal(t, []api.BatteryMode{api.BatteryCharge, api.BatteryNormal, api.BatteryNormal}, modes(s))
+
+	// no charging if price difference does not cover losses
+	fc = forecast(clock.Now(), []float64{0.38, 0.4, 0.4}, 1e3)
+	s = Optimize(b, fc, nil)
+	assert.Equal(t, []api.BatteryMode{api.BatteryNormal, api.BatteryNormal, api.BatteryNormal}, modes(s))
+}
+
+func TestOptimizeSolar(t *testing.T) {
+	clock := clock.NewMock()
+
+	b := Battery{Capacity: 10, Soc: 0, ChargePower: 5e3, Efficiency: 0.9}
+	fc := forecast(clock.Now(), []float64{0.1, 0.3, 0.4}, 1e3)
+	fc[1].Solar = 6e3
+
+	// solar surplus fills battery, no grid charging needed
+	s := Optimize(b, fc, nil)
+	assert.Equal(t, []api.BatteryMode{api.BatteryNormal, api.BatteryNormal, api.BatteryNormal}, modes(s))
+	assert.Equal(t, 45.0, s[1].Soc)
+}
+
+func TestOptimizeOverride(t *testing.T) {
+	clock := clock.NewMock()
+
+	b := Battery{Capacity: 10, Soc: 20, ChargePower: 5e3, Efficiency: 0.9}
+	fc := forecast(clock.Now(), []float64{0.1, 0.1, 0.4, 0.4}, 1e3)
+
+	s := Optimize(b, fc, map[time.Time]api.BatteryMode{
+		clock.Now(): api.BatteryNormal,
+	})
+	assert.Equal(t, []api.BatteryMode{api.BatteryNormal, api.BatteryCharge, api.BatteryNormal, api.BatteryNormal}, modes(s))
+	assert.True(t, s[0].Override)
+
+	slot, ok := s.At(clock.Now().Add(90 * time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, api.BatteryCharge, slot.Mode)
+}
+
+func TestScheduleJson(t *testing.T) {
+	b, err := json.Marshal(Slot{Mode: api.BatteryCharge})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"mode":"charge"`)
+}
@@ -32,9 +32,12 @@ type Circuit struct {
 	maxPower      float64                 // max allowed power
 	getMaxCurrent func() (float64, error) // dynamic max allowed current
 	getMaxPower   func() (float64, error) // dynamic max allowed power
+	maxUnbalance  float64                 // max allowed current difference between phases
+	curtailed     bool                    // curtailed by grid operator
 
-	current float64
-	power   float64
+	current  float64
+	currents [3]float64 // currents per phase
+	power    float64
 
 	currentUpdated time.Time
 	powerUpdated   time.Time
@@ -50,6 +53,7 @@ func NewFromConfig(ctx context.Context, log *util.Logger, other map[string]inter
 		MaxPower      float64        // the max allowed power
 		GetMaxCurrent *plugin.Config // dynamic max allowed current
 		GetMaxPower   *plugin.Config // dynamic max allowed power
+		MaxUnbalance  float64        // the max allowed current difference between phases
 		Timeout       time.Duration  // timeout between meter updates
 	}{
 		Timeout: time.Minute,
@@ -73,6 +77,13 @@ func NewFromConfig(ctx context.Context, log *util.Logger, other map[string]inter
 		return nil, err
 	}
 
+	if cc.MaxUnbalance > 0 {
+		if _, ok := meter.(api.PhaseCurrents); meter != nil && !ok {
+			return nil, fmt.Errorf("meter does not support phase currents")
+		}
+		circuit.maxUnbalance = cc.MaxUnbalance
+	}
+
 	circuit.getMaxPower, err = cc.GetMaxPower.FloatGetter(ctx)
 	if err != nil {
 		return nil, err
@@ -210,22 +221,54 @@ func (c *Circuit) SetMaxCurrent(current float64) {
 	c.maxCurrent = current
 }
 
+// Curtailed returns true if the circuit or any of its parents is curtailed by the grid operator
+func (c *Circuit) Curtailed() bool {
+	c.mu.RLock()
+	curtailed, parent := c.curtailed, c.parent
+	c.mu.RUnlock()
+
+	return curtailed || parent != nil && parent.Curtailed()
+}
+
+// SetCurtailed sets the grid operator curtailment status
+func (c *Circuit) SetCurtailed(curtailed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.curtailed = curtailed
+}
+
 // RegisterChild registers child circuit
 func (c *Circuit) RegisterChild(child api.Circuit) {
 	c.children = append(c.children, child)
 }
 
+// phaseCurrents returns the load's currents per phase. Loads without phase information are assumed to be symmetrical.
+func phaseCurrents(load api.CircuitMeasurements) [3]float64 {
+	if pc, ok := load.(api.CircuitPhaseCurrents); ok {
+		return pc.GetPhaseCurrents()
+	}
+
+	current := load.GetMaxPhaseCurrent()
+	return [3]float64{current, current, current}
+}
+
+func (c *Circuit) addLoad(load api.CircuitMeasurements) {
+	c.power += load.GetChargePower()
+	for i, current := range phaseCurrents(load) {
+		c.currents[i] += current
+	}
+}
+
 func (c *Circuit) updateLoadpoints(loadpoints []api.CircuitLoad) {
 	c.power = 0
-	c.current = 0
+	c.currents = [3]float64{}
 
 	for _, lp := range loadpoints {
 		if lp.GetCircuit() != c {
 			continue
 		}
 
-		c.power += lp.GetChargePower()
-		c.current += lp.GetMaxPhaseCurrent()
+		c.addLoad(lp)
 	}
 }
 
@@ -252,6 +295,9 @@ func (c *Circuit) updateMeters() error {
 			return err
 		}, modbus.Backoff()); err != nil {
 			c.overloadOnError(c.currentUpdated, &c.current)
+			for i := range c.currents {
+				c.overloadOnError(c.currentUpdated, &c.currents[i])
+			}
 			return fmt.Errorf("circuit currents: %w", err)
 		}
 
@@ -263,7 +309,8 @@ func (c *Circuit) updateMeters() error {
 			}
 		}
 
-		c.current = max(util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3))
+		c.currents = [3]float64{util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3)}
+		c.current = max(c.currents[0], c.currents[1], c.currents[2])
 		c.currentUpdated = time.Now()
 	}
 
@@ -303,10 +350,11 @@ func (c *Circuit) Update(loadpoints []api.CircuitLoad) (err error) {
 	// no meter available
 	c.updateLoadpoints(loadpoints)
 	for _, ch := range c.children {
-		c.power += ch.GetChargePower()
-		c.current += ch.GetMaxPhaseCurrent()
+		c.addLoad(ch)
 	}
 
+	c.current = max(c.currents[0], c.currents[1], c.currents[2])
+
 	return nil
 }
 
@@ -320,6 +368,11 @@ func (c *Circuit) GetMaxPhaseCurrent() float64 {
 	return c.current
 }
 
+// GetPhaseCurrents returns the actual currents per phase
+func (c *Circuit) GetPhaseCurrents() [3]float64 {
+	return c.currents
+}
+
 // ValidatePower validates power request
 func (c *Circuit) ValidatePower(old, new float64) float64 {
 	if maxPower := c.GetMaxPower(); maxPower != 0 {
@@ -342,24 +395,76 @@ func (c *Circuit) ValidatePower(old, new float64) float64 {
 	return c.parent.ValidatePower(old, new)
 }
 
-// ValidateCurrent validates current request
-func (c *Circuit) ValidateCurrent(old, new float64) float64 {
+// ValidatePhaseCurrents validates a symmetrical current request on the phases with non-zero new current.
+// Both max current per phase and max unbalance between phases are enforced.
+// It returns the allowed current per active phase.
+func (c *Circuit) ValidatePhaseCurrents(old, new [3]float64) float64 {
+	var (
+		active [3]bool
+		res    float64
+	)
+
+	for i, current := range new {
+		if current > 0 {
+			active[i] = true
+			res = max(res, current)
+		}
+	}
+
+	if res == 0 {
+		return 0
+	}
+
 	if maxCurrent := c.GetMaxCurrent(); maxCurrent != 0 {
-		delta := max(0, new-old)
-		potential := maxCurrent - c.current
+		for i := range c.currents {
+			if !active[i] {
+				continue
+			}
 
-		if delta > potential {
-			capped := min(new, max(0, old+potential))
-			c.log.DEBUG.Printf("validate current: %.3gA + (%.3gA -> %.3gA) > %.3gA capped at %.3gA", c.current, old, new, maxCurrent, capped)
-			new = capped
-		} else {
-			c.log.TRACE.Printf("validate current: %.3gA + (%.3gA -> %.3gA) <= %.3gA ok", c.current, old, new, maxCurrent)
+			delta := max(0, res-old[i])
+			potential := maxCurrent - c.currents[i]
+
+			if delta > potential {
+				capped := min(res, max(0, old[i]+potential))
+				c.log.DEBUG.Printf("validate current: L%d %.3gA + (%.3gA -> %.3gA) > %.3gA capped at %.3gA", i+1, c.currents[i], old[i], res, maxCurrent, capped)
+				res = capped
+			}
+		}
+	}
+
+	if c.maxUnbalance != 0 {
+		// phase currents without the requesting load
+		var base [3]float64
+		for i, current := range c.currents {
+			base[i] = current - old[i]
+		}
+
+		upper, lower := -math.MaxFloat64, math.MaxFloat64
+		for i, current := range base {
+			if active[i] {
+				upper = max(upper, current)
+			} else {
+				lower = min(lower, current)
+			}
+		}
+
+		// unbalance only increases with load on a subset of phases
+		if limit := lower + c.maxUnbalance - upper; lower != math.MaxFloat64 && res > limit {
+			capped := max(0, limit)
+			c.log.DEBUG.Printf("validate unbalance: %.3gA -> %.3gA exceeds %.3gA capped at %.3gA", lower, upper+res, c.maxUnbalance, capped)
+			res = capped
 		}
 	}
 
 	if c.parent == nil {
-		return new
+		return res
+	}
+
+	for i := range new {
+		if active[i] {
+			new[i] = res
+		}
 	}
 
-	return c.parent.ValidateCurrent(old, new)
+	return c.parent.ValidatePhaseCurrents(old, new)
 }
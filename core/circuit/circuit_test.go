@@ -134,8 +134,56 @@ func TestCircuitCurrents(t *testing.T) {
 		cm2.MockPhaseCurrents.EXPECT().Currents().Return(tc.c2, tc.c2, tc.c2, nil)
 		require.NoError(t, pc.Update(nil))
 
-		assert.Equal(t, tc.res, c1.ValidateCurrent(tc.old, tc.new), tc)
+		assert.Equal(t, tc.res, c1.ValidatePhaseCurrents([3]float64{tc.old, tc.old, tc.old}, [3]float64{tc.new, tc.new, tc.new}), tc)
 
 		ctrl.Finish()
 	}
 }
+
+func TestCircuitPhaseCurrents(t *testing.T) {
+	c, err := New(util.NewLogger("foo"), "foo", 32, 0, nil, 0)
+	require.NoError(t, err)
+	c.maxUnbalance = 20
+
+	for _, tc := range []struct {
+		currents, old, new [3]float64
+		res                float64
+	}{
+		// 3p symmetrical
+		{[3]float64{10, 10, 10}, [3]float64{}, [3]float64{16, 16, 16}, 16},
+		{[3]float64{20, 10, 10}, [3]float64{}, [3]float64{16, 16, 16}, 12},
+		// 1p on unloaded phase
+		{[3]float64{30, 10, 10}, [3]float64{}, [3]float64{0, 16, 0}, 16},
+		// 1p on loaded phase
+		{[3]float64{20, 10, 10}, [3]float64{}, [3]float64{16, 0, 0}, 10},
+		{[3]float64{20, 10, 10}, [3]float64{6, 0, 0}, [3]float64{16, 0, 0}, 16},
+		// unbalance
+		{[3]float64{10, 0, 0}, [3]float64{}, [3]float64{0, 0, 16}, 16},
+		{[3]float64{0, 0, 0}, [3]float64{}, [3]float64{0, 0, 32}, 20},
+		{[3]float64{16, 16, 0}, [3]float64{}, [3]float64{32, 32, 0}, 4},
+		{[3]float64{0, 20, 30}, [3]float64{0, 0, 16}, [3]float64{0, 0, 32}, 6},
+		// disable
+		{[3]float64{40, 40, 40}, [3]float64{16, 16, 16}, [3]float64{}, 0},
+	} {
+		c.currents = tc.currents
+		assert.Equal(t, tc.res, c.ValidatePhaseCurrents(tc.old, tc.new), tc)
+	}
+}
+
+func TestCircuitCurtailed(t *testing.T) {
+	parent, err := New(util.NewLogger("parent"), "parent", 0, 0, nil, 0)
+	require.NoError(t, err)
+
+	c, err := New(util.NewLogger("child"), "child", 0, 0, nil, 0)
+	require.NoError(t, err)
+	require.NoError(t, c.Wrap(parent))
+
+	assert.False(t, c.Curtailed())
+
+	parent.SetCurtailed(true)
+	assert.True(t, c.Curtailed())
+	assert.False(t, c.curtailed)
+
+	parent.SetCurtailed(false)
+	assert.False(t, c.Curtailed())
+}
@@ -0,0 +1,232 @@
+package household
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+)
+
+const (
+	SlotDuration = 15 * time.Minute
+
+	slotsPerDay  = int(24 * time.Hour / SlotDuration)
+	slotsPerWeek = 7 * slotsPerDay
+
+	maxSamples = 4 // number of samples averaged before older values start fading out
+)
+
+// Profile is the learned household load profile by weekday and time of day
+type Profile struct {
+	Power   []float64 `json:"power"`   // average power per slot in W
+	Samples []int     `json:"samples"` // number of samples per slot
+}
+
+// Forecaster learns the household load profile from power measurements
+type Forecaster struct {
+	mu      sync.RWMutex
+	clock   clock.Clock
+	profile Profile
+
+	slot     time.Time     // start of the current slot
+	updated  time.Time     // last measurement
+	energy   float64       // energy accumulated in current slot in Wh
+	duration time.Duration // duration covered in current slot
+}
+
+// New creates a household load forecaster
+func New(clock clock.Clock) *Forecaster {
+	return &Forecaster{
+		clock: clock,
+		profile: Profile{
+			Power:   make([]float64, slotsPerWeek),
+			Samples: make([]int, slotsPerWeek),
+		},
+	}
+}
+
+// index returns the profile index for the given time
+func index(ts time.Time) int {
+	ts = ts.Local()
+	return int(ts.Weekday())*slotsPerDay + (ts.Hour()*60+ts.Minute())/int(SlotDuration/time.Minute)
+}
+
+// Load restores a previously learned profile
+func (f *Forecaster) Load(p Profile) error {
+	if len(p.Power) != slotsPerWeek || len(p.Samples) != slotsPerWeek {
+		return errors.New("invalid profile")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.profile = p
+
+	return nil
+}
+
+// Profile returns a copy of the learned profile
+func (f *Forecaster) Profile() Profile {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return Profile{
+		Power:   append([]float64(nil), f.profile.Power...),
+		Samples: append([]int(nil), f.profile.Samples...),
+	}
+}
+
+// Add adds the current household power. Power is assumed constant since the previous measurement.
+// Returns true if a slot was completed and the profile has changed.
+func (f *Forecaster) Add(power float64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.clock.Now()
+	slot := now.Truncate(SlotDuration)
+
+	defer func() {
+		f.slot = slot
+		f.updated = now
+	}()
+
+	if f.updated.IsZero() {
+		return false
+	}
+
+	var changed bool
+	from := f.updated
+
+	if slot.After(f.slot) {
+		end := f.slot.Add(SlotDuration)
+		f.accumulate(power, from, end)
+		changed = f.commit()
+
+		// don't bridge gaps of more than a single slot
+		from = now
+		if end.Equal(slot) {
+			from = slot
+		}
+	}
+
+	f.accumulate(power, from, now)
+
+	return changed
+}
+
+// accumulate adds the energy between from and to to the current slot
+func (f *Forecaster) accumulate(power float64, from, to time.Time) {
+	if d := to.Sub(from); d > 0 {
+		f.energy += power * d.Hours()
+		f.duration += d
+	}
+}
+
+// commit adds the current slot's average power to the profile and resets the slot
+func (f *Forecaster) commit() bool {
+	defer func() {
+		f.energy = 0
+		f.duration = 0
+	}()
+
+	// ignore slots with insufficient data
+	if f.duration < SlotDuration/2 {
+		return false
+	}
+
+	avg := f.energy / f.duration.Hours()
+
+	i := index(f.slot)
+	n := float64(min(f.profile.Samples[i], maxSamples))
+
+	f.profile.Power[i] = (f.profile.Power[i]*n + avg) / (n + 1)
+	f.profile.Samples[i]++
+
+	return true
+}
+
+// Learned returns true if any profile slot has been learned
+func (f *Forecaster) Learned() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return slices.ContainsFunc(f.profile.Samples, func(n int) bool { return n > 0 })
+}
+
+// average returns the average power of the profile slots matching the filter
+func (f *Forecaster) average(match func(i int) bool) (float64, bool) {
+	var sum float64
+	var count int
+
+	for i, n := range f.profile.Samples {
+		if n > 0 && match(i) {
+			sum += f.profile.Power[i]
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0, false
+	}
+
+	return sum / float64(count), true
+}
+
+// value returns the expected power for the given profile slot.
+// If the slot has not been learned yet, the same time of day on other weekdays is used
+// and finally the average of all learned slots.
+func (f *Forecaster) value(i int) (float64, bool) {
+	if f.profile.Samples[i] > 0 {
+		return f.profile.Power[i], true
+	}
+
+	if v, ok := f.average(func(j int) bool { return j%slotsPerDay == i%slotsPerDay }); ok {
+		return v, true
+	}
+
+	return f.average(func(int) bool { return true })
+}
+
+// Forecast returns the expected household power between from and to in slot resolution
+func (f *Forecaster) Forecast(from, to time.Time) api.Rates {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var res api.Rates
+
+	for ts := from.Truncate(SlotDuration); ts.Before(to); ts = ts.Add(SlotDuration) {
+		if v, ok := f.value(index(ts)); ok {
+			res = append(res, api.Rate{
+				Start: ts,
+				End:   ts.Add(SlotDuration),
+				Value: v,
+			})
+		}
+	}
+
+	return res
+}
+
+// Energy returns the expected household energy between from and to in Wh
+func (f *Forecaster) Energy(from, to time.Time) float64 {
+	var res float64
+
+	for _, r := range f.Forecast(from, to) {
+		start := r.Start
+		if start.Before(from) {
+			start = from
+		}
+
+		end := r.End
+		if end.After(to) {
+			end = to
+		}
+
+		res += r.Value * end.Sub(start).Hours()
+	}
+
+	return res
+}
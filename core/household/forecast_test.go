@@ -0,0 +1,82 @@
+package household
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastLearn(t *testing.T) {
+	clock := clock.NewMock()
+	f := New(clock)
+
+	start := clock.Now().Truncate(SlotDuration)
+	clock.Set(start)
+
+	assert.False(t, f.Add(500))
+
+	for range 30 {
+		clock.Add(time.Minute)
+		f.Add(500)
+	}
+
+	// two slots completed
+	assert.Equal(t, 1, f.Profile().Samples[index(start)])
+	assert.Equal(t, 1, f.Profile().Samples[index(start.Add(SlotDuration))])
+
+	fc := f.Forecast(start, start.Add(2*SlotDuration))
+	require.Len(t, fc, 2)
+	assert.InDelta(t, 500.0, fc[0].Value, 1e-6)
+
+	// same time of day on other weekday
+	fc = f.Forecast(start.Add(24*time.Hour), start.Add(24*time.Hour+SlotDuration))
+	require.Len(t, fc, 1)
+	assert.InDelta(t, 500.0, fc[0].Value, 1e-6)
+
+	assert.InDelta(t, 250.0, f.Energy(start, start.Add(2*SlotDuration)), 1e-6)
+
+	// average of learned slots
+	assert.InDelta(t, 500.0, f.Energy(start.Add(time.Hour), start.Add(2*time.Hour)), 1e-6)
+}
+
+func TestForecastAverage(t *testing.T) {
+	clock := clock.NewMock()
+	f := New(clock)
+
+	start := clock.Now().Truncate(SlotDuration)
+
+	for week, power := range []float64{100, 200} {
+		clock.Set(start.AddDate(0, 0, 7*week))
+		f.Add(power)
+		clock.Add(SlotDuration)
+		f.Add(power)
+	}
+
+	assert.Equal(t, 2, f.Profile().Samples[index(start)])
+	assert.Equal(t, 150.0, f.Profile().Power[index(start)])
+}
+
+func TestForecastGap(t *testing.T) {
+	clock := clock.NewMock()
+	f := New(clock)
+
+	start := clock.Now().Truncate(SlotDuration)
+	clock.Set(start.Add(SlotDuration - time.Minute))
+	f.Add(100)
+
+	// incomplete slot is ignored
+	clock.Set(start.Add(3 * SlotDuration))
+	assert.False(t, f.Add(100))
+	assert.False(t, f.Learned())
+	assert.Nil(t, f.Forecast(start, start.Add(4*SlotDuration)))
+}
+
+func TestProfileLoad(t *testing.T) {
+	f := New(clock.NewMock())
+
+	assert.Error(t, f.Load(Profile{}))
+	assert.NoError(t, f.Load(New(clock.NewMock()).Profile()))
+}
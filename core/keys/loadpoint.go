@@ -54,6 +54,9 @@ const (
 	EffectiveMaxCurrent = "effectiveMaxCurrent" // effective max current
 	EffectiveLimitSoc   = "effectiveLimitSoc"   // effective limit soc
 
+	// circuit
+	AllocatedCurrent = "allocatedCurrent" // circuit capacity allocated to the loadpoint
+
 	// measurements
 	ChargePower       = "chargePower"       // charge power
 	ChargeCurrents    = "chargeCurrents"    // charge currents
@@ -67,10 +70,14 @@ const (
 	ChargeRemainingDuration = "chargeRemainingDuration" // charge remaining duration
 	ChargeRemainingEnergy   = "chargeRemainingEnergy"   // charge remaining energy
 
+	// thermal storage
+	ThermalEnergy = "thermalEnergy" // thermal energy stored above minimum temperature
+
 	// plan
 	PlanTime           = "planTime"           // charge plan finish time goal
 	PlanEnergy         = "planEnergy"         // charge plan energy goal
 	PlanSoc            = "planSoc"            // charge plan soc goal
+	PlanTemperature    = "planTemperature"    // charge plan temperature goal (heating devices)
 	PlanPrecondition   = "planPrecondition"   // charge plan precondition duration
 	PlanActive         = "planActive"         // charge plan has determined current slot to be an active slot
 	PlanProjectedStart = "planProjectedStart" // charge plan start time (earliest slot)
@@ -80,6 +87,12 @@ const (
 	// repeating plans
 	RepeatingPlans = "repeatingPlans" // key to access all repeating plans in db
 
+	// charge curve
+	ChargeCurve = "chargeCurve" // key to access learned charge curve in db
+
+	// vehicle data
+	Snapshot = "snapshot" // key to access last known vehicle data in db
+
 	// remote control
 	RemoteDisabled       = "remoteDisabled"       // remote disabled
 	RemoteDisabledSource = "remoteDisabledSource" // remote disabled source
@@ -92,6 +105,7 @@ const (
 	VehicleOdometer        = "vehicleOdometer"        // vehicle odometer
 	VehicleRange           = "vehicleRange"           // vehicle range
 	VehicleSoc             = "vehicleSoc"             // vehicle soc
+	VehicleSocUpdated      = "vehicleSocUpdated"      // vehicle soc timestamp
 	VehicleLimitSoc        = "vehicleLimitSoc"        // vehicle api soc limit
 	VehicleClimaterActive  = "vehicleClimaterActive"  // vehicle climater active
 	VehicleWelcomeActive   = "vehicleWelcomeActive"   // vehicle might need welcome charge
@@ -22,6 +22,8 @@ const (
 	Forecast              = "forecast"
 	SolarAccYield         = "solarAccYield"
 	SolarAccForecast      = "solarAccForecast"
+	HouseholdProfile      = "householdProfile"
+	CircuitStats          = "circuitStats"
 	TariffCo2             = "tariffCo2"
 	TariffCo2Home         = "tariffCo2Home"
 	TariffCo2Loadpoints   = "tariffCo2Loadpoints"
@@ -44,16 +46,26 @@ const (
 	BatteryDischargeControl = "batteryDischargeControl"
 	BatteryGridChargeLimit  = "batteryGridChargeLimit"
 	BatteryGridChargeActive = "batteryGridChargeActive"
+	BatteryOptimizer        = "batteryOptimizer"
 	BufferSoc               = "bufferSoc"
 	BufferStartSoc          = "bufferStartSoc"
 
 	// battery status
-	Battery       = "battery"
-	BatteryEnergy = "batteryEnergy"
-	BatteryMode   = "batteryMode"
-	BatteryPower  = "batteryPower"
-	BatterySoc    = "batterySoc"
+	Battery         = "battery"
+	BatteryEnergy   = "batteryEnergy"
+	BatteryMode     = "batteryMode"
+	BatteryPower    = "batteryPower"
+	BatterySchedule = "batterySchedule"
+	BatterySoc      = "batterySoc"
 
 	// external battery control
 	BatteryModeExternal = "batteryModeExternal"
+
+	// feed-in limitation
+	FeedInLimit         = "feedInLimit"
+	FeedInLimitExternal = "feedInLimitExternal"
+	PvCurtailment       = "pvCurtailment"
+
+	// negative price policy
+	NegativePrice = "negativePrice"
 )
@@ -69,6 +69,11 @@ var elapsed = time.Unix(0, 1)
 // Poll modes
 const pollInterval = 60 * time.Minute
 
+const (
+	chargeCurvePersistInterval = 15 * time.Minute // learned charge curve persistence interval while charging
+	snapshotPersistInterval    = time.Hour        // unchanged vehicle data persistence interval
+)
+
 // Task is the task type
 type Task = func()
 
@@ -97,9 +102,11 @@ type Loadpoint struct {
 	Enable, Disable loadpoint.ThresholdConfig
 
 	// from yaml
-	DefaultMode api.ChargeMode `mapstructure:"mode"`     // Default charge mode, used for disconnect
-	Title       string         `mapstructure:"title"`    // UI title
-	Priority    int            `mapstructure:"priority"` // Priority
+	DefaultMode         api.ChargeMode `mapstructure:"mode"`                // Default charge mode, used for disconnect
+	Title               string         `mapstructure:"title"`               // UI title
+	Priority            int            `mapstructure:"priority"`            // Priority
+	PhaseRotation       string         `mapstructure:"phaseRotation"`       // Grid phases connected to charger phases, e.g. L2L3L1
+	ClimatePrecondition time.Duration  `mapstructure:"climatePrecondition"` // Start vehicle climatisation before plan target time
 
 	// from yaml, deprecated
 	GuardDuration_ time.Duration `mapstructure:"guardduration"` // ignored, present for compatibility
@@ -117,6 +124,8 @@ type Loadpoint struct {
 	smartCostLimit   *float64 // always charge if cost is below this value
 	batteryBoost     int      // battery boost state
 
+	negativePrice negativePriceStruct // site negative price policy state
+
 	mode                api.ChargeMode
 	enabled             bool      // Charger enabled state
 	phases              int       // Charger enabled phases, guarded by mutex
@@ -126,6 +135,9 @@ type Loadpoint struct {
 	vehicleDetect       time.Time // Vehicle connected timestamp
 	chargerSwitched     time.Time // Charger enabled/disabled timestamp
 	phasesSwitched      time.Time // Phase switch timestamp
+	climateDeparture    time.Time // Plan target time of finished one-shot plan
+	climatePlanTime     time.Time // Plan target time vehicle climatisation has been started for
+	climateRetry        time.Time // Vehicle climatisation backoff after failure
 	vehicleDetectTicker *clock.Ticker
 	vehicleIdentifier   string
 
@@ -140,12 +152,18 @@ type Loadpoint struct {
 	defaultVehicle api.Vehicle // Default vehicle (disables detection)
 	coordinator    coordinator.API
 	socEstimator   *soc.Estimator
+	curvePersisted time.Time                       // charge curve last persisted
+	snapshot       vehicle.Snapshot                // last known vehicle data
+	snapshotSaved  vehicle.Snapshot                // last persisted vehicle data
+	thermalModel   func() (api.ThermalModel, bool) // cached thermal storage model
 
 	// charge planning
-	planner          *planner.Planner
+	planner          *planner.Participant
 	planTime         time.Time     // time goal
 	planPrecondition time.Duration // precondition duration
 	planEnergy       float64       // Plan charge energy in kWh (dumb vehicles)
+	planTemperature  float64       // Plan temperature in °C (heating devices with thermal storage)
+	programRunning   bool          // Appliance program running (deferrable loads)
 	planSlotEnd      time.Time     // current plan slot end time
 	planActive       bool          // charge plan exists and has a currently active slot
 
@@ -154,6 +172,8 @@ type Loadpoint struct {
 	remoteDemand   loadpoint.RemoteDemand // External status demand
 	chargePower    float64                // Charging power
 	chargeCurrents []float64              // Phase currents
+	phaseRotation  []int                  // Grid phase per charger phase, nil if unknown
+	allocation     *float64               // Circuit current allocated to the loadpoint, nil if unrestricted
 	connectedTime  time.Time              // Time when vehicle was connected
 	pvTimer        time.Time              // PV enabled/disable timer
 	phaseTimer     time.Time              // 1p3p switch timer
@@ -161,6 +181,8 @@ type Loadpoint struct {
 
 	// charge progress
 	vehicleSoc              float64       // Vehicle Soc
+	vehicleLimitSoc         int           // Vehicle onboard soc limit
+	vehicleLimitSocSet      int           // Vehicle onboard soc limit written by loadpoint
 	chargeDuration          time.Duration // Charge duration
 	energyMetrics           EnergyMetrics // Stats for charged energy by session
 	chargeRemainingDuration time.Duration // Remaining charge duration
@@ -168,8 +190,10 @@ type Loadpoint struct {
 	progress                *Progress     // Step-wise progress indicator
 
 	// session log
-	db      *session.DB
-	session *session.Session
+	db                  *session.DB
+	session             *session.Session
+	sessionBatteryShare float64               // current share of battery energy (0-1) for session breakdown
+	logbook             *session.LogbookEntry // vehicle plug-in logbook entry
 
 	settings settings.Settings
 
@@ -212,6 +236,14 @@ func NewLoadpointFromConfig(log *util.Logger, settings settings.Settings, other
 		lp.setPriority(lp.Priority)
 	}
 
+	if lp.PhaseRotation != "" {
+		rotation, err := parsePhaseRotation(lp.PhaseRotation)
+		if err != nil {
+			return lp, fmt.Errorf("phase rotation: %w", err)
+		}
+		lp.phaseRotation = rotation
+	}
+
 	if lp.CircuitRef != "" {
 		dev, err := config.Circuits().ByName(lp.CircuitRef)
 		if err != nil {
@@ -284,6 +316,8 @@ func NewLoadpoint(log *util.Logger, settings settings.Settings) *Loadpoint {
 		tasks:       util.NewQueue[Task](),                                           // task queue
 	}
 
+	lp.thermalModel = sync.OnceValues(lp.resolveThermalModel)
+
 	return lp
 }
 
@@ -349,6 +383,9 @@ func (lp *Loadpoint) restoreSettings() {
 	if err1 == nil && err2 == nil {
 		lp.setPlanEnergy(t, time.Duration(d)*time.Second, v)
 	}
+	if v, err := lp.settings.Float(keys.PlanTemperature); err1 == nil && err == nil && v > 0 {
+		lp.setPlanTemperature(t, time.Duration(d)*time.Second, v)
+	}
 }
 
 // requestUpdate requests site to update this loadpoint
@@ -496,6 +533,9 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 
 	// create charging session
 	lp.createSession()
+
+	// record vehicle plug-in
+	lp.createLogbookEntry()
 }
 
 // evVehicleDisconnectHandler sends external start event
@@ -526,6 +566,12 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	// set default mode on disconnect
 	lp.defaultMode()
 
+	// persist charge curve learned during session
+	lp.saveChargeCurve()
+
+	// record vehicle plug-out
+	lp.finishLogbookEntry()
+
 	// set default vehicle (may be nil)
 	lp.setActiveVehicle(lp.defaultVehicle)
 
@@ -640,6 +686,9 @@ func (lp *Loadpoint) Prepare(site site.API, uiChan chan<- util.Param, pushChan c
 		lp.publishChargerFeature(f)
 	}
 
+	// thermal storage
+	lp.chargerThermalModel()
+
 	// charger icon
 	if c, ok := lp.charger.(api.IconDescriber); ok {
 		lp.publish(keys.ChargerIcon, c.Icon())
@@ -662,6 +711,7 @@ func (lp *Loadpoint) Prepare(site site.API, uiChan chan<- util.Param, pushChan c
 	// restored settings
 	lp.publish(keys.PlanTime, lp.planTime)
 	lp.publish(keys.PlanEnergy, lp.planEnergy)
+	lp.publish(keys.PlanTemperature, lp.planTemperature)
 	lp.publish(keys.PlanPrecondition, lp.planPrecondition)
 	lp.publish(keys.LimitSoc, lp.limitSoc)
 	lp.publish(keys.LimitEnergy, lp.limitEnergy)
@@ -843,20 +893,31 @@ func (lp *Loadpoint) setLimit(current float64) error {
 
 	// apply circuit limits
 	if lp.circuit != nil {
-		var actualCurrent float64
-		if lp.chargeCurrents != nil {
-			actualCurrent = max(lp.chargeCurrents[0], lp.chargeCurrents[1], lp.chargeCurrents[2])
-		} else if lp.charging() {
-			actualCurrent = lp.offeredCurrent
+		activePhases := lp.ActivePhases()
+
+		actualCurrents := lp.chargeCurrents
+		if actualCurrents == nil {
+			var actualCurrent float64
+			if lp.charging() {
+				actualCurrent = lp.offeredCurrent
+			}
+			actualCurrents = chargerPhaseCurrents(actualCurrent, activePhases)
 		}
 
-		currentLimit := lp.circuit.ValidateCurrent(actualCurrent, current)
+		currentLimit := current
+		if current > 0 {
+			currentLimit = lp.circuit.ValidatePhaseCurrents(lp.gridPhaseCurrents(actualCurrents), lp.gridPhaseCurrents(chargerPhaseCurrents(current, activePhases)))
+		}
 
-		activePhases := lp.ActivePhases()
 		powerLimit := lp.circuit.ValidatePower(lp.chargePower, currentToPower(current, activePhases))
 		currentLimitViaPower := powerToCurrent(powerLimit, activePhases)
 
 		current = lp.roundedCurrent(min(currentLimit, currentLimitViaPower))
+
+		// apply circuit allocation
+		if allocation := lp.getAllocatedCurrent(); allocation != nil {
+			current = lp.roundedCurrent(min(current, *allocation))
+		}
 	}
 
 	// https://github.com/evcc-io/evcc/issues/16309
@@ -1358,6 +1419,12 @@ func (lp *Loadpoint) boostPower(batteryBoostPower float64) float64 {
 	return res
 }
 
+// surplusCurrent returns the charge current consuming the available surplus, limited to max current
+func (lp *Loadpoint) surplusCurrent(sitePower float64) float64 {
+	targetCurrent := lp.effectiveCurrent() + powerToCurrent(-sitePower, lp.ActivePhases())
+	return min(max(targetCurrent, 0), lp.effectiveMaxCurrent())
+}
+
 // pvMaxCurrent calculates the maximum target current for PV mode
 func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower, batteryBoostPower float64, batteryBuffered, batteryStart bool) float64 {
 	// read only once to simplify testing
@@ -1602,18 +1669,29 @@ func (lp *Loadpoint) updateChargeVoltages() {
 	}
 }
 
+// updateChargedEnergy adds the energy charged since the last update to the session
+func (lp *Loadpoint) updateChargedEnergy() error {
+	f, err := lp.chargeRater.ChargedEnergy()
+	if err != nil {
+		return err
+	}
+
+	// workaround for Go-E resetting during disconnect, see
+	// https://github.com/evcc-io/evcc/issues/5092
+	if f > lp.chargedAtStartup {
+		added, addedGreen := lp.energyMetrics.Update(f - lp.chargedAtStartup)
+		lp.addSessionEnergy(added)
+		if telemetry.Enabled() && added > 0 {
+			telemetry.UpdateEnergy(added, addedGreen)
+		}
+	}
+
+	return nil
+}
+
 // publish charged energy and duration
 func (lp *Loadpoint) publishChargeProgress() {
-	if f, err := lp.chargeRater.ChargedEnergy(); err == nil {
-		// workaround for Go-E resetting during disconnect, see
-		// https://github.com/evcc-io/evcc/issues/5092
-		if f > lp.chargedAtStartup {
-			added, addedGreen := lp.energyMetrics.Update(f - lp.chargedAtStartup)
-			if telemetry.Enabled() && added > 0 {
-				telemetry.UpdateEnergy(added, addedGreen)
-			}
-		}
-	} else {
+	if err := lp.updateChargedEnergy(); err != nil {
 		lp.log.ERROR.Printf("charge rater: %v", err)
 	}
 
@@ -1654,15 +1732,19 @@ func (lp *Loadpoint) publishSocAndRange() {
 			lp.vehicleSoc = soc
 			lp.publish(keys.VehicleSoc, lp.vehicleSoc)
 
+			var chargerLimit *int64
 			if vs, ok := lp.charger.(api.SocLimiter); ok {
 				if limit, err := vs.GetLimitSoc(); err == nil {
 					lp.log.DEBUG.Printf("charger soc limit: %d%%", limit)
 					// https://github.com/evcc-io/evcc/issues/13349
 					lp.publish(keys.VehicleLimitSoc, float64(limit))
+					chargerLimit = &limit
 				} else if !errors.Is(err, api.ErrNotAvailable) {
 					lp.log.ERROR.Printf("charger soc limit: %v", err)
 				}
 			}
+
+			lp.publishThermalEnergy(chargerLimit)
 		} else if !errors.Is(err, api.ErrNotAvailable) {
 			lp.log.ERROR.Printf("charger soc: %v", err)
 		}
@@ -1682,6 +1764,9 @@ func (lp *Loadpoint) publishSocAndRange() {
 				lp.log.ERROR.Printf("vehicle soc: %v", err)
 			}
 
+			// use last known soc while vehicle api is unavailable
+			lp.restoreVehicleSnapshot()
+
 			return
 		}
 
@@ -1689,6 +1774,20 @@ func (lp *Loadpoint) publishSocAndRange() {
 		lp.log.DEBUG.Printf("vehicle soc: %.0f%%", lp.vehicleSoc)
 		lp.publish(keys.VehicleSoc, lp.vehicleSoc)
 
+		// soc not recovered from previous value after api error
+		if socEstimator.Fetched() {
+			lp.snapshot.Soc = f
+			lp.snapshot.Updated = lp.socUpdated
+			lp.publish(keys.VehicleSocUpdated, lp.socUpdated)
+		}
+
+		// record plug-in soc
+		if lp.logbook != nil && lp.logbook.Soc == nil {
+			lp.updateLogbookEntry(func(entry *session.LogbookEntry) {
+				entry.Soc = &f
+			})
+		}
+
 		// vehicle target soc
 		// TODO take vehicle api limits into account
 		apiLimitSoc := 100
@@ -1697,6 +1796,8 @@ func (lp *Loadpoint) publishSocAndRange() {
 		if vs, ok := lp.GetVehicle().(api.SocLimiter); ok {
 			if limit, err := vs.GetLimitSoc(); err == nil {
 				apiLimitSoc = int(limit)
+				lp.setVehicleLimitSoc(apiLimitSoc)
+				lp.snapshot.LimitSoc = apiLimitSoc
 				lp.log.DEBUG.Printf("vehicle soc limit: %d%%", limit)
 				// https://github.com/evcc-io/evcc/issues/13349
 				lp.publish(keys.VehicleLimitSoc, float64(limit))
@@ -1705,12 +1806,24 @@ func (lp *Loadpoint) publishSocAndRange() {
 			}
 		}
 
+		// write loadpoint limit to vehicle
+		lp.syncVehicleLimitSoc()
+
 		// use minimum of vehicle and loadpoint
 		limitSoc := min(apiLimitSoc, lp.EffectiveLimitSoc())
 
 		var d time.Duration
 		if lp.charging() {
+			// only learn charge power not limited by pv or current limits
+			if lp.offeredCurrent >= lp.effectiveMaxCurrent() {
+				socEstimator.AddChargePower(lp.chargePower)
+			}
 			d = socEstimator.RemainingChargeDuration(limitSoc, lp.chargePower)
+
+			// persist learned charge curve to survive restarts
+			if lp.clock.Since(lp.curvePersisted) >= chargeCurvePersistInterval {
+				lp.saveChargeCurve()
+			}
 		}
 		lp.SetRemainingDuration(d)
 
@@ -1721,11 +1834,15 @@ func (lp *Loadpoint) publishSocAndRange() {
 			if rng, err := vs.Range(); err == nil {
 				lp.log.DEBUG.Printf("vehicle range: %dkm", rng)
 				lp.publish(keys.VehicleRange, rng)
+				lp.snapshot.Range = rng
 			} else if !loadpoint.AcceptableError(err) {
 				lp.log.ERROR.Printf("vehicle range: %v", err)
 			}
 		}
 
+		// persist vehicle data for use while the vehicle api is unavailable
+		lp.saveVehicleSnapshot()
+
 		// trigger message after variables are updated
 		lp.bus.Publish(evVehicleSoc, f)
 	}
@@ -1822,10 +1939,20 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 
 	if sr, ok := lp.charger.(api.StatusReasoner); ok && lp.GetStatus() == api.StatusB {
 		if r, err := sr.StatusReason(); err == nil {
+			// vehicle stopped at its onboard limit
+			if r == api.ReasonUnknown && lp.vehicleLimitSocReached() {
+				r = api.ReasonVehicleLimit
+			}
 			lp.publish(keys.ChargerStatusReason, r)
 		} else {
 			lp.log.ERROR.Printf("charger status reason: %v", err)
 		}
+	} else if lp.GetStatus() == api.StatusB {
+		reason := api.ReasonUnknown
+		if lp.vehicleLimitSocReached() {
+			reason = api.ReasonVehicleLimit
+		}
+		lp.publish(keys.ChargerStatusReason, reason)
 	}
 
 	// identify connected vehicle
@@ -1843,6 +1970,9 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 	// initial update of connected state matches charger status
 	lp.publishSocAndRange()
 
+	// precondition vehicle for departure
+	lp.updateClimatePrecondition()
+
 	// sync settings with charger
 	if err := lp.syncCharger(); err != nil {
 		lp.log.ERROR.Println(err)
@@ -1898,16 +2028,31 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 		err = lp.fastCharging()
 
 	case mode == api.ModeMinPV || mode == api.ModePV:
+		negativePrice := lp.getNegativePrice()
+
 		// cheap tariff
-		if smartCostActive {
-			rate, _ := rates.At(time.Now())
-			lp.log.DEBUG.Printf("smart cost active: %.2f", rate.Value)
+		if smartCostActive || negativePrice.gridActive() {
+			if rate, _ := rates.At(time.Now()); smartCostActive {
+				lp.log.DEBUG.Printf("smart cost active: %.2f", rate.Value)
+			} else {
+				lp.log.DEBUG.Printf("negative grid price active: %.2f", negativePrice.Grid)
+			}
 			err = lp.fastCharging()
 			lp.resetPhaseTimer()
 			lp.elapsePVTimer() // let PV mode disable immediately afterwards
 			break
 		}
 
+		// negative feed-in price: absorb pv export without drawing from grid
+		if negativePrice.feedInActive() {
+			if targetCurrent := lp.surplusCurrent(sitePower); targetCurrent >= lp.effectiveMinCurrent() {
+				lp.log.DEBUG.Printf("negative feed-in price active: %.2f", negativePrice.FeedIn)
+				err = lp.setLimit(targetCurrent)
+				lp.elapsePVTimer() // let PV mode disable immediately afterwards
+				break
+			}
+		}
+
 		targetCurrent := lp.pvMaxCurrent(mode, sitePower, batteryBoostPower, batteryBuffered, batteryStart)
 
 		if targetCurrent == 0 && lp.vehicleClimateActive() {
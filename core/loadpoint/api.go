@@ -117,14 +117,22 @@ type API interface {
 	GetPlanEnergy() (time.Time, time.Duration, float64)
 	// SetPlanEnergy sets the charge plan energy
 	SetPlanEnergy(time.Time, time.Duration, float64) error
+	// GetPlanTemperature returns the charge plan temperature of heating devices
+	GetPlanTemperature() (time.Time, time.Duration, float64)
+	// SetPlanTemperature sets the charge plan temperature of heating devices
+	SetPlanTemperature(time.Time, time.Duration, float64) error
 	// GetPlanGoal returns the plan goal, precondition duration and if the goal is soc based
 	GetPlanGoal() (float64, bool)
-	// GetPlanRequiredDuration returns required duration of plan to reach the goal from current state
-	GetPlanRequiredDuration(goal, maxPower float64) time.Duration
+	// GetPlanRequiredDuration returns required duration of plan to reach the goal at target time from current state
+	GetPlanRequiredDuration(goal, maxPower float64, targetTime time.Time) time.Duration
 	// GetPlanPreCondDuration returns the precondition duration
 	GetPlanPreCondDuration() time.Duration
 	// SocBasedPlanning determines if the planner is soc based
 	SocBasedPlanning() bool
+	// ThermalPlanning determines if the planner is temperature based
+	ThermalPlanning() bool
+	// GetPlanSolarDuration returns the part of the required duration expected to be covered by solar surplus
+	GetPlanSolarDuration(targetTime time.Time, requiredDuration time.Duration) time.Duration
 	// GetPlan creates a charging plan
 	GetPlan(targetTime time.Time, requiredDuration, precondition time.Duration) api.Rates
 
@@ -479,17 +479,47 @@ func (mr *MockAPIMockRecorder) GetPlanPreCondDuration() *gomock.Call {
 }
 
 // GetPlanRequiredDuration mocks base method.
-func (m *MockAPI) GetPlanRequiredDuration(goal, maxPower float64) time.Duration {
+func (m *MockAPI) GetPlanRequiredDuration(goal, maxPower float64, targetTime time.Time) time.Duration {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPlanRequiredDuration", goal, maxPower)
+	ret := m.ctrl.Call(m, "GetPlanRequiredDuration", goal, maxPower, targetTime)
 	ret0, _ := ret[0].(time.Duration)
 	return ret0
 }
 
 // GetPlanRequiredDuration indicates an expected call of GetPlanRequiredDuration.
-func (mr *MockAPIMockRecorder) GetPlanRequiredDuration(goal, maxPower any) *gomock.Call {
+func (mr *MockAPIMockRecorder) GetPlanRequiredDuration(goal, maxPower, targetTime any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanRequiredDuration", reflect.TypeOf((*MockAPI)(nil).GetPlanRequiredDuration), goal, maxPower)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanRequiredDuration", reflect.TypeOf((*MockAPI)(nil).GetPlanRequiredDuration), goal, maxPower, targetTime)
+}
+
+// GetPlanSolarDuration mocks base method.
+func (m *MockAPI) GetPlanSolarDuration(targetTime time.Time, requiredDuration time.Duration) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanSolarDuration", targetTime, requiredDuration)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPlanSolarDuration indicates an expected call of GetPlanSolarDuration.
+func (mr *MockAPIMockRecorder) GetPlanSolarDuration(targetTime, requiredDuration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanSolarDuration", reflect.TypeOf((*MockAPI)(nil).GetPlanSolarDuration), targetTime, requiredDuration)
+}
+
+// GetPlanTemperature mocks base method.
+func (m *MockAPI) GetPlanTemperature() (time.Time, time.Duration, float64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanTemperature")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(float64)
+	return ret0, ret1, ret2
+}
+
+// GetPlanTemperature indicates an expected call of GetPlanTemperature.
+func (mr *MockAPIMockRecorder) GetPlanTemperature() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanTemperature", reflect.TypeOf((*MockAPI)(nil).GetPlanTemperature))
 }
 
 // GetPriority mocks base method.
@@ -884,6 +914,20 @@ func (mr *MockAPIMockRecorder) SetPlanEnergy(arg0, arg1, arg2 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlanEnergy", reflect.TypeOf((*MockAPI)(nil).SetPlanEnergy), arg0, arg1, arg2)
 }
 
+// SetPlanTemperature mocks base method.
+func (m *MockAPI) SetPlanTemperature(arg0 time.Time, arg1 time.Duration, arg2 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPlanTemperature", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPlanTemperature indicates an expected call of SetPlanTemperature.
+func (mr *MockAPIMockRecorder) SetPlanTemperature(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlanTemperature", reflect.TypeOf((*MockAPI)(nil).SetPlanTemperature), arg0, arg1, arg2)
+}
+
 // SetPriority mocks base method.
 func (m *MockAPI) SetPriority(arg0 int) {
 	m.ctrl.T.Helper()
@@ -981,3 +1025,17 @@ func (mr *MockAPIMockRecorder) StartVehicleDetection() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVehicleDetection", reflect.TypeOf((*MockAPI)(nil).StartVehicleDetection))
 }
+
+// ThermalPlanning mocks base method.
+func (m *MockAPI) ThermalPlanning() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ThermalPlanning")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ThermalPlanning indicates an expected call of ThermalPlanning.
+func (mr *MockAPIMockRecorder) ThermalPlanning() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ThermalPlanning", reflect.TypeOf((*MockAPI)(nil).ThermalPlanning))
+}
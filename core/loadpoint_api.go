@@ -362,6 +362,11 @@ func (lp *Loadpoint) setPlanEnergy(finishAt time.Time, precondition time.Duratio
 		precondition = 0
 	}
 
+	lp.setPlanTime(finishAt, precondition)
+}
+
+// setPlanTime sets plan time and precondition shared by energy and temperature plans (no mutex)
+func (lp *Loadpoint) setPlanTime(finishAt time.Time, precondition time.Duration) {
 	lp.planTime = finishAt
 	lp.planPrecondition = precondition
 	lp.publish(keys.PlanTime, finishAt)
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// climateRetryInterval is the backoff after vehicle climatisation has failed
+const climateRetryInterval = 5 * time.Minute
+
+// updateClimatePrecondition starts vehicle climatisation before the plan's target time while the
+// vehicle is connected. Climatisation is started once per plan and left to the vehicle to stop,
+// as the driver may depart later than planned. Plans finished before their target time keep
+// their departure.
+func (lp *Loadpoint) updateClimatePrecondition() {
+	now := lp.clock.Now()
+
+	// reset after departure
+	if !lp.connected() || !now.Before(lp.climateDeparture) {
+		lp.climateDeparture = time.Time{}
+	}
+	if !lp.climatePlanTime.IsZero() && (!lp.connected() || !now.Before(lp.climatePlanTime)) {
+		lp.climatePlanTime = time.Time{}
+		lp.climateRetry = time.Time{}
+	}
+
+	if lp.ClimatePrecondition <= 0 || !lp.connected() {
+		return
+	}
+
+	cc, ok := lp.GetVehicle().(api.VehicleClimateController)
+	if !ok {
+		return
+	}
+
+	planTime := lp.EffectivePlanTime()
+	if planTime.IsZero() {
+		planTime = lp.climateDeparture
+	}
+
+	if planTime.IsZero() || planTime.Equal(lp.climatePlanTime) || now.Before(lp.climateRetry) {
+		return
+	}
+
+	if now.Before(planTime.Add(-lp.ClimatePrecondition)) || !now.Before(planTime) {
+		return
+	}
+
+	lp.log.INFO.Printf("vehicle climatisation: starting for departure at %v", planTime.Round(time.Second).Local())
+
+	if err := cc.Climatize(true); err != nil {
+		lp.log.ERROR.Printf("vehicle climatisation: %v", err)
+		lp.climateRetry = now.Add(climateRetryInterval)
+		return
+	}
+
+	lp.climatePlanTime = planTime
+	lp.climateRetry = time.Time{}
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClimatePrecondition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clock := clock.NewMock()
+
+	vehicle := &struct {
+		*api.MockVehicle
+		*api.MockVehicleClimateController
+	}{
+		api.NewMockVehicle(ctrl),
+		api.NewMockVehicleClimateController(ctrl),
+	}
+	vehicle.MockVehicle.EXPECT().Capacity().Return(0.0).AnyTimes()
+
+	lp := &Loadpoint{
+		log:                 util.NewLogger("foo"),
+		clock:               clock,
+		vehicle:             vehicle,
+		status:              api.StatusB,
+		ClimatePrecondition: 30 * time.Minute,
+		planTime:            clock.Now().Add(time.Hour),
+		planEnergy:          10,
+	}
+
+	// before precondition window
+	lp.updateClimatePrecondition()
+
+	// start once within window
+	clock.Add(45 * time.Minute)
+	vehicle.MockVehicleClimateController.EXPECT().Climatize(true).Return(nil)
+	lp.updateClimatePrecondition()
+	lp.updateClimatePrecondition()
+
+	// next plan
+	lp.planTime = clock.Now().Add(10 * time.Minute)
+	vehicle.MockVehicleClimateController.EXPECT().Climatize(true).Return(nil)
+	lp.updateClimatePrecondition()
+	assert.Equal(t, lp.planTime, lp.climatePlanTime)
+
+	// reset after departure
+	clock.Add(10 * time.Minute)
+	lp.updateClimatePrecondition()
+	assert.True(t, lp.climatePlanTime.IsZero())
+
+	// disconnected
+	lp.planTime = clock.Now().Add(20 * time.Minute)
+	vehicle.MockVehicleClimateController.EXPECT().Climatize(true).Return(nil)
+	lp.updateClimatePrecondition()
+	lp.status = api.StatusA
+	lp.updateClimatePrecondition()
+	assert.True(t, lp.climatePlanTime.IsZero())
+}
+
+func TestClimatePreconditionFinishedPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clock := clock.NewMock()
+
+	vehicle := &struct {
+		*api.MockVehicle
+		*api.MockVehicleClimateController
+	}{
+		api.NewMockVehicle(ctrl),
+		api.NewMockVehicleClimateController(ctrl),
+	}
+	vehicle.MockVehicle.EXPECT().Capacity().Return(0.0).AnyTimes()
+
+	departure := clock.Now().Add(time.Hour)
+
+	lp := &Loadpoint{
+		log:                 util.NewLogger("foo"),
+		clock:               clock,
+		settings:            settings.NewDatabaseSettingsAdapter("foo"),
+		vehicle:             vehicle,
+		status:              api.StatusB,
+		ClimatePrecondition: 30 * time.Minute,
+		planTime:            departure,
+		planEnergy:          10,
+	}
+
+	// plan finished before precondition window
+	lp.updateClimatePrecondition()
+	lp.finishPlan()
+	assert.True(t, lp.EffectivePlanTime().IsZero())
+	assert.Equal(t, departure, lp.climateDeparture)
+
+	// back off after failure
+	clock.Add(40 * time.Minute)
+	vehicle.MockVehicleClimateController.EXPECT().Climatize(true).Return(api.ErrTimeout)
+	lp.updateClimatePrecondition()
+	lp.updateClimatePrecondition()
+
+	clock.Add(climateRetryInterval)
+	vehicle.MockVehicleClimateController.EXPECT().Climatize(true).Return(nil)
+	lp.updateClimatePrecondition()
+	lp.updateClimatePrecondition()
+	assert.Equal(t, departure, lp.climatePlanTime)
+
+	// reset after departure
+	clock.Add(20 * time.Minute)
+	lp.updateClimatePrecondition()
+	assert.True(t, lp.climateDeparture.IsZero())
+	assert.True(t, lp.climatePlanTime.IsZero())
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// deferrableLoad returns the charger if it is a one-shot appliance
+func (lp *Loadpoint) deferrableLoad() (api.DeferrableLoad, bool) {
+	dl, ok := lp.charger.(api.DeferrableLoad)
+	return dl, ok
+}
+
+// deferrableLoadProgress returns true while the appliance program is running and
+// completed if the program has finished since the last update
+func (lp *Loadpoint) deferrableLoadProgress() (running, completed bool) {
+	dl, ok := lp.deferrableLoad()
+	if !ok {
+		return false, false
+	}
+
+	running = dl.ProgramRunning()
+	completed = lp.programRunning && !running
+	lp.programRunning = running
+
+	return running, completed
+}
+
+// deferrableLoadDuration returns the appliance program duration if configured
+func (lp *Loadpoint) deferrableLoadDuration() time.Duration {
+	if dl, ok := lp.deferrableLoad(); ok {
+		return dl.ProgramDuration()
+	}
+	return 0
+}
+
+// deferrableSolarDuration returns the required duration if there is a window until target time
+// where the expected solar surplus covers the entire program energy. Otherwise the program is
+// planned from grid, since the appliance cannot be paused when surplus drops.
+func (lp *Loadpoint) deferrableSolarDuration(usable timeseries, targetTime time.Time, requiredDuration time.Duration) time.Duration {
+	_, _, energy := lp.GetPlanEnergy()
+	if energy <= 0 {
+		return 0
+	}
+
+	now := lp.clock.Now()
+	latestStart := targetTime.Add(-requiredDuration)
+
+	starts := []time.Time{now}
+	for _, v := range usable {
+		starts = append(starts, v.Timestamp)
+	}
+
+	for _, start := range starts {
+		if start.Before(now) || start.After(latestStart) {
+			continue
+		}
+
+		if usable.energy(start, start.Add(requiredDuration)) >= energy*1e3 {
+			return requiredDuration
+		}
+	}
+
+	return 0
+}
@@ -41,7 +41,7 @@ type plan struct {
 
 func (lp *Loadpoint) nextActivePlan(maxPower float64, plans []plan) *plan {
 	for i, p := range plans {
-		requiredDuration := lp.getPlanRequiredDuration(float64(p.Soc), maxPower)
+		requiredDuration := lp.getPlanRequiredDuration(float64(p.Soc), maxPower, p.End)
 		plans[i].Start = p.End.Add(-requiredDuration)
 	}
 
@@ -112,6 +112,12 @@ func (lp *Loadpoint) EffectivePlanId() int {
 		_, _, _, id := lp.NextVehiclePlan()
 		return id
 	}
+	if lp.thermalPlanning() {
+		if lp.planTemperature > 0 {
+			return 1
+		}
+		return 0
+	}
 	if lp.planEnergy > 0 {
 		return 1
 	}
@@ -162,6 +168,13 @@ func (lp *Loadpoint) effectiveMinCurrent() float64 {
 	}
 }
 
+// EffectiveMaxCurrent returns the effective max current
+func (lp *Loadpoint) EffectiveMaxCurrent() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.effectiveMaxCurrent()
+}
+
 // effectiveMaxCurrent returns the effective max current
 func (lp *Loadpoint) effectiveMaxCurrent() float64 {
 	maxCurrent := lp.getMaxCurrent()
@@ -191,18 +204,25 @@ func (lp *Loadpoint) EffectiveLimitSoc() int {
 // effectiveLimitSoc returns the effective session limit soc
 // TODO take vehicle api limits into account
 func (lp *Loadpoint) effectiveLimitSoc() int {
+	if soc := lp.explicitLimitSoc(); soc > 0 {
+		return soc
+	}
+
+	// MUST return 100 here as UI looks at effectiveLimitSoc and not limitSoc (VehicleSoc.vue)
+	return 100
+}
+
+// explicitLimitSoc returns the limit soc set for the session or vehicle, zero if none
+func (lp *Loadpoint) explicitLimitSoc() int {
 	if lp.limitSoc > 0 {
 		return lp.limitSoc
 	}
 
 	if v := lp.GetVehicle(); v != nil {
-		if soc := vehicle.Settings(lp.log, v).GetLimitSoc(); soc > 0 {
-			return soc
-		}
+		return vehicle.Settings(lp.log, v).GetLimitSoc()
 	}
 
-	// MUST return 100 here as UI looks at effectiveLimitSoc and not limitSoc (VehicleSoc.vue)
-	return 100
+	return 0
 }
 
 // EffectiveStepPower returns the effective step power for the currently active phases
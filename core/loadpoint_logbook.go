@@ -0,0 +1,78 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/core/vehicle"
+)
+
+// createLogbookEntry creates the vehicle's plug-in logbook entry. The entry is not persisted
+// until the vehicle is known and is completed by odometer and soc once read from the vehicle.
+func (lp *Loadpoint) createLogbookEntry() {
+	// test guard
+	if lp.db == nil {
+		return
+	}
+
+	lp.logbook = &session.LogbookEntry{
+		Created:   lp.clock.Now(),
+		Loadpoint: lp.GetTitle(),
+		Event:     session.LogbookPlugIn,
+	}
+}
+
+// updateLogbookEntry updates the plug-in logbook entry and persists it for the active vehicle
+func (lp *Loadpoint) updateLogbookEntry(opts ...func(*session.LogbookEntry)) {
+	// test guard
+	if lp.db == nil || lp.logbook == nil {
+		return
+	}
+
+	v := lp.GetVehicle()
+	if v == nil {
+		return
+	}
+
+	lp.logbook.Vehicle = vehicle.Settings(lp.log, v).Name()
+
+	for _, opt := range opts {
+		opt(lp.logbook)
+	}
+
+	lp.db.Persist(lp.logbook)
+}
+
+// finishLogbookEntry persists the vehicle's plug-out logbook entry
+func (lp *Loadpoint) finishLogbookEntry() {
+	// test guard
+	if lp.db == nil {
+		return
+	}
+
+	lp.logbook = nil
+
+	v := lp.GetVehicle()
+	if v == nil {
+		return
+	}
+
+	entry := session.LogbookEntry{
+		Created:       lp.clock.Now(),
+		Vehicle:       vehicle.Settings(lp.log, v).Name(),
+		Loadpoint:     lp.GetTitle(),
+		Event:         session.LogbookPlugOut,
+		ChargedEnergy: lp.GetChargedEnergy() / 1e3,
+	}
+
+	if vs, ok := v.(api.VehicleOdometer); ok {
+		if odo, err := vs.Odometer(); err == nil {
+			entry.Odometer = &odo
+		}
+	}
+
+	if soc := lp.vehicleSoc; soc > 0 {
+		entry.Soc = &soc
+	}
+
+	lp.db.Persist(&entry)
+}
@@ -1,6 +1,10 @@
 package core
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
 )
@@ -162,3 +166,70 @@ func (lp *Loadpoint) hasPhaseSwitching() bool {
 	_, ok := lp.charger.(api.PhaseSwitcher)
 	return ok
 }
+
+// parsePhaseRotation parses the grid phases connected to the charger phases, e.g. L2L3L1.
+// A single grid phase denotes the phase connected to charger L1 with the remaining phases following in order.
+func parsePhaseRotation(s string) ([]int, error) {
+	s = strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+
+	var res []int
+	for i := 0; i+1 < len(s); i += 2 {
+		if s[i] != 'L' || s[i+1] < '1' || s[i+1] > '3' {
+			return nil, fmt.Errorf("invalid phase: %s", s[i:i+2])
+		}
+
+		phase := int(s[i+1] - '1')
+		if slices.Contains(res, phase) {
+			return nil, fmt.Errorf("duplicate phase: %s", s[i:i+2])
+		}
+
+		res = append(res, phase)
+	}
+
+	switch {
+	case len(s)%2 != 0 || len(res) != 1 && len(res) != 3:
+		return nil, fmt.Errorf("invalid phase rotation: %s", s)
+	case len(res) == 1:
+		res = append(res, (res[0]+1)%3, (res[0]+2)%3)
+	}
+
+	return res, nil
+}
+
+// chargerPhaseCurrents returns the current on the active charger phases
+func chargerPhaseCurrents(current float64, phases int) []float64 {
+	res := make([]float64, 3)
+	for i := range min(phases, 3) {
+		res[i] = current
+	}
+	return res
+}
+
+// gridPhaseCurrents maps charger phase currents to grid phases.
+// Without phase rotation, the max phase current is assumed on all grid phases.
+func (lp *Loadpoint) gridPhaseCurrents(currents []float64) [3]float64 {
+	if lp.phaseRotation == nil {
+		current := max(currents[0], currents[1], currents[2])
+		return [3]float64{current, current, current}
+	}
+
+	var res [3]float64
+	for i, phase := range lp.phaseRotation {
+		res[phase] = currents[i]
+	}
+
+	return res
+}
+
+// GetPhaseCurrents returns the charge currents per grid phase
+func (lp *Loadpoint) GetPhaseCurrents() [3]float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	currents := lp.chargeCurrents
+	if currents == nil {
+		currents = chargerPhaseCurrents(lp.offeredCurrent, lp.activePhases())
+	}
+
+	return lp.gridPhaseCurrents(currents)
+}
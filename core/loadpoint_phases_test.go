@@ -477,3 +477,37 @@ func TestScalePhasesIfAvailable(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestParsePhaseRotation(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		res []int
+	}{
+		{"L1L2L3", []int{0, 1, 2}},
+		{"l2l3l1", []int{1, 2, 0}},
+		{"L3 L2 L1", []int{2, 1, 0}},
+		{"L2", []int{1, 2, 0}},
+		{"L3", []int{2, 0, 1}},
+		{"L1L1L2", nil},
+		{"L1L2", nil},
+		{"L4", nil},
+		{"L1L2L", nil},
+	} {
+		res, err := parsePhaseRotation(tc.in)
+		if tc.res == nil {
+			require.Error(t, err, tc.in)
+		} else {
+			require.NoError(t, err, tc.in)
+		}
+		require.Equal(t, tc.res, res, tc.in)
+	}
+}
+
+func TestGridPhaseCurrents(t *testing.T) {
+	lp := &Loadpoint{}
+	require.Equal(t, [3]float64{16, 16, 16}, lp.gridPhaseCurrents(chargerPhaseCurrents(16, 1)))
+
+	lp.phaseRotation = []int{1, 2, 0}
+	require.Equal(t, [3]float64{0, 16, 0}, lp.gridPhaseCurrents(chargerPhaseCurrents(16, 1)))
+	require.Equal(t, [3]float64{3, 1, 2}, lp.gridPhaseCurrents([]float64{1, 2, 3}))
+}
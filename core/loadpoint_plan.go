@@ -8,6 +8,7 @@ import (
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/samber/lo"
 )
 
 const (
@@ -30,8 +31,15 @@ func (lp *Loadpoint) setPlanActive(active bool) {
 
 // finishPlan deletes the charging plan, either loadpoint or vehicle
 func (lp *Loadpoint) finishPlan() {
+	// keep departure for climatisation if plan finishes before target time
+	if ts := lp.EffectivePlanTime(); lp.clock.Now().Before(ts) {
+		lp.climateDeparture = ts
+	}
+
 	if lp.repeatingPlanning() {
 		return // noting to do
+	} else if lp.thermalPlanning() {
+		lp.setPlanTemperature(time.Time{}, 0, 0)
 	} else if !lp.socBasedPlanning() {
 		lp.setPlanEnergy(time.Time{}, 0, 0)
 	} else if v := lp.GetVehicle(); v != nil {
@@ -44,15 +52,15 @@ func (lp *Loadpoint) remainingPlanEnergy(planEnergy float64) float64 {
 	return max(0, planEnergy-lp.getChargedEnergy()/1e3)
 }
 
-// GetPlanRequiredDuration is the estimated total charging duration
-func (lp *Loadpoint) GetPlanRequiredDuration(goal, maxPower float64) time.Duration {
+// GetPlanRequiredDuration is the estimated total charging duration for reaching the goal at target time
+func (lp *Loadpoint) GetPlanRequiredDuration(goal, maxPower float64, targetTime time.Time) time.Duration {
 	lp.RLock()
 	defer lp.RUnlock()
-	return lp.getPlanRequiredDuration(goal, maxPower)
+	return lp.getPlanRequiredDuration(goal, maxPower, targetTime)
 }
 
-// getPlanRequiredDuration is the estimated total charging duration
-func (lp *Loadpoint) getPlanRequiredDuration(goal, maxPower float64) time.Duration {
+// getPlanRequiredDuration is the estimated total charging duration for reaching the goal at target time
+func (lp *Loadpoint) getPlanRequiredDuration(goal, maxPower float64, targetTime time.Time) time.Duration {
 	if lp.socBasedPlanning() {
 		if lp.socEstimator == nil {
 			return 0
@@ -60,11 +68,20 @@ func (lp *Loadpoint) getPlanRequiredDuration(goal, maxPower float64) time.Durati
 		return lp.socEstimator.RemainingChargeDuration(int(goal), maxPower)
 	}
 
+	if lp.thermalPlanning() {
+		energy := lp.thermalEnergy(goal, targetTime)
+		return time.Duration(energy * 1e3 / maxPower * float64(time.Hour))
+	}
+
+	if d := lp.deferrableLoadDuration(); d > 0 && goal > 0 {
+		return d
+	}
+
 	energy := lp.remainingPlanEnergy(goal)
 	return time.Duration(energy * 1e3 / maxPower * float64(time.Hour))
 }
 
-// GetPlanGoal returns the plan goal in %, true or kWh, false
+// GetPlanGoal returns the plan goal in %, true or kWh (°C for heating devices with thermal storage), false
 func (lp *Loadpoint) GetPlanGoal() (float64, bool) {
 	lp.RLock()
 	defer lp.RUnlock()
@@ -74,6 +91,10 @@ func (lp *Loadpoint) GetPlanGoal() (float64, bool) {
 		return float64(soc), true
 	}
 
+	if lp.thermalPlanning() {
+		return lp.planTemperature, false
+	}
+
 	_, _, limit := lp.getPlanEnergy()
 	return limit, false
 }
@@ -92,14 +113,73 @@ func (lp *Loadpoint) GetPlanPreCondDuration() time.Duration {
 	return precondition
 }
 
+// planGoal creates the joint planner goal for given time and duration
+func (lp *Loadpoint) planGoal(targetTime time.Time, requiredDuration, precondition time.Duration) planner.Goal {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	_, continuous := lp.deferrableLoad()
+
+	return planner.Goal{
+		Circuit:          lp.circuit,
+		TargetTime:       targetTime,
+		RequiredDuration: requiredDuration,
+		Precondition:     precondition,
+		Power:            lp.effectiveMaxPower(),
+		Current:          lp.effectiveMaxCurrent(),
+		Continuous:       continuous,
+	}
+}
+
+// GetPlanSolarDuration returns the part of the required duration that is expected to be covered by solar surplus until target time.
+// Solar surplus is only considered in pv and minpv modes and shared with other loadpoints planning with solar surplus.
+func (lp *Loadpoint) GetPlanSolarDuration(targetTime time.Time, requiredDuration time.Duration) time.Duration {
+	if mode := lp.GetMode(); mode != api.ModePV && mode != api.ModeMinPV {
+		return 0
+	}
+
+	if lp.site == nil || !targetTime.After(lp.clock.Now()) {
+		return 0
+	}
+
+	surplus := lp.site.GetSurplusForecast(lp)
+	minPower := lp.EffectiveMinPower()
+	maxPower := lp.EffectiveMaxPower()
+
+	if len(surplus) == 0 || maxPower <= 0 {
+		return 0
+	}
+
+	// surplus below min power will not start charging, surplus above max power cannot be used
+	usable := timestampSeries(lo.Map(surplus, func(r api.Rate, _ int) api.Rate {
+		if r.Value < minPower {
+			r.Value = 0
+		}
+		r.Value = min(r.Value, maxPower)
+		return r
+	}))
+
+	// appliance programs cannot be split between solar and grid
+	if _, ok := lp.deferrableLoad(); ok {
+		return lp.deferrableSolarDuration(usable, targetTime, requiredDuration)
+	}
+
+	energy := usable.energy(lp.clock.Now(), targetTime)
+
+	return min(requiredDuration, time.Duration(energy/maxPower*float64(time.Hour)))
+}
+
 // GetPlan creates a charging plan for given time and duration
-// The plan is sorted by time
+// The plan is sorted by time and takes other loadpoints' active plans into account.
+// In pv and minpv modes, only the duration not covered by solar surplus is planned.
 func (lp *Loadpoint) GetPlan(targetTime time.Time, requiredDuration, precondition time.Duration) api.Rates {
 	if lp.planner == nil || targetTime.IsZero() {
 		return nil
 	}
 
-	return lp.planner.Plan(requiredDuration, precondition, targetTime)
+	requiredDuration -= lp.GetPlanSolarDuration(targetTime, requiredDuration)
+
+	return lp.planner.Plan(lp.planGoal(targetTime, requiredDuration, precondition))
 }
 
 // plannerActive checks if the charging plan has a currently active slot
@@ -110,8 +190,16 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 
 	var planStart, planEnd time.Time
 	var planOverrun time.Duration
+	var plan api.Rates
 
 	defer func() {
+		lp.updateChargePlan(plan)
+
+		// release reserved circuit capacity for other loadpoints
+		if planStart.IsZero() {
+			lp.planner.ClearGoal()
+		}
+
 		lp.publish(keys.PlanProjectedStart, planStart)
 		lp.publish(keys.PlanProjectedEnd, planEnd)
 		lp.publish(keys.PlanOverrun, planOverrun)
@@ -122,6 +210,16 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 		return false
 	}
 
+	// never interrupt a running appliance program, finish plan once program has completed
+	if running, completed := lp.deferrableLoadProgress(); running && lp.planActive {
+		lp.log.DEBUG.Println("plan: appliance program running")
+		return true
+	} else if completed && !lp.EffectivePlanTime().IsZero() {
+		lp.log.DEBUG.Println("plan: appliance program completed")
+		lp.finishPlan()
+		return false
+	}
+
 	planTime := lp.EffectivePlanTime()
 	if planTime.IsZero() {
 		return false
@@ -136,7 +234,7 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 
 	goal, isSocBased := lp.GetPlanGoal()
 	maxPower := lp.EffectiveMaxPower()
-	requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
+	requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower, planTime)
 	if requiredDuration <= 0 {
 		// continue a 100% plan as long as the vehicle is charging
 		if lp.planActive && isSocBased && goal == 100 && lp.charging() {
@@ -147,7 +245,16 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 		return false
 	}
 
-	plan := lp.GetPlan(planTime, requiredDuration, lp.GetPlanPreCondDuration())
+	// remaining duration is charged from grid, solar surplus is used by pv mode
+	solarDuration := lp.GetPlanSolarDuration(planTime, requiredDuration)
+	if solarDuration > 0 {
+		lp.log.DEBUG.Printf("plan: expecting %v of %v from solar surplus", solarDuration.Round(time.Second), requiredDuration.Round(time.Second))
+	}
+
+	planGoal := lp.planGoal(planTime, requiredDuration-solarDuration, lp.GetPlanPreCondDuration())
+	lp.planner.SetGoal(planGoal)
+
+	plan = lp.planner.Plan(planGoal)
 	if plan == nil {
 		return false
 	}
@@ -204,3 +311,20 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 
 	return active
 }
+
+// updateChargePlan hands the current plan to chargers that are able to follow it on their own
+func (lp *Loadpoint) updateChargePlan(plan api.Rates) {
+	cp, ok := lp.charger.(api.ChargePlanner)
+	if !ok {
+		return
+	}
+
+	// the charger must not charge on its own if the loadpoint is off
+	if lp.GetMode() == api.ModeOff {
+		plan = nil
+	}
+
+	if err := cp.SetChargePlan(plan, lp.EffectiveMaxCurrent()); err != nil {
+		lp.log.ERROR.Printf("charge plan: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type surplusSite struct {
+	site.API
+	surplus api.Rates
+}
+
+func (s *surplusSite) GetSurplusForecast(loadpoint.API) api.Rates {
+	return s.surplus
+}
+
+func TestPlanSolarDuration(t *testing.T) {
+	Voltage = 230 // V
+	clock := clock.NewMock()
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.clock = clock
+	lp.phases = 3
+
+	surplus := func(power float64) api.Rates {
+		var res api.Rates
+		for i := range 4 {
+			res = append(res, api.Rate{
+				Start: clock.Now().Add(time.Duration(i) * time.Hour),
+				End:   clock.Now().Add(time.Duration(i+1) * time.Hour),
+				Value: power,
+			})
+		}
+		return res
+	}
+
+	target := clock.Now().Add(2 * time.Hour)
+
+	for _, tc := range []struct {
+		mode     api.ChargeMode
+		surplus  float64
+		required time.Duration
+		expected time.Duration
+	}{
+		{api.ModeNow, 1e5, time.Hour, 0},
+		{api.ModePV, 1e5, time.Hour, time.Hour},         // fully covered
+		{api.ModePV, 1e5, 3 * time.Hour, 2 * time.Hour}, // limited by max power
+		{api.ModeMinPV, lp.EffectiveMaxPower() / 2, 3 * time.Hour, time.Hour},
+		{api.ModePV, lp.EffectiveMinPower() / 2, time.Hour, 0}, // below min power
+	} {
+		t.Logf("%+v", tc)
+
+		lp.mode = tc.mode
+		lp.site = &surplusSite{surplus: surplus(tc.surplus)}
+
+		assert.Equal(t, tc.expected, lp.GetPlanSolarDuration(target, tc.required))
+	}
+}
+
+func TestUpdateChargePlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	charger := struct {
+		*api.MockCharger
+		*api.MockChargePlanner
+	}{
+		api.NewMockCharger(ctrl),
+		api.NewMockChargePlanner(ctrl),
+	}
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.charger = charger
+	lp.maxCurrent = 16
+
+	plan := api.Rates{{Start: time.Now(), End: time.Now().Add(time.Hour)}}
+
+	lp.mode = api.ModeNow
+	charger.MockChargePlanner.EXPECT().SetChargePlan(plan, 16.0)
+	lp.updateChargePlan(plan)
+
+	// no autonomous charging when off
+	lp.mode = api.ModeOff
+	charger.MockChargePlanner.EXPECT().SetChargePlan(nil, 16.0)
+	lp.updateChargePlan(plan)
+}
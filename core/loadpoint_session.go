@@ -3,6 +3,7 @@ package core
 import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/samber/lo"
 )
 
@@ -42,8 +43,35 @@ func (lp *Loadpoint) createSession() {
 	if c, ok := lp.charger.(api.Identifier); ok {
 		if id, err := c.Identify(); err == nil {
 			lp.session.Identifier = id
+			lp.session.UserID = lp.db.UserID(id)
 		}
 	}
+
+	lp.session.TempStart = lp.chargerTemperature()
+}
+
+// chargerTemperature returns the temperature of heating devices
+func (lp *Loadpoint) chargerTemperature() *float64 {
+	if !lp.chargerHasFeature(api.Heating) {
+		return nil
+	}
+
+	temp, err := lp.chargerSoc()
+	if err != nil {
+		return nil
+	}
+
+	return &temp
+}
+
+// sessionThermalEnergy returns the thermal energy in kWh added to the storage between start and stop temperature
+func (lp *Loadpoint) sessionThermalEnergy(start, stop *float64) *float64 {
+	model, ok := lp.chargerThermalModel()
+	if !ok || start == nil || stop == nil {
+		return nil
+	}
+
+	return lo.ToPtr(model.Capacity() * (*stop - *start))
 }
 
 // stopSession ends a charging session segment and persists the session.
@@ -60,6 +88,13 @@ func (lp *Loadpoint) stopSession() {
 		return
 	}
 
+	// record energy charged since the last update
+	if lp.chargeRater != nil {
+		if err := lp.updateChargedEnergy(); err != nil {
+			lp.log.ERROR.Printf("charge rater: %v", err)
+		}
+	}
+
 	s.Finished = lp.clock.Now()
 	if meterStop := lp.chargeMeterTotal(); meterStop > 0 {
 		s.MeterStop = &meterStop
@@ -75,10 +110,43 @@ func (lp *Loadpoint) stopSession() {
 	s.Co2PerKWh = lp.energyMetrics.Co2PerKWh()
 	s.ChargedEnergy = lp.energyMetrics.TotalWh() / 1e3
 	s.ChargeDuration = lo.ToPtr(lp.chargeDuration.Abs())
+	s.TempStop = lp.chargerTemperature()
+	s.ThermalEnergy = lp.sessionThermalEnergy(s.TempStart, s.TempStop)
 
 	lp.db.Persist(s)
 }
 
+// addSessionEnergy adds charged energy in kWh to the session's slot breakdown
+func (lp *Loadpoint) addSessionEnergy(energy float64) {
+	// test guard
+	if lp.db == nil || lp.session == nil || energy <= 0 {
+		return
+	}
+
+	var gridPrice *float64
+	if lp.site != nil {
+		if v, err := tariff.Now(lp.site.GetTariff(api.TariffUsageGrid)); err == nil {
+			gridPrice = &v
+		}
+	}
+
+	lp.RLock()
+	batteryShare := lp.sessionBatteryShare
+	lp.RUnlock()
+
+	em := lp.energyMetrics
+	solarShare := max(0, em.currentGreenShare-batteryShare)
+
+	lp.session.AddEnergy(lp.clock.Now(), energy, solarShare, batteryShare, em.currentPrice, gridPrice)
+}
+
+// setSessionBatteryShare sets the current share of battery energy included in the green share
+func (lp *Loadpoint) setSessionBatteryShare(batteryShare float64) {
+	lp.Lock()
+	defer lp.Unlock()
+	lp.sessionBatteryShare = batteryShare
+}
+
 type sessionOption func(*session.Session)
 
 // updateSession updates any parameter of a charging session and persists the session.
@@ -181,3 +181,81 @@ func createMockSessions(db *session.DB, clock *clock.Mock) []*session.Session {
 	}
 	return sessions
 }
+
+func TestSessionUser(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db, err := session.NewStore("foo", serverdb.Instance)
+	require.NoError(t, err)
+
+	user := session.User{Name: "alice", Tags: []session.UserTag{{Tag: "ab12 "}}}
+	require.NoError(t, serverdb.Instance.Create(&user).Error)
+
+	ctrl := gomock.NewController(t)
+
+	charger := api.NewMockCharger(ctrl)
+	identifier := api.NewMockIdentifier(ctrl)
+	identifier.EXPECT().Identify().Return("ab12", nil)
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clock.NewMock(),
+		db:    db,
+		charger: struct {
+			api.Charger
+			api.Identifier
+		}{
+			Charger:    charger,
+			Identifier: identifier,
+		},
+	}
+
+	lp.createSession()
+	assert.Equal(t, "ab12", lp.session.Identifier)
+	assert.Equal(t, &user.ID, lp.session.UserID)
+
+	// tag is unique
+	assert.Error(t, serverdb.Instance.Create(&session.User{Name: "bob", Tags: []session.UserTag{{Tag: "AB12"}}}).Error)
+}
+
+func TestSessionFinalEnergy(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db, err := session.NewStore("foo", serverdb.Instance)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	rater := api.NewMockChargeRater(ctrl)
+
+	clock := clock.NewMock()
+	lp := &Loadpoint{
+		log:                 util.NewLogger("foo"),
+		clock:               clock,
+		db:                  db,
+		chargeRater:         rater,
+		sessionBatteryShare: 0.5,
+	}
+
+	lp.energyMetrics.SetEnvironment(0.75, nil, nil)
+
+	lp.session = db.New(0)
+	lp.session.Created = clock.Now()
+
+	rater.EXPECT().ChargedEnergy().Return(1.0, nil)
+	require.NoError(t, lp.updateChargedEnergy())
+
+	// energy charged since last update is recorded when stopping
+	clock.Add(time.Hour)
+	rater.EXPECT().ChargedEnergy().Return(2.0, nil)
+	lp.stopSession()
+
+	require.Len(t, lp.session.Slots, 2)
+	assert.Equal(t, 1.0, lp.session.Slots[1].ChargedEnergy)
+	assert.Equal(t, 0.25, lp.session.Slots[1].SolarEnergy)
+	assert.Equal(t, 0.5, lp.session.Slots[1].BatteryEnergy)
+	assert.Equal(t, 0.25, lp.session.Slots[1].GridEnergy)
+}
@@ -28,3 +28,17 @@ func (lp *Loadpoint) smartCostNextStart(rates api.Rates) time.Time {
 
 	return time.Time{}
 }
+
+// getNegativePrice returns the site's negative price policy state
+func (lp *Loadpoint) getNegativePrice() negativePriceStruct {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.negativePrice
+}
+
+// setNegativePrice sets the site's negative price policy state
+func (lp *Loadpoint) setNegativePrice(negativePrice negativePriceStruct) {
+	lp.Lock()
+	defer lp.Unlock()
+	lp.negativePrice = negativePrice
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// setVehicleLimitSoc updates the vehicle's onboard soc limit. A changed limit allows syncing again.
+func (lp *Loadpoint) setVehicleLimitSoc(limit int) {
+	if limit != lp.vehicleLimitSoc {
+		lp.vehicleLimitSocSet = 0
+	}
+	lp.vehicleLimitSoc = limit
+}
+
+// syncVehicleLimitSoc writes the loadpoint's explicit limit or active plan soc to the vehicle's onboard soc limit
+// to prevent the vehicle from stopping below the loadpoint's target
+func (lp *Loadpoint) syncVehicleLimitSoc() {
+	vs, ok := lp.GetVehicle().(api.SocLimitSetter)
+	if !ok {
+		return
+	}
+
+	target := max(lp.explicitLimitSoc(), lp.EffectivePlanSoc())
+	if target == 0 || target == lp.vehicleLimitSoc || target == lp.vehicleLimitSocSet {
+		return
+	}
+
+	lp.log.DEBUG.Printf("set vehicle soc limit: %d%%", target)
+
+	if err := vs.SetLimitSoc(int64(target)); err != nil {
+		if !loadpoint.AcceptableError(err) {
+			lp.log.ERROR.Printf("set vehicle soc limit: %v", err)
+		}
+		return
+	}
+
+	lp.vehicleLimitSocSet = target
+}
+
+// vehicleLimitSocReached returns true if the vehicle's onboard soc limit is below the loadpoint's limit and has been reached
+func (lp *Loadpoint) vehicleLimitSocReached() bool {
+	limit := lp.vehicleLimitSoc
+	return limit > 0 && limit < lp.EffectiveLimitSoc() && lp.vehicleSoc >= float64(limit)-1
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSyncVehicleLimitSoc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := &struct {
+		*api.MockVehicle
+		*api.MockSocLimitSetter
+	}{
+		api.NewMockVehicle(ctrl),
+		api.NewMockSocLimitSetter(ctrl),
+	}
+	vehicle.MockVehicle.EXPECT().OnIdentified().Return(api.ActionConfig{}).AnyTimes()
+	vehicle.MockVehicle.EXPECT().Phases().Return(0).AnyTimes()
+
+	lp := &Loadpoint{
+		log:             util.NewLogger("foo"),
+		vehicle:         vehicle,
+		vehicleLimitSoc: 80,
+	}
+
+	// no explicit limit
+	lp.syncVehicleLimitSoc()
+
+	// write loadpoint limit once
+	lp.limitSoc = 90
+	vehicle.MockSocLimitSetter.EXPECT().SetLimitSoc(int64(90)).Return(nil)
+	lp.syncVehicleLimitSoc()
+	lp.syncVehicleLimitSoc()
+
+	// vehicle limit changed externally
+	lp.setVehicleLimitSoc(70)
+	vehicle.MockSocLimitSetter.EXPECT().SetLimitSoc(int64(90)).Return(nil)
+	lp.syncVehicleLimitSoc()
+
+	// vehicle limit matches
+	lp.setVehicleLimitSoc(90)
+	lp.syncVehicleLimitSoc()
+}
+
+func TestVehicleLimitSocReached(t *testing.T) {
+	lp := &Loadpoint{
+		log:      util.NewLogger("foo"),
+		limitSoc: 90,
+	}
+
+	for _, tc := range []struct {
+		vehicleLimit int
+		soc          float64
+		reached      bool
+	}{
+		{0, 80, false},
+		{80, 50, false},
+		{80, 79.5, true},
+		{90, 90, false},
+	} {
+		t.Logf("%+v", tc)
+
+		lp.vehicleLimitSoc = tc.vehicleLimit
+		lp.vehicleSoc = tc.soc
+
+		assert.Equal(t, tc.reached, lp.vehicleLimitSocReached())
+	}
+
+	assert.Equal(t, "vehiclelimit", api.ReasonVehicleLimit.String())
+}
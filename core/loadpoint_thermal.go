@@ -0,0 +1,143 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// resolveThermalModel returns the thermal storage model of heating devices if available and valid
+func (lp *Loadpoint) resolveThermalModel() (api.ThermalModel, bool) {
+	c, ok := lp.charger.(api.ThermalStorage)
+	if !ok {
+		return api.ThermalModel{}, false
+	}
+
+	model, err := c.ThermalModel()
+	if err != nil {
+		if !errors.Is(err, api.ErrNotAvailable) {
+			lp.log.ERROR.Printf("thermal model: %v", err)
+		}
+		return api.ThermalModel{}, false
+	}
+
+	if err := model.Validate(); err != nil {
+		lp.log.WARN.Printf("thermal model: %v", err)
+		return api.ThermalModel{}, false
+	}
+
+	return model, true
+}
+
+// chargerThermalModel returns the cached thermal storage model
+func (lp *Loadpoint) chargerThermalModel() (api.ThermalModel, bool) {
+	if lp.thermalModel == nil {
+		return lp.resolveThermalModel()
+	}
+	return lp.thermalModel()
+}
+
+// thermalPlanning returns true if the charger provides a thermal storage model and temperature
+func (lp *Loadpoint) thermalPlanning() bool {
+	if _, ok := lp.chargerThermalModel(); !ok {
+		return false
+	}
+
+	_, ok := lp.charger.(api.Battery)
+	return ok && !lp.socBasedPlanning()
+}
+
+// ThermalPlanning returns true if temperature based planning is enabled
+func (lp *Loadpoint) ThermalPlanning() bool {
+	return lp.thermalPlanning()
+}
+
+// thermalEnergy returns the electrical energy in kWh required for reaching the goal temperature at target time
+func (lp *Loadpoint) thermalEnergy(goal float64, targetTime time.Time) float64 {
+	model, ok := lp.chargerThermalModel()
+	if !ok {
+		return 0
+	}
+
+	temp := lp.vehicleSoc
+	if !targetTime.IsZero() {
+		temp = model.Temperature(temp, lp.clock.Until(targetTime))
+	}
+
+	return model.Energy(temp, goal)
+}
+
+// publishThermalEnergy publishes stored thermal energy and remaining energy to the temperature limit
+func (lp *Loadpoint) publishThermalEnergy(limit *int64) {
+	model, ok := lp.chargerThermalModel()
+	if !ok {
+		return
+	}
+
+	lp.publish(keys.ThermalEnergy, model.StoredEnergy(lp.vehicleSoc))
+
+	if limit != nil {
+		lp.SetRemainingEnergy(1e3 * model.Energy(lp.vehicleSoc, float64(*limit)))
+	}
+}
+
+// GetPlanTemperature returns plan target temperature
+func (lp *Loadpoint) GetPlanTemperature() (time.Time, time.Duration, float64) {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.planTime, lp.planPrecondition, lp.planTemperature
+}
+
+// setPlanTemperature sets plan target temperature (no mutex)
+func (lp *Loadpoint) setPlanTemperature(finishAt time.Time, precondition time.Duration, temp float64) {
+	lp.planTemperature = temp
+	lp.publish(keys.PlanTemperature, temp)
+	lp.settings.SetFloat(keys.PlanTemperature, temp)
+
+	// remove plan
+	if temp == 0 {
+		finishAt = time.Time{}
+		precondition = 0
+	} else if lp.planEnergy != 0 {
+		// temperature and energy plans are exclusive
+		lp.planEnergy = 0
+		lp.publish(keys.PlanEnergy, 0)
+		lp.settings.SetFloat(keys.PlanEnergy, 0)
+	}
+
+	lp.setPlanTime(finishAt, precondition)
+}
+
+// SetPlanTemperature sets plan target temperature
+func (lp *Loadpoint) SetPlanTemperature(finishAt time.Time, precondition time.Duration, temp float64) error {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if temp != 0 {
+		model, ok := lp.chargerThermalModel()
+		if !ok {
+			return errors.New("temperature planning requires a heating device with thermal storage model")
+		}
+
+		if temp < model.MinTemp || temp > model.MaxTemp {
+			return fmt.Errorf("temperature must be between %.0f°C and %.0f°C", model.MinTemp, model.MaxTemp)
+		}
+	}
+
+	if !finishAt.IsZero() && finishAt.Before(lp.clock.Now()) {
+		return errors.New("timestamp is in the past")
+	}
+
+	lp.log.DEBUG.Printf("set plan temperature: %.1f°C @ %v", temp, finishAt.Round(time.Second).Local())
+
+	// apply immediately
+	if lp.planTemperature != temp || lp.planPrecondition != precondition || !lp.planTime.Equal(finishAt) {
+		lp.setPlanTemperature(finishAt, precondition, temp)
+		lp.requestUpdate()
+	}
+
+	return nil
+}
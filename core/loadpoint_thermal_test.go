@@ -0,0 +1,122 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type thermalStorage api.ThermalModel
+
+func (t thermalStorage) ThermalModel() (api.ThermalModel, error) {
+	return api.ThermalModel(t), nil
+}
+
+func TestThermalPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clock := clock.NewMock()
+
+	charger := struct {
+		*api.MockCharger
+		*api.MockBattery
+		thermalStorage
+	}{
+		api.NewMockCharger(ctrl),
+		api.NewMockBattery(ctrl),
+		thermalStorage{Volume: 300, MinTemp: 40, MaxTemp: 60, HeatLoss: 0.5, Cop: 3},
+	}
+
+	lp := NewLoadpoint(util.NewLogger("foo"), settings.NewDatabaseSettingsAdapter("foo"))
+	lp.clock = clock
+	lp.charger = charger
+	lp.vehicleSoc = 50 // °C
+
+	require.True(t, lp.ThermalPlanning())
+
+	// outside storage temperatures
+	assert.Error(t, lp.SetPlanTemperature(clock.Now().Add(4*time.Hour), 0, 70))
+
+	require.NoError(t, lp.SetPlanTemperature(clock.Now().Add(4*time.Hour), 0, 60))
+	assert.Equal(t, 1, lp.EffectivePlanId())
+	assert.Equal(t, clock.Now().Add(4*time.Hour), lp.EffectivePlanTime())
+
+	goal, socBased := lp.GetPlanGoal()
+	assert.Equal(t, 60.0, goal)
+	assert.False(t, socBased)
+
+	// 2K standby loss until target time, 12K at cop 3 = 1.395kWh
+	assert.Equal(t, time.Duration(1.395*float64(time.Hour)/3).Round(time.Minute), lp.GetPlanRequiredDuration(goal, 3e3, clock.Now().Add(4*time.Hour)).Round(time.Minute))
+
+	// preview for later target time includes additional standby loss
+	assert.Greater(t, lp.GetPlanRequiredDuration(goal, 3e3, clock.Now().Add(8*time.Hour)), lp.GetPlanRequiredDuration(goal, 3e3, clock.Now().Add(4*time.Hour)))
+
+	// goal reached
+	lp.vehicleSoc = 65
+	assert.Zero(t, lp.GetPlanRequiredDuration(goal, 3e3, clock.Now().Add(4*time.Hour)))
+
+	lp.finishPlan()
+	assert.Zero(t, lp.EffectivePlanId())
+	assert.True(t, lp.EffectivePlanTime().IsZero())
+}
+
+func TestSessionThermalEnergy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := &Loadpoint{
+		log: util.NewLogger("foo"),
+		charger: struct {
+			*api.MockCharger
+			thermalStorage
+		}{
+			api.NewMockCharger(ctrl),
+			thermalStorage{Volume: 300, MinTemp: 40, MaxTemp: 60, HeatLoss: 0.5, Cop: 3},
+		},
+	}
+
+	assert.InDelta(t, 3.49, *lp.sessionThermalEnergy(lo.ToPtr(45.0), lo.ToPtr(55.0)), 1e-2)
+	assert.Nil(t, lp.sessionThermalEnergy(nil, lo.ToPtr(55.0)))
+}
+
+type thermalNotAvailable struct{}
+
+func (thermalNotAvailable) ThermalModel() (api.ThermalModel, error) {
+	return api.ThermalModel{}, api.ErrNotAvailable
+}
+
+func TestThermalModelNotAvailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := &Loadpoint{
+		log: util.NewLogger("foo"),
+		charger: struct {
+			*api.MockCharger
+			thermalNotAvailable
+		}{
+			api.NewMockCharger(ctrl),
+			thermalNotAvailable{},
+		},
+	}
+
+	_, ok := lp.resolveThermalModel()
+	assert.False(t, ok)
+
+	// invalid model
+	lp.charger = struct {
+		*api.MockCharger
+		thermalStorage
+	}{
+		api.NewMockCharger(ctrl),
+		thermalStorage{Volume: 300, MinTemp: 60, MaxTemp: 40},
+	}
+
+	_, ok = lp.resolveThermalModel()
+	assert.False(t, ok)
+}
@@ -68,6 +68,12 @@ func (lp *Loadpoint) identifyVehicle() {
 	if id != "" {
 		lp.log.DEBUG.Println("charger vehicle id:", id)
 
+		// attribute session to identifier's user
+		lp.updateSession(func(s *session.Session) {
+			s.Identifier = id
+			s.UserID = lp.db.UserID(id)
+		})
+
 		if vehicle := lp.selectVehicleByID(id); vehicle != nil {
 			lp.stopVehicleDetection()
 			lp.setActiveVehicle(vehicle)
@@ -139,6 +145,7 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 			estimate = true
 		}
 		lp.socEstimator = soc.NewEstimator(lp.log, lp.charger, v, estimate)
+		lp.socEstimator.SetCurve(vehicle.Settings(lp.log, v).GetChargeCurve())
 
 		lp.publish(keys.VehicleName, vehicle.Settings(lp.log, v).Name())
 		lp.publish(keys.VehicleTitle, v.GetTitle())
@@ -172,6 +179,78 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 	})
 }
 
+// saveChargeCurve persists the charge curve learned by the soc estimator for the active vehicle
+func (lp *Loadpoint) saveChargeCurve() {
+	v := lp.GetVehicle()
+	if v == nil || lp.socEstimator == nil || !lp.socEstimator.Learned() {
+		return
+	}
+
+	lp.curvePersisted = lp.clock.Now()
+
+	if err := vehicle.Settings(lp.log, v).SetChargeCurve(lp.socEstimator.Curve()); err != nil {
+		lp.log.ERROR.Println("charge curve:", err)
+	}
+}
+
+// saveVehicleSnapshot persists the last known vehicle data when changed or periodically
+func (lp *Loadpoint) saveVehicleSnapshot() {
+	v := lp.GetVehicle()
+	if v == nil || lp.snapshot.Updated.IsZero() {
+		return
+	}
+
+	unchanged := lp.snapshot
+	unchanged.Updated = lp.snapshotSaved.Updated
+
+	if unchanged == lp.snapshotSaved && lp.snapshot.Updated.Sub(lp.snapshotSaved.Updated) < snapshotPersistInterval {
+		return
+	}
+
+	if err := vehicle.Settings(lp.log, v).SetSnapshot(lp.snapshot); err != nil {
+		lp.log.ERROR.Println("vehicle snapshot:", err)
+		return
+	}
+
+	lp.snapshotSaved = lp.snapshot
+}
+
+// restoreVehicleSnapshot publishes the last known vehicle data if no soc is available, e.g. after restart.
+// The data's age is published as soc timestamp.
+func (lp *Loadpoint) restoreVehicleSnapshot() {
+	v := lp.GetVehicle()
+	if v == nil || lp.vehicleSoc > 0 {
+		return
+	}
+
+	snapshot := vehicle.Settings(lp.log, v).GetSnapshot()
+	if snapshot.Updated.IsZero() {
+		return
+	}
+
+	lp.log.DEBUG.Printf("vehicle soc: %.0f%% (last known %v ago)", snapshot.Soc, lp.clock.Since(snapshot.Updated).Round(time.Minute))
+
+	lp.snapshot = snapshot
+	lp.snapshotSaved = snapshot
+
+	lp.vehicleSoc = snapshot.Soc
+	lp.publish(keys.VehicleSoc, lp.vehicleSoc)
+	lp.publish(keys.VehicleSocUpdated, snapshot.Updated)
+
+	if snapshot.Range > 0 {
+		lp.publish(keys.VehicleRange, snapshot.Range)
+	}
+
+	if snapshot.LimitSoc > 0 {
+		lp.setVehicleLimitSoc(snapshot.LimitSoc)
+		lp.publish(keys.VehicleLimitSoc, float64(snapshot.LimitSoc))
+	}
+
+	if snapshot.Odometer > 0 {
+		lp.publish(keys.VehicleOdometer, snapshot.Odometer)
+	}
+}
+
 func (lp *Loadpoint) wakeUpVehicle() {
 	// wake up charger or vehicle. First wakeupAttemptsLeft will be odd.
 	charger, chargerCanWakeUp := lp.charger.(api.Resurrector)
@@ -206,9 +285,14 @@ func (lp *Loadpoint) unpublishVehicleIdentity() {
 // unpublishVehicle resets published vehicle data
 func (lp *Loadpoint) unpublishVehicle() {
 	lp.vehicleSoc = 0
+	lp.vehicleLimitSoc = 0
+	lp.vehicleLimitSocSet = 0
+	lp.snapshot = vehicle.Snapshot{}
+	lp.snapshotSaved = vehicle.Snapshot{}
 
 	lp.publish(keys.VehicleClimaterActive, nil)
 	lp.publish(keys.VehicleSoc, 0.0)
+	lp.publish(keys.VehicleSocUpdated, nil)
 	lp.publish(keys.VehicleRange, int64(0))
 	lp.publish(keys.VehicleLimitSoc, 0.0)
 	lp.publish(keys.VehicleOdometer, 0.0)
@@ -308,11 +392,19 @@ func (lp *Loadpoint) vehicleOdometer() {
 		if odo, err := vs.Odometer(); err == nil {
 			lp.log.DEBUG.Printf("vehicle odometer: %.0fkm", odo)
 			lp.publish(keys.VehicleOdometer, odo)
+			lp.snapshot.Odometer = odo
 
 			// update session once odometer is read
 			lp.updateSession(func(session *session.Session) {
 				session.Odometer = &odo
 			})
+
+			// record plug-in odometer
+			if lp.logbook != nil && lp.logbook.Odometer == nil {
+				lp.updateLogbookEntry(func(entry *session.LogbookEntry) {
+					entry.Odometer = &odo
+				})
+			}
 		} else if !loadpoint.AcceptableError(err) {
 			lp.log.ERROR.Printf("vehicle odometer: %v", err)
 		}
@@ -11,8 +11,11 @@ import (
 	"github.com/evcc-io/evcc/core/coordinator"
 	"github.com/evcc-io/evcc/core/settings"
 	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -291,3 +294,95 @@ func TestReconnectVehicle(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishSocAndRangeLearnChargePower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	charger := api.NewMockCharger(ctrl)
+	vehicle := api.NewMockVehicle(ctrl)
+	expectVehiclePublish(vehicle)
+	vehicle.EXPECT().Soc().Return(50.0, nil).AnyTimes()
+
+	for _, tc := range []struct {
+		offered float64
+		learned bool
+	}{
+		{minA, false},
+		{maxA, true},
+	} {
+		log := util.NewLogger("foo")
+		lp := &Loadpoint{
+			log:            log,
+			bus:            evbus.New(),
+			clock:          clock.NewMock(),
+			charger:        charger,
+			vehicle:        vehicle,
+			chargeMeter:    &Null{}, // silence nil panics
+			chargeRater:    &Null{}, // silence nil panics
+			chargeTimer:    &Null{}, // silence nil panics
+			socEstimator:   soc.NewEstimator(log, charger, vehicle, false),
+			minCurrent:     minA,
+			maxCurrent:     maxA,
+			offeredCurrent: tc.offered,
+			chargePower:    3e3,
+			phases:         1,
+			status:         api.StatusC,
+			mode:           api.ModeNow,
+		}
+
+		// populate channels
+		x, y, z := createChannels(t)
+		attachChannels(lp, x, y, z)
+
+		lp.publishSocAndRange()
+		assert.Equal(t, tc.learned, lp.socEstimator.Learned(), tc)
+	}
+}
+
+func TestVehicleSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	v := api.NewMockVehicle(ctrl)
+	expectVehiclePublish(v)
+
+	require.NoError(t, config.Vehicles().Add(config.NewStaticDevice(config.Named{Name: "snapshot"}, api.Vehicle(v))))
+	t.Cleanup(func() { _ = config.Vehicles().Delete("snapshot") })
+
+	lp := &Loadpoint{
+		log:     util.NewLogger("foo"),
+		bus:     evbus.New(),
+		clock:   clck,
+		vehicle: v,
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	settings := vehicle.Settings(lp.log, v)
+
+	fetched := clck.Now().UTC()
+	lp.snapshot = vehicle.Snapshot{Soc: 50, Range: 200, LimitSoc: 80, Odometer: 1000, Updated: fetched}
+	lp.saveVehicleSnapshot()
+	assert.Equal(t, lp.snapshot, settings.GetSnapshot())
+
+	// unchanged data is persisted periodically only
+	clck.Add(time.Minute)
+	lp.snapshot.Updated = clck.Now().UTC()
+	lp.saveVehicleSnapshot()
+	assert.Equal(t, fetched, settings.GetSnapshot().Updated)
+
+	// changed data is persisted immediately
+	lp.snapshot.Soc = 51
+	lp.saveVehicleSnapshot()
+	assert.Equal(t, lp.snapshot, settings.GetSnapshot())
+
+	// restore stale data after restart
+	clck.Add(24 * time.Hour)
+	lp.unpublishVehicle()
+	lp.restoreVehicleSnapshot()
+	assert.Equal(t, 51.0, lp.vehicleSoc)
+	assert.Equal(t, 80, lp.vehicleLimitSoc)
+	assert.Equal(t, settings.GetSnapshot(), lp.snapshot)
+}
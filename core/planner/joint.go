@@ -0,0 +1,229 @@
+package planner
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Goal is a loadpoint's charging goal considered for joint planning
+type Goal struct {
+	Circuit          api.Circuit   // circuit the loadpoint is attached to
+	TargetTime       time.Time     // plan target time
+	RequiredDuration time.Duration // charging duration at max power
+	Precondition     time.Duration // precondition duration
+	Power            float64       // max charging power in W
+	Current          float64       // max phase current in A
+	Continuous       bool          // plan a single uninterrupted slot
+}
+
+// load is the planned load of a circuit during a single rate slot
+type load struct {
+	power, current float64
+}
+
+// limit is the max load of a circuit
+type limit load
+
+// Joint plans charging slots for multiple loadpoints together. Goals are
+// allocated in order of their target time, each using the cheapest slots
+// that still have capacity left in the goal's circuit hierarchy.
+type Joint struct {
+	mu      sync.Mutex
+	planner *Planner
+	goals   map[int]Goal
+}
+
+// NewJoint creates a joint planner for all loadpoints of a site
+func NewJoint(log *util.Logger, tariff api.Tariff, opt ...func(t *Planner)) *Joint {
+	return &Joint{
+		planner: New(log, tariff, opt...),
+		goals:   make(map[int]Goal),
+	}
+}
+
+// WithClock sets the planner's clock
+func WithClock(clock clock.Clock) func(t *Planner) {
+	return func(t *Planner) {
+		t.clock = clock
+	}
+}
+
+// Participant returns the planner for a single loadpoint
+func (t *Joint) Participant(id int) *Participant {
+	return &Participant{joint: t, id: id}
+}
+
+// setGoal registers the goal of a participant
+func (t *Joint) setGoal(id int, goal Goal) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.goals[id] = goal
+}
+
+// clearGoal removes the goal of a participant
+func (t *Joint) clearGoal(id int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.goals, id)
+}
+
+// plan creates the participant's plan for given goal, taking all other registered goals into account
+func (t *Joint) plan(id int, goal Goal) api.Rates {
+	if goal.RequiredDuration <= 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	goals := maps.Clone(t.goals)
+	t.mu.Unlock()
+
+	goals[id] = goal
+
+	var rates api.Rates
+	if t.planner.tariff != nil {
+		if res, err := t.planner.tariff.Rates(); err == nil {
+			rates = res
+		}
+	}
+
+	return t.allocate(rates, goals)[id]
+}
+
+// allocate creates plans for all goals, ordered by target time.
+// Rates MUST be sorted by start time.
+func (t *Joint) allocate(rates api.Rates, goals map[int]Goal) map[int]api.Rates {
+	ids := slices.SortedFunc(maps.Keys(goals), func(i, j int) int {
+		return cmp.Or(goals[i].TargetTime.Compare(goals[j].TargetTime), cmp.Compare(i, j))
+	})
+
+	limits := make(map[api.Circuit]limit)
+	usage := make([]map[api.Circuit]load, len(rates))
+	for i := range usage {
+		usage[i] = make(map[api.Circuit]load)
+	}
+
+	// full slots are removed from the available rates and must not shorten the tariff horizon
+	var horizon time.Time
+	if len(rates) > 0 {
+		horizon = rates[len(rates)-1].End
+	}
+
+	res := make(map[int]api.Rates, len(goals))
+
+	for _, id := range ids {
+		goal := goals[id]
+		if goal.RequiredDuration <= 0 {
+			continue
+		}
+
+		// rates with sufficient capacity left for this goal
+		var available api.Rates
+		for i, r := range rates {
+			if t.fits(limits, usage[i], goal) {
+				available = append(available, r)
+			}
+		}
+
+		plan := t.planGoal(available, horizon, goal)
+
+		if len(available) < len(rates) {
+			// compare with plan ignoring other goals
+			if unconstrained := t.planGoal(slices.Clone(rates), horizon, goal); len(available) == 0 || Duration(plan) < Duration(unconstrained) {
+				t.planner.log.WARN.Printf("plan: insufficient circuit capacity for loadpoint %d, ignoring other plans", id+1)
+				plan = unconstrained
+			}
+		}
+
+		res[id] = plan
+
+		// reserve capacity of all overlapping rate slots
+		for i, r := range rates {
+			if !overlaps(r, plan) {
+				continue
+			}
+
+			for c := goal.Circuit; c != nil; c = c.GetParent() {
+				u := usage[i][c]
+				u.power += goal.Power
+				u.current += goal.Current
+				usage[i][c] = u
+			}
+		}
+	}
+
+	return res
+}
+
+// planGoal creates the plan for a single goal from the given rates ending at the tariff horizon
+func (t *Joint) planGoal(rates api.Rates, horizon time.Time, goal Goal) api.Rates {
+	if goal.Continuous {
+		return t.planner.planContinuous(rates, goal.RequiredDuration, goal.TargetTime)
+	}
+	return t.planner.planRatesUntil(rates, horizon, goal.RequiredDuration, goal.Precondition, goal.TargetTime)
+}
+
+// fits checks if the goal's power fits into the remaining capacity of its circuit hierarchy
+func (t *Joint) fits(limits map[api.Circuit]limit, usage map[api.Circuit]load, goal Goal) bool {
+	for c := goal.Circuit; c != nil; c = c.GetParent() {
+		l, ok := limits[c]
+		if !ok {
+			l = limit{power: c.GetMaxPower(), current: c.GetMaxCurrent()}
+			limits[c] = l
+		}
+
+		u := usage[c]
+		if l.power > 0 && u.power+goal.Power > l.power {
+			return false
+		}
+		if l.current > 0 && u.current+goal.Current > l.current {
+			return false
+		}
+	}
+
+	return true
+}
+
+// overlaps checks if the rate overlaps any of the plan's slots
+func overlaps(r api.Rate, plan api.Rates) bool {
+	for _, slot := range plan {
+		if slot.Start.Before(r.End) && slot.End.After(r.Start) {
+			return true
+		}
+	}
+	return false
+}
+
+// Participant is a single loadpoint's view of the joint planner
+type Participant struct {
+	joint *Joint
+	id    int
+}
+
+// Plan creates a plan for the goal without registering it
+func (t *Participant) Plan(goal Goal) api.Rates {
+	if t == nil {
+		return nil
+	}
+	return t.joint.plan(t.id, goal)
+}
+
+// SetGoal registers the participant's active goal
+func (t *Participant) SetGoal(goal Goal) {
+	if t != nil {
+		t.joint.setGoal(t.id, goal)
+	}
+}
+
+// ClearGoal removes the participant's active goal
+func (t *Participant) ClearGoal() {
+	if t != nil {
+		t.joint.clearGoal(t.id)
+	}
+}
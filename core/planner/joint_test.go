@@ -0,0 +1,138 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestJointWithoutCircuit(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{10, 20, 30, 40}, clock.Now(), time.Hour), nil)
+
+	j := NewJoint(util.NewLogger("foo"), trf, WithClock(clock))
+
+	goal := Goal{
+		TargetTime:       clock.Now().Add(4 * time.Hour),
+		RequiredDuration: time.Hour,
+		Power:            11e3,
+		Current:          16,
+	}
+
+	j.Participant(0).SetGoal(goal)
+
+	// no circuit, both plans use the cheapest slot
+	plan := j.Participant(1).Plan(goal)
+	assert.Equal(t, api.Rates{{Start: clock.Now(), End: clock.Now().Add(time.Hour), Value: 10}}, plan)
+}
+
+func TestJointCircuitLimit(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{10, 20, 30, 40}, clock.Now(), time.Hour), nil)
+
+	circuit := api.NewMockCircuit(ctrl)
+	circuit.EXPECT().GetParent().AnyTimes().Return(nil)
+	circuit.EXPECT().GetMaxPower().AnyTimes().Return(16e3)
+	circuit.EXPECT().GetMaxCurrent().AnyTimes().Return(0.0)
+
+	j := NewJoint(util.NewLogger("foo"), trf, WithClock(clock))
+
+	goal := Goal{
+		Circuit:          circuit,
+		TargetTime:       clock.Now().Add(4 * time.Hour),
+		RequiredDuration: time.Hour,
+		Power:            11e3,
+		Current:          16,
+	}
+
+	lp1 := j.Participant(0)
+	lp2 := j.Participant(1)
+
+	lp1.SetGoal(goal)
+	assert.Equal(t, api.Rates{{Start: clock.Now(), End: clock.Now().Add(time.Hour), Value: 10}}, lp1.Plan(goal))
+
+	// second plan must use next cheapest slot
+	assert.Equal(t, api.Rates{{Start: clock.Now().Add(time.Hour), End: clock.Now().Add(2 * time.Hour), Value: 20}}, lp2.Plan(goal))
+
+	// earlier target time takes precedence
+	early := goal
+	early.TargetTime = clock.Now().Add(2 * time.Hour)
+	lp2.SetGoal(early)
+
+	assert.Equal(t, api.Rates{{Start: clock.Now(), End: clock.Now().Add(time.Hour), Value: 10}}, lp2.Plan(early))
+	assert.Equal(t, api.Rates{{Start: clock.Now().Add(time.Hour), End: clock.Now().Add(2 * time.Hour), Value: 20}}, lp1.Plan(goal))
+
+	// released capacity is available again
+	lp2.ClearGoal()
+	assert.Equal(t, api.Rates{{Start: clock.Now(), End: clock.Now().Add(time.Hour), Value: 10}}, lp1.Plan(goal))
+}
+
+func TestJointInsufficientCapacity(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{10, 20, 30, 40}, clock.Now(), time.Hour), nil)
+
+	circuit := api.NewMockCircuit(ctrl)
+	circuit.EXPECT().GetParent().AnyTimes().Return(nil)
+	circuit.EXPECT().GetMaxPower().AnyTimes().Return(0.0)
+	circuit.EXPECT().GetMaxCurrent().AnyTimes().Return(20.0)
+
+	j := NewJoint(util.NewLogger("foo"), trf, WithClock(clock))
+
+	goal := Goal{
+		Circuit:          circuit,
+		TargetTime:       clock.Now().Add(2 * time.Hour),
+		RequiredDuration: 2 * time.Hour,
+		Power:            11e3,
+		Current:          16,
+	}
+
+	j.Participant(0).SetGoal(goal)
+
+	// both goals need all slots, plan ignores other loadpoint
+	assert.Equal(t, 2*time.Hour, Duration(j.Participant(1).Plan(goal)))
+}
+
+func TestJointFullLateSlot(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{40, 30, 20, 10}, clock.Now(), time.Hour), nil)
+
+	circuit := api.NewMockCircuit(ctrl)
+	circuit.EXPECT().GetParent().AnyTimes().Return(nil)
+	circuit.EXPECT().GetMaxPower().AnyTimes().Return(16e3)
+	circuit.EXPECT().GetMaxCurrent().AnyTimes().Return(0.0)
+
+	j := NewJoint(util.NewLogger("foo"), trf, WithClock(clock))
+
+	// first goal occupies the last slot
+	first := Goal{
+		Circuit:          circuit,
+		TargetTime:       clock.Now().Add(4 * time.Hour),
+		RequiredDuration: time.Hour,
+		Power:            11e3,
+	}
+	j.Participant(0).SetGoal(first)
+
+	// second goal's target time is beyond the tariff horizon, full last slot must not shorten the horizon
+	second := first
+	second.TargetTime = clock.Now().Add(6 * time.Hour)
+	second.RequiredDuration = 3 * time.Hour
+
+	assert.Equal(t, api.Rates{{Start: clock.Now().Add(2 * time.Hour), End: clock.Now().Add(3 * time.Hour), Value: 20}}, j.Participant(1).Plan(second))
+}
@@ -1,6 +1,7 @@
 package planner
 
 import (
+	"math"
 	"slices"
 	"time"
 
@@ -136,11 +137,39 @@ func (t *Planner) continuousPlan(rates api.Rates, start, end time.Time) api.Rate
 	return res
 }
 
+// Plan creates a lowest-cost plan for the required duration using the planner's tariff
 func (t *Planner) Plan(requiredDuration, precondition time.Duration, targetTime time.Time) api.Rates {
 	if t == nil || requiredDuration <= 0 {
 		return nil
 	}
 
+	var rates api.Rates
+	if t.tariff != nil {
+		// treat like normal target charging if we don't have rates
+		if res, err := t.tariff.Rates(); err == nil {
+			rates = res
+		}
+	}
+
+	return t.planRates(rates, requiredDuration, precondition, targetTime)
+}
+
+// planRates creates a lowest-cost plan for the required duration from the given rates.
+// Rates are modified in place.
+func (t *Planner) planRates(rates api.Rates, requiredDuration, precondition time.Duration, targetTime time.Time) api.Rates {
+	var last time.Time
+	if len(rates) > 0 {
+		// rates are by default sorted by date, oldest to newest
+		last = rates[len(rates)-1].End
+	}
+
+	return t.planRatesUntil(rates, last, requiredDuration, precondition, targetTime)
+}
+
+// planRatesUntil creates a lowest-cost plan for the required duration from the given rates.
+// The end of the tariff horizon is given separately as rates may not be contiguous.
+// Rates are modified in place.
+func (t *Planner) planRatesUntil(rates api.Rates, last time.Time, requiredDuration, precondition time.Duration, targetTime time.Time) api.Rates {
 	latestStart := targetTime.Add(-requiredDuration)
 	if latestStart.Before(t.clock.Now()) {
 		latestStart = t.clock.Now()
@@ -155,15 +184,8 @@ func (t *Planner) Plan(requiredDuration, precondition time.Duration, targetTime
 		},
 	}
 
-	// target charging without tariff or late start
-	if t.tariff == nil {
-		return simplePlan
-	}
-
-	rates, err := t.tariff.Rates()
-
-	// treat like normal target charging if we don't have rates
-	if len(rates) == 0 || err != nil {
+	// target charging without tariff or rates
+	if len(rates) == 0 {
 		return simplePlan
 	}
 
@@ -172,9 +194,6 @@ func (t *Planner) Plan(requiredDuration, precondition time.Duration, targetTime
 		return t.continuousPlan(rates, latestStart, targetTime)
 	}
 
-	// rates are by default sorted by date, oldest to newest
-	last := rates[len(rates)-1].End
-
 	// sort rates by price and time
 	slices.SortStableFunc(rates, sortByCost)
 
@@ -215,6 +234,69 @@ func (t *Planner) Plan(requiredDuration, precondition time.Duration, targetTime
 	return plan
 }
 
+// planContinuous creates a lowest-cost plan for the required duration as a single uninterrupted slot.
+// Windows not fully covered by rates are only considered if no other window is available.
+func (t *Planner) planContinuous(rates api.Rates, requiredDuration time.Duration, targetTime time.Time) api.Rates {
+	now := t.clock.Now()
+
+	latestStart := targetTime.Add(-requiredDuration)
+	if latestStart.Before(now) {
+		latestStart = now
+	}
+
+	rates = slices.Clone(rates)
+	rates.Sort()
+
+	// the cost of a window only changes when its start or end crosses a slot boundary
+	candidates := []time.Time{now, latestStart}
+	for _, r := range rates {
+		candidates = append(candidates, r.Start, r.End.Add(-requiredDuration))
+	}
+
+	start := latestStart
+	cost := math.Inf(1)
+
+	for _, c := range candidates {
+		if c.Before(now) || c.After(latestStart) {
+			continue
+		}
+
+		// prefer late windows at same cost
+		if wc, ok := windowCost(rates, c, c.Add(requiredDuration)); ok && (wc < cost || wc == cost && c.After(start)) {
+			start, cost = c, wc
+		}
+	}
+
+	return t.continuousPlan(rates, start, start.Add(requiredDuration))
+}
+
+// windowCost returns the cost of the window between start and end.
+// Rates MUST be sorted by start time. Returns false if the window is not fully covered by rates.
+func windowCost(rates api.Rates, start, end time.Time) (float64, bool) {
+	var cost float64
+
+	ts := start
+	for _, r := range rates {
+		if !r.End.After(ts) {
+			continue
+		}
+
+		if r.Start.After(ts) || !ts.Before(end) {
+			break
+		}
+
+		slotEnd := r.End
+		if slotEnd.After(end) {
+			slotEnd = end
+		}
+
+		cost += r.Value * slotEnd.Sub(ts).Hours()
+		ts = slotEnd
+	}
+
+	return cost, !ts.Before(end)
+}
+
 func splitPreconditionSlots(rates api.Rates, precondition time.Duration, targetTime time.Time) (api.Rates, api.Rates) {
 	var res, adjusted api.Rates
 
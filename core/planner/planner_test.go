@@ -368,3 +368,34 @@ func TestContinuousPlanOutsideRates(t *testing.T) {
 	// 3-slot plan
 	assert.Len(t, plan, 1)
 }
+
+func TestPlanContinuousWindow(t *testing.T) {
+	clock := clock.NewMock()
+
+	p := &Planner{
+		log:   util.NewLogger("foo"),
+		clock: clock,
+	}
+
+	rr := rates([]float64{20, 60, 10, 80, 5, 15, 90}, clock.Now(), time.Hour)
+
+	// cheapest 2h window is 4:00-6:00 even though 2:00 is cheaper than 5:00
+	plan := p.planContinuous(rr, 2*time.Hour, clock.Now().Add(7*time.Hour))
+	assert.Equal(t, clock.Now().Add(4*time.Hour), Start(plan))
+	assert.Equal(t, clock.Now().Add(6*time.Hour), End(plan))
+	assert.Equal(t, 2*time.Hour, Duration(plan))
+
+	// window not aligned to slots
+	plan = p.planContinuous(rr, 90*time.Minute, clock.Now().Add(7*time.Hour))
+	assert.Equal(t, clock.Now().Add(4*time.Hour), Start(plan))
+	assert.Equal(t, clock.Now().Add(330*time.Minute), End(plan))
+
+	// target time limits window
+	plan = p.planContinuous(rr, 2*time.Hour, clock.Now().Add(4*time.Hour))
+	assert.Equal(t, clock.Now().Add(time.Hour), Start(plan))
+	assert.Equal(t, clock.Now().Add(3*time.Hour), End(plan))
+
+	// no rates starts as late as possible
+	plan = p.planContinuous(nil, time.Hour, clock.Now().Add(4*time.Hour))
+	assert.Equal(t, api.Rates{{Start: clock.Now().Add(3 * time.Hour), End: clock.Now().Add(4 * time.Hour)}}, plan)
+}
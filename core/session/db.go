@@ -14,7 +14,7 @@ type DB struct {
 
 // NewStore creates a session store
 func NewStore(name string, db *gorm.DB) (*DB, error) {
-	err := db.AutoMigrate(new(Session))
+	err := db.AutoMigrate(new(Session), new(Slot), new(User), new(UserTag), new(LogbookEntry))
 
 	sessiondb := &DB{
 		log:  util.NewLogger("db"),
@@ -40,16 +40,37 @@ func (s *DB) New(meter float64) *Session {
 
 // Persist creates or updates a transaction in the database
 func (s *DB) Persist(session interface{}) {
-	if err := s.db.Save(session).Error; err != nil {
+	// update existing slots as well
+	if err := s.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(session).Error; err != nil {
 		s.log.ERROR.Printf("persist: %v", err)
 	}
 }
 
+// UserID returns the id of the user owning the given identifier, e.g. an RFID tag
+func (s *DB) UserID(id string) *uint {
+	if normalizeTag(id) == "" {
+		return nil
+	}
+
+	var tag UserTag
+	tx := s.db.Limit(1).Find(&tag, "tag = ?", normalizeTag(id))
+	if tx.Error != nil {
+		s.log.ERROR.Printf("users: %v", tx.Error)
+		return nil
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil
+	}
+
+	return &tag.UserID
+}
+
 // Return sessions
 // TODO make this part of server/db
 func (s *DB) Sessions() (Sessions, error) {
 	var res Sessions
-	tx := s.db.Find(&res)
+	tx := s.db.Preload("Slots").Find(&res)
 	return res, tx.Error
 }
 
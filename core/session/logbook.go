@@ -0,0 +1,121 @@
+package session
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"golang.org/x/text/message"
+)
+
+// LogbookEvent is the vehicle event recorded in the logbook
+type LogbookEvent string
+
+const (
+	LogbookPlugIn  LogbookEvent = "plugin"
+	LogbookPlugOut LogbookEvent = "plugout"
+)
+
+// LogbookEntry records a vehicle's odometer and soc when plugged in or out
+type LogbookEntry struct {
+	ID            uint         `json:"id" gorm:"primarykey"`
+	Created       time.Time    `json:"created"`
+	Vehicle       string       `json:"vehicle" gorm:"index"` // vehicle name
+	Loadpoint     string       `json:"loadpoint"`
+	Event         LogbookEvent `json:"event"`
+	Odometer      *float64     `json:"odometer"`
+	Soc           *float64     `json:"soc"`
+	ChargedEnergy float64      `json:"chargedEnergy"` // energy charged while plugged in, kWh
+}
+
+// Trip is the driving between plugging a vehicle out and in again
+type Trip struct {
+	Vehicle       string    `json:"vehicle"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	OdometerStart *float64  `json:"odometerStart" csv:"Odometer Start (km)" format:"int"`
+	OdometerEnd   *float64  `json:"odometerEnd" csv:"Odometer End (km)" format:"int"`
+	Distance      *float64  `json:"distance" csv:"Distance (km)" format:"int"`
+	SocStart      *float64  `json:"socStart" csv:"Soc Start (%)" format:"int"`
+	SocEnd        *float64  `json:"socEnd" csv:"Soc End (%)" format:"int"`
+	Consumption   *float64  `json:"consumption" csv:"Consumption (kWh/100km)"`
+	ChargedEnergy float64   `json:"chargedEnergy" csv:"Charged Energy (kWh)"`         // energy charged after the trip, replacing the energy used
+	EnergyPerKm   *float64  `json:"energyPerKm" csv:"Charged Energy per km (kWh/km)"` // charged energy after the trip per km driven
+}
+
+// Trips is a vehicle logbook
+type Trips []Trip
+
+var _ api.CsvWriter = (*Trips)(nil)
+
+// NewTrips derives the trips from the vehicle's logbook entries. Capacity is the vehicle's battery capacity in kWh.
+func NewTrips(entries []LogbookEntry, capacity float64) Trips {
+	entries = slices.Clone(entries)
+	slices.SortStableFunc(entries, func(a, b LogbookEntry) int {
+		return a.Created.Compare(b.Created)
+	})
+
+	res := make(Trips, 0)
+
+	for i := 1; i < len(entries); i++ {
+		start, end := entries[i-1], entries[i]
+		if start.Event != LogbookPlugOut || end.Event != LogbookPlugIn {
+			continue
+		}
+
+		trip := Trip{
+			Vehicle:       start.Vehicle,
+			Start:         start.Created,
+			End:           end.Created,
+			OdometerStart: start.Odometer,
+			OdometerEnd:   end.Odometer,
+			SocStart:      start.Soc,
+			SocEnd:        end.Soc,
+		}
+
+		// energy charged while plugged in at the end of the trip
+		if i+1 < len(entries) && entries[i+1].Event == LogbookPlugOut {
+			trip.ChargedEnergy = entries[i+1].ChargedEnergy
+		}
+
+		if start.Odometer != nil && end.Odometer != nil && *end.Odometer > *start.Odometer {
+			distance := *end.Odometer - *start.Odometer
+			trip.Distance = &distance
+
+			// soc increases if the vehicle has been charged elsewhere during the trip
+			if start.Soc != nil && end.Soc != nil && *start.Soc > *end.Soc && capacity > 0 {
+				consumption := (*start.Soc - *end.Soc) / 100 * capacity / distance * 100
+				trip.Consumption = &consumption
+			}
+
+			if trip.ChargedEnergy > 0 {
+				perKm := trip.ChargedEnergy / distance
+				trip.EnergyPerKm = &perKm
+			}
+		}
+
+		res = append(res, trip)
+	}
+
+	return res
+}
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *Trips) WriteCsv(ctx context.Context, w io.Writer) error {
+	return writeCsv(ctx, w, func(ww *csv.Writer, mp *message.Printer) error {
+		if err := ww.Write(csvHeader(ctx, Trip{})); err != nil {
+			return err
+		}
+
+		for _, r := range *t {
+			if err := ww.Write(csvRow(mp, r)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestTrips(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := []LogbookEntry{
+		{Created: ts.Add(4 * time.Hour), Event: LogbookPlugOut, Odometer: lo.ToPtr(1100.0), Soc: lo.ToPtr(80.0), ChargedEnergy: 16},
+		{Created: ts, Event: LogbookPlugIn, Odometer: lo.ToPtr(1000.0), Soc: lo.ToPtr(40.0)},
+		{Created: ts.Add(6 * time.Hour), Event: LogbookPlugIn, Odometer: lo.ToPtr(1200.0), Soc: lo.ToPtr(50.0)},
+		{Created: ts.Add(7 * time.Hour), Event: LogbookPlugOut, Odometer: lo.ToPtr(1200.0), Soc: lo.ToPtr(60.0), ChargedEnergy: 15},
+		// charged elsewhere during the trip
+		{Created: ts.Add(8 * time.Hour), Event: LogbookPlugIn, Odometer: lo.ToPtr(1250.0), Soc: lo.ToPtr(70.0)},
+		// plug-in without previous plug-out is no trip
+		{Created: ts.Add(9 * time.Hour), Event: LogbookPlugIn, Odometer: lo.ToPtr(1260.0)},
+		{Created: ts.Add(10 * time.Hour), Event: LogbookPlugOut},
+		{Created: ts.Add(11 * time.Hour), Event: LogbookPlugIn, Odometer: lo.ToPtr(1300.0)},
+	}
+
+	res := NewTrips(entries, 50)
+	require.Len(t, res, 3)
+
+	assert.Equal(t, Trip{
+		Start:         ts.Add(4 * time.Hour),
+		End:           ts.Add(6 * time.Hour),
+		OdometerStart: lo.ToPtr(1100.0),
+		OdometerEnd:   lo.ToPtr(1200.0),
+		Distance:      lo.ToPtr(100.0),
+		SocStart:      lo.ToPtr(80.0),
+		SocEnd:        lo.ToPtr(50.0),
+		Consumption:   lo.ToPtr(15.0),
+		ChargedEnergy: 15,
+		EnergyPerKm:   lo.ToPtr(0.15),
+	}, res[0])
+
+	// soc increased, no charging after the trip
+	assert.Equal(t, lo.ToPtr(50.0), res[1].Distance)
+	assert.Nil(t, res[1].Consumption)
+	assert.Zero(t, res[1].ChargedEnergy)
+	assert.Nil(t, res[1].EnergyPerKm)
+
+	// unknown odometer
+	assert.Nil(t, res[2].Distance)
+	assert.Nil(t, res[2].Consumption)
+
+	row := csvRow(message.NewPrinter(language.English), res[0])
+	assert.Equal(t, []string{"1100", "1200", "100", "80", "50", "15", "15", "0.15"}, row[3:])
+}
@@ -0,0 +1,144 @@
+package session
+
+import (
+	"context"
+	"encoding/csv"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/samber/lo"
+	"golang.org/x/text/message"
+)
+
+// ReportEntry is the accumulated charging of a single user
+type ReportEntry struct {
+	User            string   `json:"user"`
+	CostCenter      string   `json:"costCenter" csv:"Cost Center"`
+	Sessions        int      `json:"sessions"`
+	ChargedEnergy   float64  `json:"chargedEnergy" csv:"Charged Energy (kWh)"`
+	SolarPercentage *float64 `json:"solarPercentage" csv:"Solar (%)"`
+	Price           *float64 `json:"price" csv:"Price"`
+}
+
+// Report is a per-user charging report
+type Report []ReportEntry
+
+var _ api.CsvWriter = (*Report)(nil)
+
+// NewReport creates a per-user report from the given sessions.
+// Sessions without known user are reported by identifier.
+func NewReport(sessions Sessions, users Users) Report {
+	type acc struct {
+		ReportEntry
+		solarEnergy float64 // energy with known solar percentage
+		solar       float64 // solar energy
+	}
+
+	type key struct {
+		user       uint
+		identifier string
+	}
+
+	entries := make(map[key]*acc)
+
+	for _, s := range sessions {
+		k := key{identifier: s.Identifier}
+		entry := ReportEntry{User: s.Identifier}
+
+		if s.UserID != nil {
+			if u, ok := users.ByID(*s.UserID); ok {
+				k = key{user: u.ID}
+				entry = ReportEntry{User: u.Name, CostCenter: u.CostCenter}
+			}
+		}
+
+		e, ok := entries[k]
+		if !ok {
+			e = &acc{ReportEntry: entry}
+			entries[k] = e
+		}
+
+		e.Sessions++
+		e.ChargedEnergy += s.ChargedEnergy
+
+		if s.SolarPercentage != nil {
+			e.solarEnergy += s.ChargedEnergy
+			e.solar += s.ChargedEnergy * *s.SolarPercentage / 100
+		}
+
+		if s.Price != nil {
+			e.Price = lo.ToPtr(lo.FromPtr(e.Price) + *s.Price)
+		}
+	}
+
+	res := make(Report, 0, len(entries))
+	for _, e := range entries {
+		if e.solarEnergy > 0 {
+			e.SolarPercentage = lo.ToPtr(100 * e.solar / e.solarEnergy)
+		}
+		res = append(res, e.ReportEntry)
+	}
+
+	slices.SortFunc(res, func(a, b ReportEntry) int {
+		return strings.Compare(a.User, b.User)
+	})
+
+	return res
+}
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *Report) WriteCsv(ctx context.Context, w io.Writer) error {
+	return writeCsv(ctx, w, func(ww *csv.Writer, mp *message.Printer) error {
+		if err := ww.Write(csvHeader(ctx, ReportEntry{})); err != nil {
+			return err
+		}
+
+		for _, r := range *t {
+			if err := ww.Write(csvRow(mp, r)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+var reportTmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"deref": lo.FromPtr[float64],
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+td.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<table>
+<tr><th>User</th><th>Cost Center</th><th>Sessions</th><th>Energy (kWh)</th><th>Solar (%)</th><th>Price</th></tr>
+{{- range .Report }}
+<tr><td>{{ .User }}</td><td>{{ .CostCenter }}</td><td class="num">{{ .Sessions }}</td><td class="num">{{ printf "%.3f" .ChargedEnergy }}</td><td class="num">{{ with .SolarPercentage }}{{ printf "%.1f" (deref .) }}{{ end }}</td><td class="num">{{ with .Price }}{{ printf "%.2f" (deref .) }}{{ end }}</td></tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as printable html page
+func (t *Report) WriteHTML(w io.Writer, title string) error {
+	return reportTmpl.Execute(w, struct {
+		Title  string
+		Report Report
+	}{
+		Title:  title,
+		Report: *t,
+	})
+}
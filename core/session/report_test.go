@@ -0,0 +1,62 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserTagJSON(t *testing.T) {
+	var u User
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"alice","tags":["AB12","CD34"]}`), &u))
+	assert.Equal(t, []UserTag{{Tag: "AB12"}, {Tag: "CD34"}}, u.Tags)
+
+	b, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"tags":["AB12","CD34"]`)
+}
+
+func TestReport(t *testing.T) {
+	users := Users{
+		{ID: 1, Name: "alice", CostCenter: "cc1"},
+	}
+
+	sessions := Sessions{
+		{UserID: lo.ToPtr(uint(1)), Identifier: "AB12", ChargedEnergy: 10, SolarPercentage: lo.ToPtr(100.0), Price: lo.ToPtr(1.0)},
+		{UserID: lo.ToPtr(uint(1)), Identifier: "CD34", ChargedEnergy: 30, SolarPercentage: lo.ToPtr(0.0), Price: lo.ToPtr(9.0)},
+		{Identifier: "EF56", ChargedEnergy: 5},
+		{UserID: lo.ToPtr(uint(2)), Identifier: "GH78", ChargedEnergy: 1}, // deleted user
+	}
+
+	res := NewReport(sessions, users)
+	require.Len(t, res, 3)
+
+	assert.Equal(t, ReportEntry{
+		User: "EF56", Sessions: 1, ChargedEnergy: 5,
+	}, res[0])
+
+	assert.Equal(t, ReportEntry{
+		User: "GH78", Sessions: 1, ChargedEnergy: 1,
+	}, res[1])
+
+	assert.Equal(t, ReportEntry{
+		User: "alice", CostCenter: "cc1", Sessions: 2, ChargedEnergy: 40,
+		SolarPercentage: lo.ToPtr(25.0), Price: lo.ToPtr(10.0),
+	}, res[2])
+
+	// renamed user
+	users[0].Name = "alice smith"
+	assert.Equal(t, "alice smith", NewReport(sessions, users)[2].User)
+
+	sessions.ResolveUsers(users)
+	assert.Equal(t, "alice smith", sessions[0].User)
+	assert.Empty(t, sessions[3].User)
+
+	var b bytes.Buffer
+	require.NoError(t, res.WriteHTML(&b, "report"))
+	assert.Contains(t, b.String(), "<td>alice</td><td>cc1</td>")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -25,6 +26,8 @@ type Session struct {
 	Finished        time.Time      `json:"finished"`
 	Loadpoint       string         `json:"loadpoint"`
 	Identifier      string         `json:"identifier"`
+	UserID          *uint          `json:"userId" csv:"-"`
+	User            string         `json:"user" gorm:"-"` // resolved from user id
 	Vehicle         string         `json:"vehicle"`
 	Odometer        *float64       `json:"odometer" format:"int"`
 	MeterStart      *float64       `json:"meterStart" csv:"Meter Start (kWh)" gorm:"column:meter_start_kwh"`
@@ -35,6 +38,10 @@ type Session struct {
 	Price           *float64       `json:"price" csv:"Price" gorm:"column:price"`
 	PricePerKWh     *float64       `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
 	Co2PerKWh       *float64       `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	TempStart       *float64       `json:"tempStart,omitempty" csv:"Temperature Start (°C)" gorm:"column:temp_start"`
+	TempStop        *float64       `json:"tempStop,omitempty" csv:"Temperature Stop (°C)" gorm:"column:temp_stop"`
+	ThermalEnergy   *float64       `json:"thermalEnergy,omitempty" csv:"Thermal Energy (kWh)" gorm:"column:thermal_kwh"`
+	Slots           Slots          `json:"slots,omitempty" csv:"-" gorm:"foreignKey:SessionID"`
 }
 
 // Sessions is a list of sessions
@@ -43,13 +50,18 @@ type Sessions []Session
 var _ api.CsvWriter = (*Sessions)(nil)
 
 func (t *Sessions) writeHeader(ctx context.Context, ww *csv.Writer) error {
+	return ww.Write(csvHeader(ctx, Session{}))
+}
+
+// csvHeader returns the localized csv captions of the struct's exported fields
+func csvHeader(ctx context.Context, v any) []string {
 	localizer := locale.Localizer
 	if val := ctx.Value(locale.Locale).(string); val != "" {
 		localizer = i18n.NewLocalizer(locale.Bundle, val, locale.Language)
 	}
 
 	var row []string
-	for _, f := range structs.Fields(Session{}) {
+	for _, f := range structs.Fields(v) {
 		csv := f.Tag("csv")
 		if csv == "-" {
 			continue
@@ -69,7 +81,7 @@ func (t *Sessions) writeHeader(ctx context.Context, ww *csv.Writer) error {
 		row = append(row, caption)
 	}
 
-	return ww.Write(row)
+	return row
 }
 
 func formatValue(mp *message.Printer, value any, digits int) string {
@@ -93,8 +105,13 @@ func formatValue(mp *message.Printer, value any, digits int) string {
 }
 
 func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) error {
+	return ww.Write(csvRow(mp, r))
+}
+
+// csvRow returns the formatted csv values of the struct's exported fields
+func csvRow(mp *message.Printer, v any) []string {
 	var row []string
-	for _, f := range structs.Fields(r) {
+	for _, f := range structs.Fields(v) {
 		if f.Tag("csv") == "-" {
 			continue
 		}
@@ -109,11 +126,28 @@ func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) erro
 		row = append(row, val)
 	}
 
-	return ww.Write(row)
+	return row
 }
 
 // WriteCsv implements the api.CsvWriter interface
 func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
+	return writeCsv(ctx, w, func(ww *csv.Writer, mp *message.Printer) error {
+		if err := t.writeHeader(ctx, ww); err != nil {
+			return err
+		}
+
+		for _, r := range *t {
+			if err := t.writeRow(ww, mp, r); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// writeCsv writes localized csv content using the rows function
+func writeCsv(ctx context.Context, w io.Writer, rows func(*csv.Writer, *message.Printer) error) error {
 	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
 		return err
 	}
@@ -136,18 +170,48 @@ func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
 		ww.Comma = ';'
 	}
 
-	if err := t.writeHeader(ctx, ww); err != nil {
+	if err := rows(ww, message.NewPrinter(tag)); err != nil {
 		return err
 	}
 
-	mp := message.NewPrinter(tag)
-	for _, r := range *t {
-		if err := t.writeRow(ww, mp, r); err != nil {
+	ww.Flush()
+
+	return ww.Error()
+}
+
+// SlotSessions is a list of sessions exported with one row per session slot
+type SlotSessions Sessions
+
+var _ api.CsvWriter = (*SlotSessions)(nil)
+
+// slotSession identifies the session of a slot csv row
+type slotSession struct {
+	Loadpoint  string
+	Identifier string
+	Vehicle    string
+}
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *SlotSessions) WriteCsv(ctx context.Context, w io.Writer) error {
+	return writeCsv(ctx, w, func(ww *csv.Writer, mp *message.Printer) error {
+		if err := ww.Write(append(csvHeader(ctx, slotSession{}), csvHeader(ctx, Slot{})...)); err != nil {
 			return err
 		}
-	}
 
-	ww.Flush()
+		for _, s := range *t {
+			session := csvRow(mp, slotSession{
+				Loadpoint:  s.Loadpoint,
+				Identifier: s.Identifier,
+				Vehicle:    s.Vehicle,
+			})
+
+			for _, slot := range s.Slots {
+				if err := ww.Write(append(slices.Clone(session), csvRow(mp, slot)...)); err != nil {
+					return err
+				}
+			}
+		}
 
-	return ww.Error()
+		return nil
+	})
 }
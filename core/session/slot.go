@@ -0,0 +1,59 @@
+package session
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// SlotDuration is the duration of a single session slot
+const SlotDuration = 15 * time.Minute
+
+// Slot is the charged energy of a session during a single time slot
+type Slot struct {
+	ID            uint      `json:"-" csv:"-" gorm:"primarykey"`
+	SessionID     uint      `json:"-" csv:"-" gorm:"index"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	ChargedEnergy float64   `json:"chargedEnergy" csv:"Charged Energy (kWh)" gorm:"column:charged_kwh"`
+	GridEnergy    float64   `json:"gridEnergy" csv:"Grid Energy (kWh)" gorm:"column:grid_kwh"`
+	SolarEnergy   float64   `json:"solarEnergy" csv:"Solar Energy (kWh)" gorm:"column:solar_kwh"`
+	BatteryEnergy float64   `json:"batteryEnergy" csv:"Battery Energy (kWh)" gorm:"column:battery_kwh"`
+	GridPrice     *float64  `json:"gridPrice" csv:"Grid Price/kWh" gorm:"column:grid_price_per_kwh"`
+	Price         *float64  `json:"price" csv:"Price" gorm:"column:price"`
+}
+
+// Slots is a list of session slots
+type Slots []Slot
+
+// AddEnergy adds charged energy in kWh to the session's slot at the given time.
+// Solar and battery share determine the energy's origin, the remainder is attributed to grid.
+// Price is the effective price per kWh of the added energy, grid price the current grid tariff.
+func (t *Session) AddEnergy(ts time.Time, energy, solarShare, batteryShare float64, price, gridPrice *float64) {
+	if energy <= 0 {
+		return
+	}
+
+	start := ts.Truncate(SlotDuration)
+	if n := len(t.Slots); n == 0 || !t.Slots[n-1].Start.Equal(start) {
+		t.Slots = append(t.Slots, Slot{
+			Start: start,
+			End:   start.Add(SlotDuration),
+		})
+	}
+
+	slot := &t.Slots[len(t.Slots)-1]
+
+	slot.ChargedEnergy += energy
+	slot.SolarEnergy += energy * solarShare
+	slot.BatteryEnergy += energy * batteryShare
+	slot.GridEnergy += energy * max(0, 1-solarShare-batteryShare)
+
+	if price != nil {
+		slot.Price = lo.ToPtr(lo.FromPtr(slot.Price) + energy**price)
+	}
+
+	if gridPrice != nil {
+		slot.GridPrice = lo.ToPtr(*gridPrice)
+	}
+}
@@ -0,0 +1,40 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddEnergy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var s Session
+	s.AddEnergy(start.Add(5*time.Minute), 1, 0.5, 0.25, lo.ToPtr(0.2), lo.ToPtr(0.3))
+	s.AddEnergy(start.Add(10*time.Minute), 1, 0, 0, lo.ToPtr(0.3), lo.ToPtr(0.3))
+	s.AddEnergy(start.Add(20*time.Minute), 2, 1, 0, nil, nil)
+	s.AddEnergy(start.Add(25*time.Minute), 0, 1, 0, nil, nil)
+
+	require.Len(t, s.Slots, 2)
+
+	assert.Equal(t, Slot{
+		Start:         start,
+		End:           start.Add(SlotDuration),
+		ChargedEnergy: 2,
+		GridEnergy:    1.25,
+		SolarEnergy:   0.5,
+		BatteryEnergy: 0.25,
+		GridPrice:     lo.ToPtr(0.3),
+		Price:         lo.ToPtr(0.5),
+	}, s.Slots[0])
+
+	assert.Equal(t, Slot{
+		Start:         start.Add(SlotDuration),
+		End:           start.Add(2 * SlotDuration),
+		ChargedEnergy: 2,
+		SolarEnergy:   2,
+	}, s.Slots[1])
+}
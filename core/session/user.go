@@ -0,0 +1,71 @@
+package session
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// User is a person or cost center that charging sessions are attributed to by identifier
+type User struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	Name       string    `json:"name" gorm:"uniqueIndex"`
+	CostCenter string    `json:"costCenter"`
+	Tags       []UserTag `json:"tags" gorm:"foreignKey:UserID"`
+}
+
+// UserTag is an identifier owned by a user, e.g. an RFID tag
+type UserTag struct {
+	ID     uint   `gorm:"primarykey"`
+	UserID uint   `gorm:"index"`
+	Tag    string `gorm:"uniqueIndex"`
+}
+
+// normalizeTag returns the case-insensitive representation of an identifier
+func normalizeTag(tag string) string {
+	return strings.ToUpper(strings.TrimSpace(tag))
+}
+
+// BeforeSave implements the gorm hook
+func (t *UserTag) BeforeSave(*gorm.DB) error {
+	t.Tag = normalizeTag(t.Tag)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (t UserTag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Tag)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *UserTag) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &t.Tag)
+}
+
+// Users is a list of users
+type Users []User
+
+// ByID returns the user with the given id
+func (uu Users) ByID(id uint) (User, bool) {
+	for _, u := range uu {
+		if u.ID == id {
+			return u, true
+		}
+	}
+
+	return User{}, false
+}
+
+// ResolveUsers sets the sessions' user names from their user ids
+func (t Sessions) ResolveUsers(users Users) {
+	for i, s := range t {
+		if s.UserID == nil {
+			continue
+		}
+
+		if u, ok := users.ByID(*s.UserID); ok {
+			t[i].User = u.Name
+		}
+	}
+}
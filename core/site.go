@@ -14,8 +14,10 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/cmd/shutdown"
+	"github.com/evcc-io/evcc/core/battery"
 	"github.com/evcc-io/evcc/core/circuit"
 	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/core/household"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/planner"
@@ -43,6 +45,7 @@ const standbyPower = 10 // consider less than 10W as charger in standby
 type updater interface {
 	loadpoint.API
 	Update(sitePower, batteryBoostPower float64, rates api.Rates, batteryBuffered, batteryStart bool, greenShare float64, effectivePrice, effectiveCo2 *float64)
+	setSessionBatteryShare(batteryShare float64)
 }
 
 // measurement is used as slice element for publishing structured data
@@ -72,10 +75,13 @@ type Site struct {
 	log *util.Logger
 
 	// configuration
-	Title         string       `mapstructure:"title"`         // UI title
-	Voltage       float64      `mapstructure:"voltage"`       // Operating voltage. 230V for Germany.
-	ResidualPower float64      `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
-	Meters        MetersConfig `mapstructure:"meters"`        // Meter references
+	Title             string              `mapstructure:"title"`             // UI title
+	Voltage           float64             `mapstructure:"voltage"`           // Operating voltage. 230V for Germany.
+	ResidualPower     float64             `mapstructure:"residualPower"`     // PV meter only: household usage. Grid meter: household safety margin
+	FeedInLimit       *float64            `mapstructure:"feedInLimit"`       // Static grid feed-in limit in W, e.g. 60/70% rule
+	NegativePrice     NegativePriceConfig `mapstructure:"negativePrice"`     // Negative price policy
+	CircuitAllocation AllocationPolicy    `mapstructure:"circuitAllocation"` // Circuit capacity sharing between loadpoints
+	Meters            MetersConfig        `mapstructure:"meters"`            // Meter references
 
 	// meters
 	circuit       api.Circuit                // Circuit
@@ -91,26 +97,43 @@ type Site struct {
 	bufferStartSoc          float64  // start charging on battery above this Soc
 	batteryDischargeControl bool     // prevent battery discharge for fast and planned charging
 	batteryGridChargeLimit  *float64 // grid charging limit
+	batteryOptimizer        bool     // schedule battery mode based on forecasts
 
 	loadpoints  []*Loadpoint             // Loadpoints
 	tariffs     *tariff.Tariffs          // Tariffs
 	coordinator *coordinator.Coordinator // Vehicles
 	prioritizer *prioritizer.Prioritizer // Power budgets
+	planner     *planner.Joint           // Joint charge planner
 	stats       *Stats                   // Stats
 	fcstEnergy  *meterEnergy
 	pvEnergy    map[string]*meterEnergy
 
+	circuitStats          map[string]*circuitEnergy // Circuit energy and load statistics
+	circuitStatsPersisted time.Time                 // Circuit statistics last persisted
+
 	// cached state
-	gridPower                float64         // Grid power
-	pvPower                  float64         // PV power
-	excessDCPower            float64         // PV excess DC charge power (hybrid only)
-	auxPower                 float64         // Aux power
-	batteryPower             float64         // Battery power (charge negative, discharge positive)
-	batterySoc               float64         // Battery soc
-	batteryCapacity          float64         // Battery capacity
-	batteryMode              api.BatteryMode // Battery mode (runtime only, not persisted)
-	batteryModeExternal      api.BatteryMode // Battery mode (external, runtime only, not persisted)
-	batteryModeExternalTimer time.Time       // Battery mode timer for external control
+	gridPower                float64               // Grid power
+	pvPower                  float64               // PV power
+	excessDCPower            float64               // PV excess DC charge power (hybrid only)
+	auxPower                 float64               // Aux power
+	batteryPower             float64               // Battery power (charge negative, discharge positive)
+	batterySoc               float64               // Battery soc
+	batteryCapacity          float64               // Battery capacity
+	batteryMode              api.BatteryMode       // Battery mode (runtime only, not persisted)
+	batteryModeExternal      api.BatteryMode       // Battery mode (external, runtime only, not persisted)
+	batteryModeExternalTimer time.Time             // Battery mode timer for external control
+	homePower                float64               // Home power
+	feedInLimitExternal      *float64              // Feed-in limit (external, runtime only, not persisted)
+	curtailment              curtailment           // PV curtailment state
+	negativePrice            negativePriceStruct   // Negative price policy state
+	household                *household.Forecaster // Household load forecast
+	householdPersisted       time.Time             // Household load profile last persisted
+
+	batterySchedule          battery.Schedule              // Battery schedule (runtime only, not persisted)
+	batteryScheduleOverrides map[time.Time]api.BatteryMode // Battery schedule overrides (runtime only, not persisted)
+	batteryScheduleRates     []api.Rates                   // Battery schedule tariffs and forecasts at last optimization
+	batteryScheduleLoad      api.Rates                     // Battery schedule learned household energy forecast at last optimization
+	batteryScheduleStale     bool                          // Battery schedule needs optimization
 }
 
 // MetersConfig contains the site's meter configuration
@@ -134,6 +157,10 @@ func NewSiteFromConfig(other map[string]interface{}) (*Site, error) {
 	// add meters from config
 	site.restoreMetersAndTitle()
 
+	if err := site.CircuitAllocation.valid(); err != nil {
+		return nil, err
+	}
+
 	// TODO title
 	Voltage = site.Voltage
 
@@ -158,12 +185,13 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 		})
 	}
 
-	tariff := site.GetTariff(api.TariffUsagePlanner)
+	// plan all loadpoints jointly to respect circuit limits
+	site.planner = planner.NewJoint(site.log, site.GetTariff(api.TariffUsagePlanner))
 
 	// give loadpoints access to vehicles and database
-	for _, lp := range loadpoints {
+	for id, lp := range loadpoints {
 		lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
-		lp.planner = planner.New(lp.log, tariff)
+		lp.planner = site.planner.Participant(id)
 
 		if db.Instance != nil {
 			var err error
@@ -233,6 +261,9 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 		site.auxMeters = append(site.auxMeters, dev)
 	}
 
+	// release pv curtailment on shutdown
+	shutdown.Register(site.releaseCurtailment)
+
 	// revert battery mode on shutdown
 	shutdown.Register(func() {
 		if mode := site.GetBatteryMode(); batteryModeModified(mode) {
@@ -248,10 +279,15 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 // NewSite creates a Site with sane defaults
 func NewSite() *Site {
 	site := &Site{
-		log:        util.NewLogger("site"),
-		Voltage:    230, // V
-		pvEnergy:   make(map[string]*meterEnergy),
-		fcstEnergy: &meterEnergy{clock: clock.New()},
+		log:      util.NewLogger("site"),
+		Voltage:  230, // V
+		pvEnergy: make(map[string]*meterEnergy),
+
+		circuitStats: make(map[string]*circuitEnergy),
+		fcstEnergy:   &meterEnergy{clock: clock.New()},
+		household:    household.New(clock.New()),
+
+		batteryScheduleOverrides: make(map[time.Time]api.BatteryMode),
 	}
 
 	return site
@@ -316,6 +352,22 @@ func (site *Site) restoreSettings() error {
 	if v, err := settings.Float(keys.BatteryGridChargeLimit); err == nil {
 		site.SetBatteryGridChargeLimit(&v)
 	}
+	if v, err := settings.Bool(keys.BatteryOptimizer); err == nil {
+		if err := site.SetBatteryOptimizer(v); err != nil {
+			return err
+		}
+	}
+
+	// restore household load profile
+	var profile household.Profile
+	if err := settings.Json(keys.HouseholdProfile, &profile); err == nil {
+		if err := site.household.Load(profile); err != nil {
+			site.log.WARN.Println("household profile:", err)
+		}
+	}
+
+	// restore circuit statistics
+	site.restoreCircuitStats()
 
 	// restore accumulated energy
 	pvEnergy := make(map[string]meterEnergy)
@@ -537,6 +589,8 @@ func (site *Site) updatePvMeters() {
 
 	mm := site.collectMeters("pv", site.pvMeters)
 
+	var curtailablePower float64
+
 	for i, dev := range site.pvMeters {
 		meter := dev.Instance()
 		if _, ok := meter.(api.Meter); !ok {
@@ -548,6 +602,10 @@ func (site *Site) updatePvMeters() {
 			site.log.WARN.Printf("pv %d power: %.0fW is negative - check configuration if sign is correct", i+1, power)
 		}
 
+		if _, ok := meter.(api.PVCurtailer); ok {
+			curtailablePower += max(0, power)
+		}
+
 		if m, ok := meter.(api.MaxACPowerGetter); ok {
 			if dc := power - m.MaxACPower(); dc > 0 && power > 0 {
 				mm[i].ExcessDCPower = dc
@@ -556,6 +614,8 @@ func (site *Site) updatePvMeters() {
 		}
 	}
 
+	site.curtailment.power = curtailablePower
+
 	site.pvPower = lo.SumBy(mm, func(m measurement) float64 {
 		return max(0, m.Power)
 	})
@@ -876,6 +936,9 @@ func (site *Site) update(lp updater) {
 		site.log.WARN.Println("planner:", err)
 	}
 
+	// negative price policy
+	site.updateNegativePrice()
+
 	// update loadpoints
 	totalChargePower := site.updateLoadpoints(rates)
 
@@ -886,6 +949,9 @@ func (site *Site) update(lp updater) {
 		}
 
 		site.publishCircuits()
+
+		// share circuit capacity between loadpoints
+		site.allocateCircuits()
 	}
 
 	// prioritize if possible
@@ -909,20 +975,31 @@ func (site *Site) update(lp updater) {
 
 	batteryGridChargeActive := site.batteryGridChargeActive(rate)
 	site.publish(keys.BatteryGridChargeActive, batteryGridChargeActive)
+	site.updateBatterySchedule()
 	site.updateBatteryMode(batteryGridChargeActive, rate)
 
 	if sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower); err == nil {
+		// offer pv power withheld by curtailment for absorbing
+		sitePower -= site.updateCurtailment()
+
 		// ignore negative pvPower values as that means it is not an energy source but consumption
 		homePower := site.gridPower + max(0, site.pvPower) + site.batteryPower - totalChargePower
 		homePower = max(homePower, 0)
 		site.publish(keys.HomePower, homePower)
 
+		site.Lock()
+		site.homePower = homePower
+		site.Unlock()
+
+		site.updateHousehold(homePower)
+
 		// add battery charging power to homePower to ignore all consumption which does not occur on loadpoints
 		// fix for: https://github.com/evcc-io/evcc/issues/11032
 		nonChargePower := homePower + max(0, -site.batteryPower)
 		greenShareHome := site.greenShare(0, homePower)
 		greenShareLoadpoints := site.greenShare(nonChargePower, nonChargePower+totalChargePower)
 
+		lp.setSessionBatteryShare(site.batteryShare(nonChargePower, nonChargePower+totalChargePower))
 		lp.Update(
 			sitePower, max(0, site.batteryPower), rates, batteryBuffered, batteryStart,
 			greenShareLoadpoints, site.effectivePrice(greenShareLoadpoints), site.effectiveCo2(greenShareLoadpoints),
@@ -961,6 +1038,7 @@ func (site *Site) prepare() {
 	site.publish(keys.BufferStartSoc, site.bufferStartSoc)
 	site.publish(keys.BatteryMode, site.batteryMode)
 	site.publish(keys.BatteryDischargeControl, site.batteryDischargeControl)
+	site.publish(keys.BatteryOptimizer, site.batteryOptimizer)
 	site.publish(keys.ResidualPower, site.GetResidualPower())
 
 	site.publish(keys.Currency, site.tariffs.Currency)
@@ -1,7 +1,10 @@
 package site
 
 import (
+	"time"
+
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/battery"
 	"github.com/evcc-io/evcc/core/loadpoint"
 )
 
@@ -30,6 +33,8 @@ type API interface {
 	// circuits
 	GetCircuit() api.Circuit
 	SetCircuit(api.Circuit)
+	// GetCircuitStats returns the energy and load statistics per circuit
+	GetCircuitStats() map[string]CircuitStats
 
 	//
 	// battery
@@ -68,6 +73,26 @@ type API interface {
 	GetBatteryDischargeControl() bool
 	SetBatteryDischargeControl(bool) error
 
+	//
+	// forecast
+	//
+
+	// GetSurplusForecast returns the loadpoint's share of the expected solar surplus power based on solar and household forecasts
+	GetSurplusForecast(loadpoint.API) api.Rates
+
+	//
+	// battery schedule
+	//
+
+	// GetBatteryOptimizer returns if the battery optimizer is enabled
+	GetBatteryOptimizer() bool
+	// SetBatteryOptimizer enables the battery optimizer
+	SetBatteryOptimizer(bool) error
+	// GetBatterySchedule returns the battery schedule
+	GetBatterySchedule() battery.Schedule
+	// SetBatteryScheduleOverride sets the battery mode of the schedule slot at given time
+	SetBatteryScheduleOverride(time.Time, api.BatteryMode) error
+
 	//
 	// battery control external
 	//
@@ -76,4 +101,13 @@ type API interface {
 	GetBatteryModeExternal() api.BatteryMode
 	// SetBatteryModeExternal sets the external battery mode
 	SetBatteryModeExternal(api.BatteryMode)
+
+	//
+	// feed-in limitation external
+	//
+
+	// GetFeedInLimitExternal returns the external grid feed-in limit
+	GetFeedInLimitExternal() *float64
+	// SetFeedInLimitExternal sets the external grid feed-in limit, nil removes the limit
+	SetFeedInLimitExternal(*float64)
 }
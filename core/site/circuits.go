@@ -0,0 +1,18 @@
+package site
+
+import "time"
+
+// CircuitDay is a circuit's energy and load statistics for a single day
+type CircuitDay struct {
+	Day         time.Time     `json:"day"`
+	Energy      float64       `json:"energy"`      // kWh
+	PeakPower   float64       `json:"peakPower"`   // W
+	PeakCurrent float64       `json:"peakCurrent"` // A
+	OverLimit   time.Duration `json:"overLimit"`   // time above max power or max current
+}
+
+// CircuitStats are a circuit's energy and load statistics for today and previous days
+type CircuitStats struct {
+	Today   CircuitDay   `json:"today"`
+	History []CircuitDay `json:"history"`
+}
@@ -0,0 +1,237 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// AllocationPolicy determines how circuit capacity is shared between loadpoints
+type AllocationPolicy string
+
+const (
+	AllocationNone      AllocationPolicy = ""          // capacity is taken in loadpoint update order
+	AllocationEqual     AllocationPolicy = "equal"     // capacity is shared equally
+	AllocationPriority  AllocationPolicy = "priority"  // capacity is shared weighted by loadpoint priority
+	AllocationFirstCome AllocationPolicy = "firstcome" // capacity is assigned in order of vehicle connection
+	AllocationUrgency   AllocationPolicy = "urgency"   // capacity is assigned in order of plan target time
+)
+
+func (p AllocationPolicy) valid() error {
+	if !slices.Contains([]AllocationPolicy{AllocationNone, AllocationEqual, AllocationPriority, AllocationFirstCome, AllocationUrgency}, p) {
+		return fmt.Errorf("invalid circuit allocation: %s", p)
+	}
+	return nil
+}
+
+// allocationDemand is a loadpoint's demand for circuit capacity
+type allocationDemand struct {
+	min, max  float64   // effective min/max current
+	charging  bool      // currently charging
+	connected time.Time // vehicle connection time
+	priority  int       // effective priority
+	plan      time.Time // plan target time
+}
+
+// allocationOrder returns the order in which loadpoints are served
+func allocationOrder(policy AllocationPolicy, demands []allocationDemand) []int {
+	order := make([]int, len(demands))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(i, j int) int {
+		a, b := demands[i], demands[j]
+
+		switch policy {
+		case AllocationPriority:
+			if a.priority != b.priority {
+				return b.priority - a.priority
+			}
+
+		case AllocationFirstCome:
+			if c := a.connected.Compare(b.connected); c != 0 {
+				return c
+			}
+
+		case AllocationUrgency:
+			if a.plan.IsZero() != b.plan.IsZero() {
+				if a.plan.IsZero() {
+					return 1
+				}
+				return -1
+			}
+			if c := a.plan.Compare(b.plan); c != 0 {
+				return c
+			}
+		}
+
+		// keep charging loadpoints running
+		if a.charging != b.charging {
+			if a.charging {
+				return -1
+			}
+			return 1
+		}
+
+		return 0
+	})
+
+	return order
+}
+
+// allocate distributes the available current between loadpoints. Loadpoints are admitted in policy
+// order as long as their min current can be served. Loadpoints that are not admitted receive zero.
+func allocate(policy AllocationPolicy, available float64, demands []allocationDemand) []float64 {
+	res := make([]float64, len(demands))
+	admitted := make([]bool, len(demands))
+	order := allocationOrder(policy, demands)
+
+	for _, i := range order {
+		if d := demands[i]; d.min <= available {
+			admitted[i] = true
+			res[i] = d.min
+			available -= d.min
+		}
+	}
+
+	// serve in order up to max current
+	if policy == AllocationFirstCome || policy == AllocationUrgency {
+		for _, i := range order {
+			if admitted[i] {
+				add := min(demands[i].max-res[i], available)
+				res[i] += add
+				available -= add
+			}
+		}
+
+		return res
+	}
+
+	weight := func(i int) float64 {
+		if policy == AllocationPriority {
+			return float64(max(0, demands[i].priority) + 1)
+		}
+		return 1
+	}
+
+	// share remaining current weighted until exhausted or all loadpoints saturated
+	for available > 1e-3 {
+		var total float64
+		for i := range demands {
+			if admitted[i] && res[i] < demands[i].max {
+				total += weight(i)
+			}
+		}
+
+		if total == 0 {
+			break
+		}
+
+		var used float64
+		for i := range demands {
+			if admitted[i] && res[i] < demands[i].max {
+				add := min(demands[i].max-res[i], available*weight(i)/total)
+				res[i] += add
+				used += add
+			}
+		}
+
+		available -= used
+	}
+
+	return res
+}
+
+// allocationDemand returns the loadpoint's demand for circuit capacity, false if it has none.
+// Connected loadpoints have demand even if not yet enabled, since enabling depends on the allocation.
+func (lp *Loadpoint) allocationDemand() (allocationDemand, bool) {
+	status := lp.GetStatus()
+
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if status != api.StatusC && (status != api.StatusB || lp.mode == api.ModeOff) {
+		return allocationDemand{}, false
+	}
+
+	return allocationDemand{
+		min:       lp.effectiveMinCurrent(),
+		max:       lp.effectiveMaxCurrent(),
+		charging:  status == api.StatusC,
+		connected: lp.connectedTime,
+	}, true
+}
+
+// getAllocatedCurrent returns the circuit current allocated to the loadpoint
+func (lp *Loadpoint) getAllocatedCurrent() *float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.allocation
+}
+
+// setAllocatedCurrent sets the circuit current allocated to the loadpoint
+func (lp *Loadpoint) setAllocatedCurrent(current *float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if current != nil && lp.allocation != nil && *current == *lp.allocation || current == nil && lp.allocation == nil {
+		return
+	}
+
+	lp.allocation = current
+	lp.publish(keys.AllocatedCurrent, current)
+}
+
+// allocateCircuits shares each circuit's available current between its loadpoints according to the allocation policy
+func (site *Site) allocateCircuits() {
+	if site.CircuitAllocation == AllocationNone {
+		return
+	}
+
+	for _, dev := range config.Circuits().Devices() {
+		circuit := dev.Instance()
+
+		var lps []*Loadpoint
+		for _, lp := range site.loadpoints {
+			if lp.GetCircuit() == circuit {
+				lps = append(lps, lp)
+			}
+		}
+
+		maxCurrent := circuit.GetMaxCurrent()
+		if maxCurrent == 0 || len(lps) == 0 {
+			continue
+		}
+
+		var (
+			demands []allocationDemand
+			active  []*Loadpoint
+			load    float64
+		)
+
+		for _, lp := range lps {
+			load += lp.GetMaxPhaseCurrent()
+
+			if d, ok := lp.allocationDemand(); ok {
+				d.priority = lp.EffectivePriority()
+				d.plan = lp.EffectivePlanTime()
+				demands = append(demands, d)
+				active = append(active, lp)
+			} else {
+				lp.setAllocatedCurrent(nil)
+			}
+		}
+
+		// capacity not used by other consumers
+		available := maxCurrent - max(0, circuit.GetMaxPhaseCurrent()-load)
+
+		for i, current := range allocate(site.CircuitAllocation, available, demands) {
+			active[i].setAllocatedCurrent(&current)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocate(t *testing.T) {
+	now := time.Now()
+
+	demands := []allocationDemand{
+		{min: 6, max: 16, connected: now.Add(-time.Hour)},
+		{min: 6, max: 16, connected: now, charging: true, priority: 2},
+		{min: 6, max: 32, connected: now.Add(-2 * time.Hour), plan: now.Add(time.Hour)},
+	}
+
+	for _, tc := range []struct {
+		policy    AllocationPolicy
+		available float64
+		res       []float64
+	}{
+		// ample capacity
+		{AllocationEqual, 100, []float64{16, 16, 32}},
+		{AllocationFirstCome, 100, []float64{16, 16, 32}},
+		// equal share
+		{AllocationEqual, 30, []float64{10, 10, 10}},
+		{AllocationEqual, 40, []float64{40.0 / 3, 40.0 / 3, 40.0 / 3}},
+		// charging loadpoint keeps running, no loadpoint below min
+		{AllocationEqual, 12, []float64{6, 6, 0}},
+		{AllocationEqual, 11, []float64{0, 11, 0}},
+		// priority weighted
+		{AllocationPriority, 30, []float64{8.4, 13.2, 8.4}},
+		// first come
+		{AllocationFirstCome, 30, []float64{6, 6, 18}},
+		{AllocationFirstCome, 12, []float64{6, 0, 6}},
+		// urgency
+		{AllocationUrgency, 30, []float64{6, 6, 18}},
+		{AllocationUrgency, 12, []float64{0, 6, 6}},
+	} {
+		t.Logf("%+v", tc)
+		assert.InDeltaSlice(t, tc.res, allocate(tc.policy, tc.available, demands), 1e-6)
+	}
+}
+
+func TestAllocationDemand(t *testing.T) {
+	for _, tc := range []struct {
+		status  api.ChargeStatus
+		mode    api.ChargeMode
+		enabled bool
+		demand  bool
+	}{
+		{api.StatusA, api.ModeNow, false, false},
+		{api.StatusB, api.ModeOff, false, false},
+		{api.StatusB, api.ModePV, false, true},
+		{api.StatusB, api.ModeNow, true, true},
+		{api.StatusC, api.ModePV, true, true},
+	} {
+		t.Logf("%+v", tc)
+
+		lp := NewLoadpoint(util.NewLogger("foo"), nil)
+		lp.status = tc.status
+		lp.mode = tc.mode
+		lp.enabled = tc.enabled
+		lp.minCurrent = 6
+		lp.maxCurrent = 16
+
+		d, ok := lp.allocationDemand()
+		assert.Equal(t, tc.demand, ok)
+
+		if ok {
+			assert.Equal(t, 6.0, d.min)
+			assert.Equal(t, 16.0, d.max)
+			assert.Equal(t, tc.status == api.StatusC, d.charging)
+		}
+	}
+}
@@ -394,3 +394,25 @@ func (site *Site) batteryModeWatchdogExpired() bool {
 
 	return false
 }
+
+// GetFeedInLimitExternal returns the external grid feed-in limit
+func (site *Site) GetFeedInLimitExternal() *float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.feedInLimitExternal
+}
+
+// SetFeedInLimitExternal sets the external grid feed-in limit, nil removes the limit
+func (site *Site) SetFeedInLimitExternal(limit *float64) {
+	site.Lock()
+	defer site.Unlock()
+
+	if limit != nil {
+		site.log.DEBUG.Printf("set external feed-in limit: %.0fW", *limit)
+	} else if site.feedInLimitExternal != nil {
+		site.log.DEBUG.Println("remove external feed-in limit")
+	}
+
+	site.feedInLimitExternal = limit
+	site.publish(keys.FeedInLimitExternal, limit)
+}
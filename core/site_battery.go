@@ -54,6 +54,7 @@ func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rat
 	var res api.BatteryMode
 	batMode := site.GetBatteryMode()
 	extMode := site.GetBatteryModeExternal()
+	scheduleMode := site.batteryScheduleMode()
 
 	var extModeReset bool
 	if extMode == api.BatteryUnknown {
@@ -77,9 +78,9 @@ func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rat
 		if extMode != batMode {
 			res = extMode
 		}
-	case batteryGridChargeActive:
+	case batteryGridChargeActive, scheduleMode == api.BatteryCharge, site.negativePrice.gridActive():
 		res = mapper(api.BatteryCharge)
-	case site.dischargeControlActive(rate):
+	case site.dischargeControlActive(rate), scheduleMode == api.BatteryHold, site.negativePrice.feedInActive():
 		res = mapper(api.BatteryHold)
 	case batteryModeModified(batMode):
 		res = api.BatteryNormal
@@ -0,0 +1,234 @@
+package core
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/battery"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/tariff"
+)
+
+const (
+	batteryScheduleHorizon = 48 * time.Hour // max battery schedule duration
+	batteryChargeRate      = 0.5            // assumed grid charge rate in C
+	batteryEfficiency      = 0.9            // assumed charge efficiency
+)
+
+// batteryForecast creates the battery optimizer input from grid, feed-in and solar forecasts
+func (site *Site) batteryForecast() []battery.Forecast {
+	grid := tariff.Forecast(site.GetTariff(api.TariffUsageGrid))
+	if len(grid) == 0 {
+		return nil
+	}
+
+	feedin := site.GetTariff(api.TariffUsageFeedIn)
+	solar := timestampSeries(tariff.Forecast(site.GetTariff(api.TariffUsageSolar)))
+	homePower := site.householdPower()
+	learned := site.household.Learned()
+
+	now := time.Now()
+	end := now.Add(batteryScheduleHorizon)
+
+	var res []battery.Forecast
+	for _, r := range grid {
+		if !r.End.After(now) || r.Start.After(end) {
+			continue
+		}
+
+		f := battery.Forecast{
+			Start: r.Start,
+			End:   r.End,
+			Grid:  r.Value,
+			Solar: solar.energy(r.Start, r.End),
+			Load:  homePower * r.End.Sub(r.Start).Hours(),
+		}
+
+		// prefer learned household profile over current consumption
+		if learned {
+			f.Load = site.household.Energy(r.Start, r.End)
+		}
+
+		if rr, err := tariff.At(feedin, r.Start); err == nil {
+			f.FeedIn = rr.Value
+		}
+
+		res = append(res, f)
+	}
+
+	return res
+}
+
+// householdPower returns the expected household power
+func (site *Site) householdPower() float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.homePower
+}
+
+// batteryScheduleInputs returns the tariffs and forecasts the battery schedule depends on
+func (site *Site) batteryScheduleInputs() []api.Rates {
+	return []api.Rates{
+		tariff.Forecast(site.GetTariff(api.TariffUsageGrid)),
+		tariff.Forecast(site.GetTariff(api.TariffUsageFeedIn)),
+		tariff.Forecast(site.GetTariff(api.TariffUsageSolar)),
+	}
+}
+
+// invalidateBatterySchedule marks the battery schedule for optimization on next update
+func (site *Site) invalidateBatterySchedule() {
+	site.Lock()
+	defer site.Unlock()
+	site.batteryScheduleStale = true
+}
+
+// batteryScheduleValid checks if the battery schedule is still valid for the given tariffs and forecasts
+func (site *Site) batteryScheduleValid(rates []api.Rates) bool {
+	site.RLock()
+	defer site.RUnlock()
+
+	if site.batteryScheduleStale {
+		return false
+	}
+
+	if _, ok := site.batterySchedule.At(time.Now()); !ok {
+		return false
+	}
+
+	return slices.EqualFunc(rates, site.batteryScheduleRates, slices.Equal[api.Rates])
+}
+
+// updateBatterySchedule creates and publishes the battery schedule when tariffs or forecasts have changed
+func (site *Site) updateBatterySchedule() {
+	if !site.batteryConfigured() || !site.GetBatteryOptimizer() {
+		site.setBatterySchedule(nil)
+		return
+	}
+
+	rates := site.batteryScheduleInputs()
+	if site.batteryScheduleValid(rates) {
+		return
+	}
+
+	site.Lock()
+	site.batteryScheduleRates = rates
+	site.batteryScheduleStale = false
+	site.Unlock()
+
+	site.RLock()
+	b := battery.Battery{
+		Capacity:    site.batteryCapacity,
+		Soc:         site.batterySoc,
+		ChargePower: site.batteryCapacity * 1e3 * batteryChargeRate,
+		Efficiency:  batteryEfficiency,
+	}
+	overrides := maps.Clone(site.batteryScheduleOverrides)
+	site.RUnlock()
+
+	forecast := site.batteryForecast()
+
+	// remember learned household forecast to detect material changes
+	var load api.Rates
+	if site.household.Learned() {
+		for _, f := range forecast {
+			load = append(load, api.Rate{Start: f.Start, End: f.End, Value: f.Load})
+		}
+	}
+
+	site.Lock()
+	site.batteryScheduleLoad = load
+	site.Unlock()
+
+	schedule := battery.Optimize(b, forecast, overrides)
+	site.setBatterySchedule(schedule)
+}
+
+// setBatterySchedule sets and publishes the battery schedule, removing expired overrides
+func (site *Site) setBatterySchedule(schedule battery.Schedule) {
+	site.Lock()
+	defer site.Unlock()
+
+	for ts := range site.batteryScheduleOverrides {
+		if _, ok := schedule.At(ts); !ok {
+			delete(site.batteryScheduleOverrides, ts)
+		}
+	}
+
+	site.batterySchedule = schedule
+	site.publish(keys.BatterySchedule, schedule)
+}
+
+// batteryScheduleMode returns the scheduled battery mode
+func (site *Site) batteryScheduleMode() api.BatteryMode {
+	site.RLock()
+	defer site.RUnlock()
+
+	if slot, ok := site.batterySchedule.At(time.Now()); ok {
+		return slot.Mode
+	}
+
+	return api.BatteryUnknown
+}
+
+// GetBatterySchedule returns the battery schedule
+func (site *Site) GetBatterySchedule() battery.Schedule {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batterySchedule
+}
+
+// GetBatteryOptimizer returns if the battery optimizer is enabled
+func (site *Site) GetBatteryOptimizer() bool {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryOptimizer
+}
+
+// SetBatteryOptimizer enables the battery optimizer
+func (site *Site) SetBatteryOptimizer(val bool) error {
+	site.log.DEBUG.Println("set battery optimizer:", val)
+
+	site.Lock()
+	defer site.Unlock()
+
+	if len(site.batteryMeters) == 0 {
+		return ErrBatteryNotConfigured
+	}
+
+	if site.batteryOptimizer != val {
+		site.batteryOptimizer = val
+		site.batteryScheduleStale = true
+		settings.SetBool(keys.BatteryOptimizer, val)
+		site.publish(keys.BatteryOptimizer, val)
+	}
+
+	return nil
+}
+
+// SetBatteryScheduleOverride sets the battery mode of the schedule slot at the given time.
+// Unknown mode removes the override.
+func (site *Site) SetBatteryScheduleOverride(ts time.Time, mode api.BatteryMode) error {
+	site.log.DEBUG.Printf("set battery schedule override: %s at %v", mode, ts.Round(time.Second).Local())
+
+	site.Lock()
+	defer site.Unlock()
+
+	slot, ok := site.batterySchedule.At(ts)
+	if !ok {
+		return errors.New("no battery schedule slot at given time")
+	}
+
+	if mode == api.BatteryUnknown {
+		delete(site.batteryScheduleOverrides, slot.Start)
+	} else {
+		site.batteryScheduleOverrides[slot.Start] = mode
+	}
+
+	site.batteryScheduleStale = true
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/battery"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatteryScheduleValid(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+
+	rates := []api.Rates{{{Start: now, End: now.Add(time.Hour), Value: 0.3}}, nil, nil}
+
+	site := &Site{
+		log:                      util.NewLogger("foo"),
+		batterySchedule:          battery.Schedule{{Start: now, End: now.Add(time.Hour)}},
+		batteryScheduleRates:     rates,
+		batteryScheduleOverrides: make(map[time.Time]api.BatteryMode),
+	}
+
+	assert.True(t, site.batteryScheduleValid(rates))
+
+	// tariff changed
+	assert.False(t, site.batteryScheduleValid([]api.Rates{{{Start: now, End: now.Add(time.Hour), Value: 0.2}}, nil, nil}))
+
+	// override changed
+	assert.NoError(t, site.SetBatteryScheduleOverride(now, api.BatteryHold))
+	assert.False(t, site.batteryScheduleValid(rates))
+
+	// schedule expired
+	site.batteryScheduleStale = false
+	site.batterySchedule = battery.Schedule{{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)}}
+	assert.False(t, site.batteryScheduleValid(rates))
+}
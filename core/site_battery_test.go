@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/battery"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/stretchr/testify/assert"
@@ -115,3 +116,34 @@ func TestExternalBatteryModeChange(t *testing.T) {
 		assert.True(t, site.batteryModeExternalTimer.IsZero())
 	}
 }
+
+func TestRequiredScheduleBatteryMode(t *testing.T) {
+	for _, tc := range []struct {
+		internal, schedule, new api.BatteryMode
+	}{
+		{api.BatteryUnknown, api.BatteryNormal, api.BatteryUnknown},
+		{api.BatteryUnknown, api.BatteryHold, api.BatteryHold},
+		{api.BatteryUnknown, api.BatteryCharge, api.BatteryCharge},
+
+		{api.BatteryHold, api.BatteryNormal, api.BatteryNormal},
+		{api.BatteryHold, api.BatteryHold, api.BatteryUnknown}, // no change required
+		{api.BatteryHold, api.BatteryCharge, api.BatteryCharge},
+	} {
+		t.Logf("%+v", tc)
+
+		site := &Site{
+			log:           util.NewLogger("foo"),
+			batteryMeters: []config.Device[api.Meter]{nil},
+			batterySchedule: battery.Schedule{{
+				Start: time.Now().Add(-time.Hour),
+				End:   time.Now().Add(time.Hour),
+				Mode:  tc.schedule,
+			}},
+		}
+
+		site.batteryMode = tc.internal
+
+		mode := site.requiredBatteryMode(false, api.Rate{})
+		assert.Equal(t, tc.new.String(), mode.String(), "internal mode expected %s got %s", tc.new, mode)
+	}
+}
@@ -1,18 +1,114 @@
 package core
 
 import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/samber/lo"
 )
 
+// circuitStatsStruct avoids shadowing the site package by the site receiver
+type circuitStatsStruct = site.CircuitStats
+
+const (
+	circuitHistoryDays     = 400       // number of days circuit statistics are retained
+	circuitPersistInterval = time.Hour // circuit statistics persistence interval
+)
+
 type circuitStruct struct {
-	Title      string   `json:"title,omitempty"`
-	Icon       string   `json:"icon,omitempty"`
-	Power      float64  `json:"power"`
-	Current    *float64 `json:"current,omitempty"`
-	MaxPower   float64  `json:"maxPower,omitempty"`
-	MaxCurrent float64  `json:"maxCurrent,omitempty"`
+	Title       string        `json:"title,omitempty"`
+	Icon        string        `json:"icon,omitempty"`
+	Power       float64       `json:"power"`
+	Current     *float64      `json:"current,omitempty"`
+	MaxPower    float64       `json:"maxPower,omitempty"`
+	MaxCurrent  float64       `json:"maxCurrent,omitempty"`
+	Energy      float64       `json:"energy"`
+	PeakPower   float64       `json:"peakPower"`
+	PeakCurrent *float64      `json:"peakCurrent,omitempty"`
+	OverLimit   time.Duration `json:"overLimit"`
+}
+
+// circuitEnergy accumulates a circuit's energy and load statistics per day
+type circuitEnergy struct {
+	clock   clock.Clock
+	energy  *meterEnergy
+	updated time.Time
+	stats   site.CircuitStats
+}
+
+func newCircuitEnergy(clock clock.Clock, stats site.CircuitStats) *circuitEnergy {
+	res := &circuitEnergy{
+		clock:  clock,
+		energy: &meterEnergy{clock: clock},
+		stats:  stats,
+	}
+
+	res.energy.Accumulated = stats.Today.Energy
+
+	return res
+}
+
+// update adds the circuit's momentary power and current to today's statistics
+func (c *circuitEnergy) update(power, current, maxPower, maxCurrent float64) {
+	now := c.clock.Now()
+
+	// start new day
+	if today := beginningOfDay(now); !today.Equal(c.stats.Today.Day) {
+		if !c.stats.Today.Day.IsZero() {
+			c.stats.History = append(c.stats.History, c.stats.Today)
+			c.stats.History = c.stats.History[max(0, len(c.stats.History)-circuitHistoryDays):]
+		}
+
+		c.stats.Today = site.CircuitDay{Day: today}
+		c.energy.Accumulated = 0
+	}
+
+	c.energy.AddPower(max(0, power))
+
+	today := &c.stats.Today
+	today.Energy = c.energy.AccumulatedEnergy()
+	today.PeakPower = max(today.PeakPower, power)
+	today.PeakCurrent = max(today.PeakCurrent, current)
+
+	if overLimit := maxPower > 0 && power > maxPower || maxCurrent > 0 && current > maxCurrent; overLimit && !c.updated.IsZero() {
+		today.OverLimit += now.Sub(c.updated)
+	}
+
+	c.updated = now
+}
+
+// restoreCircuitStats restores persisted circuit statistics
+func (site *Site) restoreCircuitStats() {
+	var stats map[string]circuitStatsStruct
+	if err := settings.Json(keys.CircuitStats, &stats); err != nil {
+		return
+	}
+
+	for name, s := range stats {
+		site.circuitStats[name] = newCircuitEnergy(clock.New(), s)
+	}
+}
+
+// GetCircuitStats returns the energy and load statistics per circuit
+func (site *Site) GetCircuitStats() map[string]site.CircuitStats {
+	site.RLock()
+	defer site.RUnlock()
+
+	res := make(map[string]circuitStatsStruct, len(site.circuitStats))
+	for name, c := range site.circuitStats {
+		res[name] = circuitStatsStruct{
+			Today:   c.stats.Today,
+			History: slices.Clone(c.stats.History),
+		}
+	}
+
+	return res
 }
 
 // publishCircuits returns a list of circuit titles
@@ -20,9 +116,21 @@ func (site *Site) publishCircuits() {
 	cc := config.Circuits().Devices()
 	res := make(map[string]circuitStruct, len(cc))
 
+	site.Lock()
+	defer site.Unlock()
+
 	for _, c := range cc {
 		instance := c.Instance()
 		props := deviceProperties(c)
+		name := c.Config().Name
+
+		stats, ok := site.circuitStats[name]
+		if !ok {
+			stats = newCircuitEnergy(clock.New(), circuitStatsStruct{})
+			site.circuitStats[name] = stats
+		}
+
+		stats.update(instance.GetChargePower(), instance.GetMaxPhaseCurrent(), instance.GetMaxPower(), instance.GetMaxCurrent())
 
 		data := circuitStruct{
 			Title:      props.Title,
@@ -30,14 +138,41 @@ func (site *Site) publishCircuits() {
 			Power:      instance.GetChargePower(),
 			MaxPower:   instance.GetMaxPower(),
 			MaxCurrent: instance.GetMaxCurrent(),
+			Energy:     stats.stats.Today.Energy,
+			PeakPower:  stats.stats.Today.PeakPower,
+			OverLimit:  stats.stats.Today.OverLimit,
 		}
 
 		if instance.GetMaxCurrent() > 0 {
 			data.Current = lo.EmptyableToPtr(instance.GetMaxPhaseCurrent())
+			data.PeakCurrent = lo.EmptyableToPtr(stats.stats.Today.PeakCurrent)
 		}
 
-		res[c.Config().Name] = data
+		res[name] = data
+	}
+
+	// drop statistics of removed circuits
+	for _, name := range slices.Collect(maps.Keys(site.circuitStats)) {
+		if _, ok := res[name]; !ok {
+			delete(site.circuitStats, name)
+		}
 	}
 
+	site.persistCircuitStats()
 	site.publish(keys.Circuits, res)
 }
+
+// persistCircuitStats periodically persists the circuit statistics
+func (site *Site) persistCircuitStats() {
+	if time.Since(site.circuitStatsPersisted) < circuitPersistInterval {
+		return
+	}
+
+	site.circuitStatsPersisted = time.Now()
+
+	if err := settings.SetJson(keys.CircuitStats, lo.MapValues(site.circuitStats, func(c *circuitEnergy, _ string) circuitStatsStruct {
+		return c.stats
+	})); err != nil {
+		site.log.ERROR.Println("circuit stats:", err)
+	}
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitEnergy(t *testing.T) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 1, 1, 22, 0, 0, 0, time.Local))
+
+	c := newCircuitEnergy(clock, site.CircuitStats{})
+
+	c.update(10e3, 15, 11e3, 16)
+	assert.Equal(t, beginningOfDay(clock.Now()), c.stats.Today.Day)
+	assert.Equal(t, 0.0, c.stats.Today.Energy)
+
+	clock.Add(time.Hour)
+	c.update(12e3, 17, 11e3, 16)
+	assert.Equal(t, 12.0, c.stats.Today.Energy)
+	assert.Equal(t, 12e3, c.stats.Today.PeakPower)
+	assert.Equal(t, 17.0, c.stats.Today.PeakCurrent)
+	assert.Equal(t, time.Hour, c.stats.Today.OverLimit)
+
+	// next day
+	clock.Add(time.Hour)
+	c.update(5e3, 8, 11e3, 16)
+	assert.Len(t, c.stats.History, 1)
+	assert.Equal(t, 12.0, c.stats.History[0].Energy)
+	assert.Equal(t, 5.0, c.stats.Today.Energy)
+	assert.Equal(t, 5e3, c.stats.Today.PeakPower)
+	assert.Equal(t, time.Duration(0), c.stats.Today.OverLimit)
+
+	// restore
+	c = newCircuitEnergy(clock, c.stats)
+	c.update(0, 0, 0, 0)
+	clock.Add(time.Hour)
+	c.update(1e3, 2, 0, 0)
+	assert.Equal(t, 6.0, c.stats.Today.Energy)
+}
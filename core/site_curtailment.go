@@ -0,0 +1,163 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/samber/lo"
+)
+
+// curtailmentRefresh is the interval for re-applying an active limit. It must be shorter than
+// the inverters' revert timeout which returns them to full output if evcc stops.
+const curtailmentRefresh = time.Minute
+
+// curtailment is the pv curtailment controller state
+type curtailment struct {
+	initialized bool      // limit has been applied since startup
+	active      bool      // inverters are curtailed
+	percent     float64   // applied limit in % of nominal power
+	updated     time.Time // limit last applied
+	power       float64   // current power of curtailable inverters
+	potential   float64   // estimated uncurtailed power of curtailable inverters
+}
+
+// curtailers returns the curtailable pv inverters and their total nominal power
+func (site *Site) curtailers() ([]api.PVCurtailer, float64) {
+	var (
+		res     []api.PVCurtailer
+		nominal float64
+	)
+
+	for _, dev := range site.pvMeters {
+		meter := dev.Instance()
+
+		c, ok := meter.(api.PVCurtailer)
+		if !ok {
+			continue
+		}
+
+		if m, ok := meter.(api.MaxACPowerGetter); ok && m.MaxACPower() > 0 {
+			res = append(res, c)
+			nominal += m.MaxACPower()
+		}
+	}
+
+	return res, nominal
+}
+
+// feedInLimit returns the effective grid feed-in limit in W from static, external and price-based limits
+func (site *Site) feedInLimit() (float64, bool) {
+	limits := []*float64{site.FeedInLimit, site.GetFeedInLimitExternal()}
+
+	// don't pay for feeding into the grid
+	if site.NegativePrice.Curtail {
+		if v, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn)); err == nil && v < 0 {
+			limits = append(limits, lo.ToPtr(0.0))
+		}
+	}
+
+	var (
+		res float64
+		ok  bool
+	)
+
+	for _, l := range limits {
+		if l != nil && (!ok || *l < res) {
+			res, ok = max(0, *l), true
+		}
+	}
+
+	return res, ok
+}
+
+// updateCurtailment curtails pv production if grid feed-in exceeds the feed-in limit.
+// It returns the pv power withheld by curtailment. Offering this power to the loadpoints
+// lets loadpoints and battery absorb excess production before pv is curtailed any further.
+func (site *Site) updateCurtailment() float64 {
+	curtailers, nominal := site.curtailers()
+	if len(curtailers) == 0 {
+		return 0
+	}
+
+	limit, ok := site.feedInLimit()
+	site.publish(keys.FeedInLimit, lo.Ternary(ok, &limit, nil))
+
+	c := &site.curtailment
+
+	// track production while not restricted by the curtailment limit
+	if !c.active || c.power < 0.9*nominal*c.percent/100 {
+		c.potential = c.power
+	}
+
+	target := 100.0
+	if ok {
+		if excess := -site.gridPower - limit; c.active || excess > 0 {
+			target = min(100, max(0, math.Floor((c.power-excess)/nominal*100)))
+		}
+	}
+
+	switch {
+	case !c.initialized && target >= 100:
+		// inverters may still be curtailed from a previous run
+		site.log.DEBUG.Println("pv curtailment: reset")
+		c.active = false
+
+	case target >= 100 && c.active:
+		site.log.DEBUG.Println("pv curtailment: released")
+		c.active = false
+
+	case target < 100 && (!c.initialized || !c.active || target != c.percent):
+		site.log.DEBUG.Printf("pv curtailment: %.0f%% (feed-in limit %.0fW)", target, limit)
+		c.active = true
+
+	case c.active && time.Since(c.updated) >= curtailmentRefresh:
+		// keep inverters from reverting to full output
+
+	default:
+		return site.withheldPower()
+	}
+
+	c.percent = target
+	c.updated = time.Now()
+	c.initialized = site.curtail(curtailers, target) || c.initialized
+
+	site.publish(keys.PvCurtailment, target)
+
+	return site.withheldPower()
+}
+
+// curtail applies the limit to all inverters and returns true if successful
+func (site *Site) curtail(curtailers []api.PVCurtailer, percent float64) bool {
+	ok := true
+
+	for _, curtailer := range curtailers {
+		if err := curtailer.Curtail(percent); err != nil {
+			site.log.ERROR.Println("pv curtailment:", err)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+// releaseCurtailment returns curtailed inverters to full output
+func (site *Site) releaseCurtailment() {
+	if !site.curtailment.active {
+		return
+	}
+
+	if curtailers, _ := site.curtailers(); site.curtail(curtailers, 100) {
+		site.curtailment.active = false
+	}
+}
+
+// withheldPower returns the estimated pv power withheld by curtailment
+func (site *Site) withheldPower() float64 {
+	if c := site.curtailment; c.active {
+		return max(0, c.potential-c.power)
+	}
+	return 0
+}
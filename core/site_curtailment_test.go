@@ -0,0 +1,117 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+type curtailablePv struct {
+	api.Meter
+	limit float64
+}
+
+func (m *curtailablePv) Curtail(percent float64) error {
+	m.limit = percent
+	return nil
+}
+
+func (m *curtailablePv) MaxACPower() float64 {
+	return 10000
+}
+
+func TestFeedInLimit(t *testing.T) {
+	for _, tc := range []struct {
+		static, external *float64
+		limit            float64
+		ok               bool
+	}{
+		{nil, nil, 0, false},
+		{lo.ToPtr(7000.0), nil, 7000, true},
+		{nil, lo.ToPtr(4000.0), 4000, true},
+		{lo.ToPtr(7000.0), lo.ToPtr(4000.0), 4000, true},
+		{lo.ToPtr(3000.0), lo.ToPtr(4000.0), 3000, true},
+		{lo.ToPtr(-100.0), nil, 0, true},
+	} {
+		t.Logf("%+v", tc)
+
+		site := &Site{
+			log:                 util.NewLogger("foo"),
+			tariffs:             &tariff.Tariffs{},
+			FeedInLimit:         tc.static,
+			feedInLimitExternal: tc.external,
+		}
+
+		limit, ok := site.feedInLimit()
+		assert.Equal(t, tc.ok, ok)
+		assert.Equal(t, tc.limit, limit)
+	}
+}
+
+func TestCurtailment(t *testing.T) {
+	// inverter left curtailed by previous run
+	pv := &curtailablePv{limit: 0}
+
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		tariffs:     &tariff.Tariffs{},
+		pvMeters:    []config.Device[api.Meter]{config.NewStaticDevice(config.Named{}, api.Meter(pv))},
+		FeedInLimit: lo.ToPtr(5000.0),
+	}
+
+	// feed-in below limit, limit reset on startup
+	site.curtailment.power = 6000
+	site.gridPower = -4000
+	assert.Equal(t, 0.0, site.updateCurtailment())
+	assert.False(t, site.curtailment.active)
+	assert.Equal(t, 100.0, pv.limit)
+
+	// limit not written again
+	pv.limit = 0
+	assert.Equal(t, 0.0, site.updateCurtailment())
+	assert.Equal(t, 0.0, pv.limit)
+	pv.limit = 100
+
+	// feed-in exceeds limit by 2kW
+	site.curtailment.power = 8000
+	site.gridPower = -7000
+	assert.Equal(t, 0.0, site.updateCurtailment())
+	assert.True(t, site.curtailment.active)
+	assert.Equal(t, 60.0, pv.limit)
+
+	// curtailed production is offered for absorption
+	site.curtailment.power = 6000
+	site.gridPower = -5000
+	assert.Equal(t, 2000.0, site.updateCurtailment())
+	assert.True(t, site.curtailment.active)
+	assert.Equal(t, 60.0, pv.limit)
+
+	// active limit refreshed before inverter reverts
+	pv.limit = 100
+	site.curtailment.updated = time.Now().Add(-curtailmentRefresh)
+	assert.Equal(t, 2000.0, site.updateCurtailment())
+	assert.Equal(t, 60.0, pv.limit)
+
+	// excess absorbed by loadpoints, curtailment released
+	site.curtailment.power = 6000
+	site.gridPower = 0
+	assert.Equal(t, 0.0, site.updateCurtailment())
+	assert.False(t, site.curtailment.active)
+	assert.Equal(t, 100.0, pv.limit)
+
+	// released on shutdown
+	site.gridPower = -7000
+	site.curtailment.power = 8000
+	site.updateCurtailment()
+	assert.Equal(t, 60.0, pv.limit)
+
+	site.releaseCurtailment()
+	assert.False(t, site.curtailment.active)
+	assert.Equal(t, 100.0, pv.limit)
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/household"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/samber/lo"
+)
+
+const (
+	householdForecastHorizon = 72 * time.Hour // household forecast duration
+	householdPersistInterval = time.Hour      // household profile persistence interval
+	householdForecastChange  = 0.1            // relative household forecast change requiring battery schedule optimization
+)
+
+// updateHousehold adds the current household power to the load profile and periodically persists the profile when changed
+func (site *Site) updateHousehold(homePower float64) {
+	if !site.household.Add(homePower) {
+		return
+	}
+
+	// household forecast has changed materially
+	if site.householdForecastChanged() {
+		site.invalidateBatterySchedule()
+	}
+
+	if time.Since(site.householdPersisted) < householdPersistInterval {
+		return
+	}
+
+	site.householdPersisted = time.Now()
+
+	if err := settings.SetJson(keys.HouseholdProfile, site.household.Profile()); err != nil {
+		site.log.ERROR.Println("household profile:", err)
+	}
+}
+
+// householdForecastChanged checks if the learned household forecast deviates materially from the forecast
+// the battery schedule has been optimized for
+func (site *Site) householdForecastChanged() bool {
+	if !site.household.Learned() {
+		return false
+	}
+
+	site.RLock()
+	load := site.batteryScheduleLoad
+	site.RUnlock()
+
+	// schedule not optimized for learned profile yet
+	if len(load) == 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	var total, deviation float64
+	for _, r := range load {
+		if !r.End.After(now) {
+			continue
+		}
+
+		total += r.Value
+		deviation += math.Abs(site.household.Energy(r.Start, r.End) - r.Value)
+	}
+
+	return deviation > householdForecastChange*total
+}
+
+// householdForecast returns the expected household power. The current household power is used until a profile has been learned.
+func (site *Site) householdForecast() api.Rates {
+	now := time.Now()
+
+	if site.household.Learned() {
+		return site.household.Forecast(now, now.Add(householdForecastHorizon))
+	}
+
+	power := site.householdPower()
+
+	var res api.Rates
+	for ts := now.Truncate(household.SlotDuration); ts.Before(now.Add(householdForecastHorizon)); ts = ts.Add(household.SlotDuration) {
+		res = append(res, api.Rate{
+			Start: ts,
+			End:   ts.Add(household.SlotDuration),
+			Value: power,
+		})
+	}
+
+	return res
+}
+
+// surplusForecast returns the expected solar surplus power, i.e. the solar forecast reduced by the household forecast.
+// Slots beyond the solar forecast are omitted.
+func surplusForecast(solar timeseries, household api.Rates) api.Rates {
+	if len(solar) == 0 {
+		return nil
+	}
+
+	last := solar[len(solar)-1].Timestamp
+
+	var res api.Rates
+	for _, r := range household {
+		if r.End.After(last) {
+			break
+		}
+
+		power := solar.energy(r.Start, r.End) / r.End.Sub(r.Start).Hours()
+
+		res = append(res, api.Rate{
+			Start: r.Start,
+			End:   r.End,
+			Value: max(0, power-r.Value),
+		})
+	}
+
+	return res
+}
+
+// solarPlanning returns true if the loadpoint expects solar surplus to cover its plan
+func solarPlanning(lp loadpoint.API) bool {
+	mode := lp.GetMode()
+	status := lp.GetStatus()
+	return (mode == api.ModePV || mode == api.ModeMinPV) && (status == api.StatusB || status == api.StatusC) && !lp.EffectivePlanTime().IsZero()
+}
+
+// surplusShare returns the loadpoint's share of the solar surplus. Loadpoints with higher priority are served up to their
+// max power first, the remaining surplus is split equally between loadpoints of equal priority.
+func surplusShare(surplus api.Rates, lp loadpoint.API, others []loadpoint.API) api.Rates {
+	var reserved float64
+	shares := 1
+
+	prio := lp.EffectivePriority()
+	for _, other := range others {
+		switch p := other.EffectivePriority(); {
+		case p > prio:
+			reserved += other.EffectiveMaxPower()
+		case p == prio:
+			shares++
+		}
+	}
+
+	return lo.Map(surplus, func(r api.Rate, _ int) api.Rate {
+		r.Value = max(0, r.Value-reserved) / float64(shares)
+		return r
+	})
+}
+
+// GetSurplusForecast returns the loadpoint's share of the expected solar surplus power
+func (site *Site) GetSurplusForecast(lp loadpoint.API) api.Rates {
+	surplus := surplusForecast(timestampSeries(tariff.Forecast(site.GetTariff(api.TariffUsageSolar))), site.householdForecast())
+
+	// other loadpoints planning with solar surplus
+	others := lo.Filter(site.Loadpoints(), func(other loadpoint.API, _ int) bool {
+		return other != lp && solarPlanning(other)
+	})
+
+	return surplusShare(surplus, lp, others)
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/household"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSurplusForecast(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+
+	solar := timeseries{
+		{Timestamp: now, Value: 1000},
+		{Timestamp: now.Add(time.Hour), Value: 1000},
+		{Timestamp: now.Add(2 * time.Hour), Value: 1000},
+	}
+
+	household := api.Rates{
+		{Start: now, End: now.Add(time.Hour), Value: 400},
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Value: 1500},
+		{Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Value: 400},
+	}
+
+	assert.Equal(t, api.Rates{
+		{Start: now, End: now.Add(time.Hour), Value: 600},
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Value: 0},
+	}, surplusForecast(solar, household))
+
+	assert.Nil(t, surplusForecast(nil, household))
+}
+
+func TestSurplusShare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().Truncate(time.Hour)
+	surplus := api.Rates{{Start: now, End: now.Add(time.Hour), Value: 10000}}
+
+	newLoadpoint := func(prio int, maxPower float64) loadpoint.API {
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().EffectivePriority().Return(prio).AnyTimes()
+		lp.EXPECT().EffectiveMaxPower().Return(maxPower).AnyTimes()
+		return lp
+	}
+
+	lp := newLoadpoint(1, 11000)
+
+	for _, tc := range []struct {
+		others   []loadpoint.API
+		expected float64
+	}{
+		{nil, 10000},
+		{[]loadpoint.API{newLoadpoint(1, 11000)}, 5000},                        // equal priority
+		{[]loadpoint.API{newLoadpoint(2, 4000)}, 6000},                         // higher priority first
+		{[]loadpoint.API{newLoadpoint(2, 11000)}, 0},                           // higher priority claims all
+		{[]loadpoint.API{newLoadpoint(0, 11000)}, 10000},                       // lower priority
+		{[]loadpoint.API{newLoadpoint(2, 4000), newLoadpoint(1, 11000)}, 3000}, // both
+	} {
+		assert.Equal(t, tc.expected, surplusShare(surplus, lp, tc.others)[0].Value)
+	}
+}
+
+func TestHouseholdForecastChanged(t *testing.T) {
+	site := &Site{
+		log:       util.NewLogger("foo"),
+		household: household.New(clock.New()),
+	}
+
+	// profile not learned
+	assert.False(t, site.householdForecastChanged())
+
+	profile := func(power float64) household.Profile {
+		var p household.Profile
+		for range 7 * 24 * 4 {
+			p.Power = append(p.Power, power)
+			p.Samples = append(p.Samples, 1)
+		}
+		return p
+	}
+
+	require.NoError(t, site.household.Load(profile(500)))
+
+	// schedule not optimized for learned profile
+	assert.True(t, site.householdForecastChanged())
+
+	now := time.Now().Truncate(time.Hour)
+	for ts := now.Add(time.Hour); ts.Before(now.Add(4 * time.Hour)); ts = ts.Add(time.Hour) {
+		site.batteryScheduleLoad = append(site.batteryScheduleLoad, api.Rate{Start: ts, End: ts.Add(time.Hour), Value: 500})
+	}
+
+	assert.False(t, site.householdForecastChanged())
+
+	// minor change
+	require.NoError(t, site.household.Load(profile(520)))
+	assert.False(t, site.householdForecastChanged())
+
+	// material change
+	require.NoError(t, site.household.Load(profile(600)))
+	assert.True(t, site.householdForecastChanged())
+}
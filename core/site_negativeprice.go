@@ -0,0 +1,68 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/tariff"
+)
+
+// NegativePriceConfig is the site policy for negative grid and feed-in prices
+type NegativePriceConfig struct {
+	FeedIn  bool `mapstructure:"feedIn"`  // avoid export by charging battery and loadpoints at negative feed-in price
+	Curtail bool `mapstructure:"curtail"` // curtail pv at negative feed-in price
+	Grid    bool `mapstructure:"grid"`    // draw from grid by charging battery and loadpoints at negative grid price
+}
+
+// negativePriceStruct is the negative price policy state. A negative price denotes an active decision,
+// zero is published while inactive.
+type negativePriceStruct struct {
+	FeedIn float64 `json:"feedIn"`
+	Grid   float64 `json:"grid"`
+}
+
+// feedInActive returns true if pv export is to be absorbed
+func (n negativePriceStruct) feedInActive() bool {
+	return n.FeedIn < 0
+}
+
+// gridActive returns true if max power is to be drawn from the grid
+func (n negativePriceStruct) gridActive() bool {
+	return n.Grid < 0
+}
+
+// active returns true if any negative price decision is active
+func (n negativePriceStruct) active() bool {
+	return n.feedInActive() || n.gridActive()
+}
+
+// negativeTariffPrice returns the current tariff price if it is negative or zero otherwise
+func (site *Site) negativeTariffPrice(usage api.TariffUsage) float64 {
+	if v, err := tariff.Now(site.GetTariff(usage)); err == nil && v < 0 {
+		return v
+	}
+	return 0
+}
+
+// updateNegativePrice evaluates the negative price policy and passes the decision to the loadpoints
+func (site *Site) updateNegativePrice() {
+	var res negativePriceStruct
+
+	if site.NegativePrice.FeedIn {
+		res.FeedIn = site.negativeTariffPrice(api.TariffUsageFeedIn)
+	}
+
+	if site.NegativePrice.Grid {
+		res.Grid = site.negativeTariffPrice(api.TariffUsageGrid)
+	}
+
+	if res.active() != site.negativePrice.active() {
+		site.log.DEBUG.Printf("negative price: active %t", res.active())
+	}
+
+	site.negativePrice = res
+	site.publish(keys.NegativePrice, res)
+
+	for _, lp := range site.loadpoints {
+		lp.setNegativePrice(res)
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func negativePriceTariff(ctrl *gomock.Controller, price float64) api.Tariff {
+	now := time.Now()
+
+	t := api.NewMockTariff(ctrl)
+	t.EXPECT().Rates().Return(api.Rates{{Start: now.Add(-time.Hour), End: now.Add(time.Hour), Value: price}}, nil).AnyTimes()
+
+	return t
+}
+
+func TestNegativePrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bat := &struct {
+		*api.MockMeter
+		*api.MockBattery
+		*api.MockBatteryController
+	}{
+		api.NewMockMeter(ctrl),
+		api.NewMockBattery(ctrl),
+		api.NewMockBatteryController(ctrl),
+	}
+
+	for _, tc := range []struct {
+		policy            NegativePriceConfig
+		feedIn, grid      float64
+		active            bool
+		batMode           api.BatteryMode
+		feedInLimitActive bool
+	}{
+		{NegativePriceConfig{}, -0.1, -0.1, false, api.BatteryUnknown, false},
+		{NegativePriceConfig{FeedIn: true, Grid: true}, 0.1, 0.3, false, api.BatteryUnknown, false},
+		{NegativePriceConfig{FeedIn: true}, -0.1, 0.3, true, api.BatteryHold, false},
+		{NegativePriceConfig{Grid: true}, -0.1, -0.05, true, api.BatteryCharge, false},
+		{NegativePriceConfig{FeedIn: true, Grid: true}, -0.1, -0.05, true, api.BatteryCharge, false},
+		{NegativePriceConfig{Curtail: true}, -0.1, 0.3, false, api.BatteryUnknown, true},
+	} {
+		t.Logf("%+v", tc)
+
+		lp := NewLoadpoint(util.NewLogger("foo"), nil)
+
+		site := NewSite()
+		site.batteryMeters = []config.Device[api.Meter]{config.NewStaticDevice(config.Named{Name: "battery"}, api.Meter(bat))}
+		site.loadpoints = []*Loadpoint{lp}
+		site.tariffs = &tariff.Tariffs{
+			FeedIn: negativePriceTariff(ctrl, tc.feedIn),
+			Grid:   negativePriceTariff(ctrl, tc.grid),
+		}
+		site.NegativePrice = tc.policy
+
+		site.updateNegativePrice()
+		assert.Equal(t, tc.active, lp.getNegativePrice().active())
+
+		mode := site.requiredBatteryMode(false, api.Rate{})
+		assert.Equal(t, tc.batMode.String(), mode.String())
+
+		_, ok := site.feedInLimit()
+		assert.Equal(t, tc.feedInLimitActive, ok)
+	}
+}
+
+func TestNegativeFeedInSurplusCurrent(t *testing.T) {
+	Voltage = 230 // V
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.status = api.StatusC
+	lp.phases = 3
+	lp.minCurrent = 6
+	lp.maxCurrent = 16
+	lp.offeredCurrent = 6
+
+	// charge from pv export only
+	assert.Equal(t, 10.0, lp.surplusCurrent(-4*3*Voltage))
+
+	// grid import reduces current
+	assert.Equal(t, 4.0, lp.surplusCurrent(2*3*Voltage))
+
+	// capped at max current
+	assert.Equal(t, 16.0, lp.surplusCurrent(-20*3*Voltage))
+}
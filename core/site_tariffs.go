@@ -30,8 +30,19 @@ type dailyDetails struct {
 //   - the current green share, calculated for the part of the consumption between powerFrom and powerTo
 //     the consumption below powerFrom will get the available green power first
 func (site *Site) greenShare(powerFrom float64, powerTo float64) float64 {
-	greenPower := math.Max(0, site.pvPower) + math.Max(0, site.batteryPower)
-	greenPowerAvailable := math.Max(0, greenPower-powerFrom)
+	return powerShare(math.Max(0, site.pvPower)+math.Max(0, site.batteryPower), powerFrom, powerTo)
+}
+
+// batteryShare returns
+//   - the current battery share, calculated for the part of the consumption between powerFrom and powerTo
+//     solar power is assumed to be consumed before battery power
+func (site *Site) batteryShare(powerFrom float64, powerTo float64) float64 {
+	return site.greenShare(powerFrom, powerTo) - powerShare(math.Max(0, site.pvPower), powerFrom, powerTo)
+}
+
+// powerShare returns the share of the consumption between powerFrom and powerTo covered by the available power
+func powerShare(available float64, powerFrom float64, powerTo float64) float64 {
+	greenPowerAvailable := math.Max(0, available-powerFrom)
 
 	power := powerTo - powerFrom
 	share := math.Min(greenPowerAvailable, power) / power
@@ -99,21 +110,25 @@ func (site *Site) publishTariffs(greenShareHome float64, greenShareLoadpoints fl
 	}
 
 	fc := struct {
-		Co2     api.Rates     `json:"co2,omitempty"`
-		FeedIn  api.Rates     `json:"feedin,omitempty"`
-		Grid    api.Rates     `json:"grid,omitempty"`
-		Planner api.Rates     `json:"planner,omitempty"`
-		Solar   *solarDetails `json:"solar,omitempty"`
+		Co2       api.Rates     `json:"co2,omitempty"`
+		FeedIn    api.Rates     `json:"feedin,omitempty"`
+		Grid      api.Rates     `json:"grid,omitempty"`
+		Planner   api.Rates     `json:"planner,omitempty"`
+		Solar     *solarDetails `json:"solar,omitempty"`
+		Household api.Rates     `json:"household,omitempty"`
+		Surplus   api.Rates     `json:"surplus,omitempty"`
 	}{
-		Co2:     tariff.Forecast(site.GetTariff(api.TariffUsageCo2)),
-		FeedIn:  tariff.Forecast(site.GetTariff(api.TariffUsageFeedIn)),
-		Planner: tariff.Forecast(site.GetTariff(api.TariffUsagePlanner)),
-		Grid:    tariff.Forecast(site.GetTariff(api.TariffUsageGrid)),
+		Co2:       tariff.Forecast(site.GetTariff(api.TariffUsageCo2)),
+		FeedIn:    tariff.Forecast(site.GetTariff(api.TariffUsageFeedIn)),
+		Planner:   tariff.Forecast(site.GetTariff(api.TariffUsagePlanner)),
+		Grid:      tariff.Forecast(site.GetTariff(api.TariffUsageGrid)),
+		Household: site.householdForecast(),
 	}
 
 	// calculate adjusted solar forecast
 	if solar := timestampSeries(tariff.Forecast(site.GetTariff(api.TariffUsageSolar))); len(solar) > 0 {
 		fc.Solar = lo.ToPtr(site.solarDetails(solar))
+		fc.Surplus = surplusForecast(solar, fc.Household)
 	}
 
 	site.publish(keys.Forecast, fc)
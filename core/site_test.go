@@ -155,3 +155,28 @@ func TestRequiredBatteryMode(t *testing.T) {
 		assert.Equal(t, tc.res, res, "expected %s, got %s", tc.res, res)
 	}
 }
+
+func TestBatteryShare(t *testing.T) {
+	tc := []struct {
+		title                 string
+		pv, battery, from, to float64
+		batteryShare          float64
+	}{
+		{"no battery", 1000, 0, 0, 1000, 0},
+		{"battery only", 0, 1000, 0, 1000, 1},
+		{"pv consumed first", 1000, 1000, 500, 1500, 0.5},
+		{"battery charging", 1000, -1000, 0, 1000, 0},
+		{"half grid", 0, 500, 0, 1000, 0.5},
+	}
+
+	for _, tc := range tc {
+		t.Log(tc.title)
+
+		site := &Site{
+			pvPower:      tc.pv,
+			batteryPower: tc.battery,
+		}
+
+		assert.InDelta(t, tc.batteryShare, site.batteryShare(tc.from, tc.to), 1e-6)
+	}
+}
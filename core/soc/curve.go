@@ -0,0 +1,90 @@
+package soc
+
+import (
+	"math"
+	"slices"
+)
+
+// curveBands is the number of soc bands of the charge curve, i.e. 5% per band
+const curveBands = 20
+
+// Curve is the learned charge characteristic of a vehicle
+type Curve struct {
+	Power      []float64 `json:"power"`      // max AC charge power per soc band in W, zero if unknown
+	Efficiency float64   `json:"efficiency"` // AC to battery charge efficiency, zero if unknown
+}
+
+// Known returns true if any charge power has been learned
+func (c Curve) Known() bool {
+	return slices.ContainsFunc(c.Power, func(p float64) bool { return p > 0 })
+}
+
+// band returns the soc band for given soc
+func band(soc float64) int {
+	return min(max(int(soc/(100/curveBands)), 0), curveBands-1)
+}
+
+// power returns the learned charge power at given soc
+func (c Curve) power(soc float64) float64 {
+	if b := band(soc); b < len(c.Power) {
+		return c.Power[b]
+	}
+	return 0
+}
+
+// Merge merges a session's observations into the curve. Higher charge power is adopted immediately
+// while lower charge power, e.g. limited by pv, only slowly reduces the learned power.
+func (c Curve) Merge(session Curve) Curve {
+	res := Curve{
+		Power:      make([]float64, curveBands),
+		Efficiency: c.Efficiency,
+	}
+
+	for b := range res.Power {
+		old, new := c.power(float64(b)*100/curveBands), session.power(float64(b)*100/curveBands)
+
+		switch {
+		case new == 0:
+			res.Power[b] = old
+		case old == 0:
+			res.Power[b] = new
+		default:
+			res.Power[b] = max(new, 0.9*old+0.1*new)
+		}
+	}
+
+	switch {
+	case session.Efficiency == 0:
+	case c.Efficiency == 0:
+		res.Efficiency = session.Efficiency
+	default:
+		res.Efficiency = 0.7*c.Efficiency + 0.3*session.Efficiency
+	}
+
+	return res
+}
+
+// duration returns the charge duration in hours from soc to target soc. The charge power is limited by the
+// learned curve or, for unknown soc bands, by the given fallback power function.
+func (c Curve) duration(soc, targetSoc, capacity, chargePower float64, fallback func(soc float64) float64) float64 {
+	var res float64
+
+	for soc < targetSoc {
+		// integrate up to the end of the soc band
+		next := min(targetSoc, math.Floor(soc/(100/curveBands)+1)*(100/curveBands))
+
+		power := c.power(soc)
+		if power == 0 {
+			power = fallback((soc + next) / 2)
+		}
+
+		if power = min(power, chargePower); power <= 0 {
+			return math.Inf(1)
+		}
+
+		res += (next - soc) / 100 * capacity / power
+		soc = next
+	}
+
+	return res
+}
@@ -0,0 +1,88 @@
+package soc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurveMerge(t *testing.T) {
+	old := Curve{Power: make([]float64, curveBands), Efficiency: 0.9}
+	old.Power[0] = 10000
+	old.Power[1] = 10000
+
+	session := Curve{Power: make([]float64, curveBands), Efficiency: 0.8}
+	session.Power[1] = 5000  // pv limited, only slowly reduced
+	session.Power[2] = 11000 // unknown band adopted
+
+	res := old.Merge(session)
+	assert.Equal(t, 10000.0, res.Power[0])
+	assert.Equal(t, 9500.0, res.Power[1])
+	assert.Equal(t, 11000.0, res.Power[2])
+	assert.InDelta(t, 0.87, res.Efficiency, 1e-6)
+
+	// higher power adopted immediately
+	session.Power[1] = 12000
+	assert.Equal(t, 12000.0, old.Merge(session).Power[1])
+
+	// empty session keeps curve
+	assert.Equal(t, old, old.Merge(Curve{}))
+}
+
+func TestCurveRemainingChargeDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	vehicle := api.NewMockVehicle(ctrl)
+	// 9 kWh userBatCap => 10 kWh virtualBatCap
+	vehicle.EXPECT().Capacity().Return(float64(9)).AnyTimes()
+
+	ce := NewEstimator(util.NewLogger("foo"), charger, vehicle, false)
+	ce.vehicleSoc = 20.0
+
+	// taper above 80%
+	curve := Curve{Power: make([]float64, curveBands)}
+	for b := range curve.Power {
+		curve.Power[b] = 11000
+	}
+	curve.Power[16], curve.Power[17] = 5000, 5000
+	curve.Power[18], curve.Power[19] = 2500, 2500
+
+	ce.SetCurve(curve)
+
+	assert.Equal(t, 1*time.Hour+8*time.Minute+44*time.Second, ce.RemainingChargeDuration(100, 11000))
+	assert.Equal(t, 2*time.Hour+44*time.Minute, ce.RemainingChargeDuration(100, 3000))
+
+	// learned efficiency determines virtual capacity
+	ce.SetCurve(Curve{Power: curve.Power, Efficiency: 0.75})
+	assert.Equal(t, 12000.0, ce.virtualCapacity)
+	assert.Equal(t, 9.6, ce.RemainingChargeEnergy(100))
+}
+
+func TestCurveLearning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	vehicle := api.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Capacity().Return(float64(9)).AnyTimes()
+
+	ce := NewEstimator(util.NewLogger("foo"), charger, vehicle, false)
+	assert.False(t, ce.Learned())
+
+	// no soc yet
+	ce.AddChargePower(11000)
+	assert.False(t, ce.Learned())
+
+	ce.vehicleSoc = 81
+	ce.AddChargePower(6000)
+	ce.AddChargePower(5000)
+	assert.True(t, ce.Learned())
+
+	assert.Equal(t, 6000.0, ce.Curve().Power[16])
+
+	// session is cleared on reset
+	ce.Reset()
+	assert.False(t, ce.Learned())
+}
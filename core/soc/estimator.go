@@ -2,6 +2,7 @@ package soc
 
 import (
 	"errors"
+	"math"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -30,6 +31,9 @@ type Estimator struct {
 	minChargePower    float64 // Lowest charge power (just before vehicle stops charging at 100%)
 	maxChargePower    float64 // Highest charge power the battery can handle on any charger
 	maxChargeSoc      float64 // SoC at/after which maxChargePower is degressive
+	curve             Curve   // learned charge curve
+	session           Curve   // charge curve observed during current session
+	fetched           bool    // soc has been fetched without error during last update
 }
 
 // NewEstimator creates new estimator
@@ -51,18 +55,78 @@ func (s *Estimator) Reset() {
 	s.prevSoc = 0
 	s.prevChargedEnergy = 0
 	s.initialSoc = 0
-	s.capacity = s.vehicle.Capacity() * 1e3           // cache to simplify debugging
-	s.virtualCapacity = s.capacity / ChargeEfficiency // initial capacity taking efficiency into account
+	s.capacity = s.vehicle.Capacity() * 1e3               // cache to simplify debugging
+	s.virtualCapacity = s.capacity / s.chargeEfficiency() // initial capacity taking efficiency into account
 	s.energyPerSocStep = s.virtualCapacity / 100
 	s.minChargePower = 1000  // default 1 kW
 	s.maxChargePower = 50000 // default 50 kW
 	s.maxChargeSoc = 50      // default 50%
+	s.session = Curve{Power: make([]float64, curveBands)}
+}
+
+// chargeEfficiency returns the learned charge efficiency or the default
+func (s *Estimator) chargeEfficiency() float64 {
+	if s.curve.Efficiency > 0 {
+		return s.curve.Efficiency
+	}
+	return ChargeEfficiency
+}
+
+// SetCurve sets the vehicle's learned charge curve
+func (s *Estimator) SetCurve(curve Curve) {
+	s.curve = curve
+
+	// apply learned efficiency unless the gradient has already been measured
+	if s.initialSoc == 0 {
+		s.virtualCapacity = s.capacity / s.chargeEfficiency()
+		s.energyPerSocStep = s.virtualCapacity / 100
+	}
+}
+
+// Curve returns the learned charge curve including the current session's observations
+func (s *Estimator) Curve() Curve {
+	return s.curve.Merge(s.session)
+}
+
+// Learned returns true if the current session provided charge curve observations
+func (s *Estimator) Learned() bool {
+	return s.session.Known() || s.session.Efficiency > 0
+}
+
+// AddChargePower records the charge power at the current vehicle soc
+func (s *Estimator) AddChargePower(power float64) {
+	if s.vehicleSoc <= 0 || power <= 0 || len(s.session.Power) != curveBands {
+		return
+	}
+
+	b := band(s.vehicleSoc)
+	s.session.Power[b] = max(s.session.Power[b], power)
+}
+
+// defaultChargePower returns the charge power at given soc assuming degressive charging above maxChargeSoc
+func (s *Estimator) defaultChargePower(soc float64) float64 {
+	const minChargeSoc = 100
+
+	if soc <= s.maxChargeSoc {
+		return s.maxChargePower
+	}
+
+	return s.maxChargePower + (soc-s.maxChargeSoc)*(s.minChargePower-s.maxChargePower)/(minChargeSoc-s.maxChargeSoc)
 }
 
 // RemainingChargeDuration returns the estimated remaining duration
 func (s *Estimator) RemainingChargeDuration(targetSoc int, chargePower float64) time.Duration {
 	const minChargeSoc = 100
 
+	// use learned charge curve where available
+	if s.curve.Known() {
+		d := s.curve.duration(s.vehicleSoc, float64(targetSoc), s.virtualCapacity, chargePower, s.defaultChargePower)
+		if math.IsInf(d, 1) {
+			return 0
+		}
+		return max(0, time.Duration(float64(time.Hour)*d)).Round(time.Second)
+	}
+
 	dy := s.minChargePower - s.maxChargePower
 	dx := minChargeSoc - s.maxChargeSoc
 
@@ -103,9 +167,15 @@ func (s *Estimator) RemainingChargeEnergy(targetSoc int) float64 {
 	return whRemaining / 1e3
 }
 
+// Fetched returns true if the last soc update was fetched from charger or vehicle without error
+func (s *Estimator) Fetched() bool {
+	return s.fetched
+}
+
 // Soc replaces the api.Vehicle.Soc interface to take charged energy into account
 func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 	var fetchedSoc *float64
+	s.fetched = false
 
 	if charger, ok := s.charger.(api.Battery); ok {
 		f, err := Guard(charger.Soc())
@@ -121,6 +191,8 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 				// recover from temporary api errors
 				f = s.prevSoc
 				s.log.WARN.Printf("vehicle soc (charger): %v (ignored by estimator)", err)
+			} else {
+				s.fetched = true
 			}
 
 			fetchedSoc = &f
@@ -144,6 +216,8 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 			// recover from temporary api errors
 			f = s.prevSoc
 			s.log.WARN.Printf("vehicle soc: %v (ignored by estimator)", err)
+		} else {
+			s.fetched = true
 		}
 
 		fetchedSoc = &f
@@ -186,6 +260,11 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 					s.energyPerSocStep = energyDiff / socDiff
 					s.virtualCapacity = s.energyPerSocStep * 100
 					s.log.DEBUG.Printf("soc gradient updated: soc: %.1f%%, socDiff: %.1f%%, energyDiff: %.0fWh, energyPerSocStep: %.1fWh, virtualCapacity: %.0fWh", s.vehicleSoc, socDiff, energyDiff, s.energyPerSocStep, s.virtualCapacity)
+
+					// learn charge efficiency, ignoring implausible values
+					if eff := s.capacity / s.virtualCapacity; eff >= 0.5 && eff <= 1 {
+						s.session.Efficiency = eff
+					}
 				}
 			}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util"
 )
@@ -144,3 +145,32 @@ func (v *adapter) GetRepeatingPlans() []api.RepeatingPlanStruct {
 
 	return []api.RepeatingPlanStruct{}
 }
+
+// GetChargeCurve returns the learned charge curve
+func (v *adapter) GetChargeCurve() soc.Curve {
+	var res soc.Curve
+	if err := settings.Json(v.key()+keys.ChargeCurve, &res); err != nil {
+		return soc.Curve{}
+	}
+	return res
+}
+
+// SetChargeCurve stores the learned charge curve
+func (v *adapter) SetChargeCurve(curve soc.Curve) error {
+	v.log.DEBUG.Printf("update %s charge curve: %v (efficiency %.2f)", v.name, curve.Power, curve.Efficiency)
+	return settings.SetJson(v.key()+keys.ChargeCurve, curve)
+}
+
+// GetSnapshot returns the last known vehicle data
+func (v *adapter) GetSnapshot() Snapshot {
+	var res Snapshot
+	if err := settings.Json(v.key()+keys.Snapshot, &res); err != nil {
+		return Snapshot{}
+	}
+	return res
+}
+
+// SetSnapshot stores the last known vehicle data
+func (v *adapter) SetSnapshot(snapshot Snapshot) error {
+	return settings.SetJson(v.key()+keys.Snapshot, snapshot)
+}
@@ -4,8 +4,18 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/soc"
 )
 
+// Snapshot is the last known vehicle data
+type Snapshot struct {
+	Soc      float64   `json:"soc"`
+	Range    int64     `json:"range,omitempty"`
+	LimitSoc int       `json:"limitSoc,omitempty"`
+	Odometer float64   `json:"odometer,omitempty"`
+	Updated  time.Time `json:"updated"` // soc fetched from vehicle api
+}
+
 //go:generate go tool mockgen -package vehicle -destination mock.go -mock_names API=MockAPI github.com/evcc-io/evcc/core/vehicle API
 
 type API interface {
@@ -48,6 +58,16 @@ type API interface {
 	// SetRepeatingPlans stores every repeating plan
 	SetRepeatingPlans([]api.RepeatingPlanStruct) error
 
+	// GetChargeCurve returns the learned charge curve
+	GetChargeCurve() soc.Curve
+	// SetChargeCurve stores the learned charge curve
+	SetChargeCurve(soc.Curve) error
+
+	// GetSnapshot returns the last known vehicle data
+	GetSnapshot() Snapshot
+	// SetSnapshot stores the last known vehicle data
+	SetSnapshot(Snapshot) error
+
 	// // GetMinCurrent returns the min charging current
 	// GetMinCurrent() float64
 	// // SetMinCurrent sets the min charging current
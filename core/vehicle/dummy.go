@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/soc"
 )
 
 var _ API = (*dummy)(nil)
@@ -56,3 +57,23 @@ func (v *dummy) SetRepeatingPlans(plans []api.RepeatingPlanStruct) error {
 func (v *dummy) GetRepeatingPlans() []api.RepeatingPlanStruct {
 	return []api.RepeatingPlanStruct{}
 }
+
+// GetChargeCurve returns the learned charge curve
+func (v *dummy) GetChargeCurve() soc.Curve {
+	return soc.Curve{}
+}
+
+// SetChargeCurve stores the learned charge curve
+func (v *dummy) SetChargeCurve(curve soc.Curve) error {
+	return nil
+}
+
+// GetSnapshot returns the last known vehicle data
+func (v *dummy) GetSnapshot() Snapshot {
+	return Snapshot{}
+}
+
+// SetSnapshot stores the last known vehicle data
+func (v *dummy) SetSnapshot(snapshot Snapshot) error {
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	time "time"
 
 	api "github.com/evcc-io/evcc/api"
+	soc "github.com/evcc-io/evcc/core/soc"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +42,20 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// GetChargeCurve mocks base method.
+func (m *MockAPI) GetChargeCurve() soc.Curve {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChargeCurve")
+	ret0, _ := ret[0].(soc.Curve)
+	return ret0
+}
+
+// GetChargeCurve indicates an expected call of GetChargeCurve.
+func (mr *MockAPIMockRecorder) GetChargeCurve() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargeCurve", reflect.TypeOf((*MockAPI)(nil).GetChargeCurve))
+}
+
 // GetLimitSoc mocks base method.
 func (m *MockAPI) GetLimitSoc() int {
 	m.ctrl.T.Helper()
@@ -99,6 +114,20 @@ func (mr *MockAPIMockRecorder) GetRepeatingPlans() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepeatingPlans", reflect.TypeOf((*MockAPI)(nil).GetRepeatingPlans))
 }
 
+// GetSnapshot mocks base method.
+func (m *MockAPI) GetSnapshot() Snapshot {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshot")
+	ret0, _ := ret[0].(Snapshot)
+	return ret0
+}
+
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockAPIMockRecorder) GetSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockAPI)(nil).GetSnapshot))
+}
+
 // Instance mocks base method.
 func (m *MockAPI) Instance() api.Vehicle {
 	m.ctrl.T.Helper()
@@ -127,28 +156,42 @@ func (mr *MockAPIMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockAPI)(nil).Name))
 }
 
+// SetChargeCurve mocks base method.
+func (m *MockAPI) SetChargeCurve(arg0 soc.Curve) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChargeCurve", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChargeCurve indicates an expected call of SetChargeCurve.
+func (mr *MockAPIMockRecorder) SetChargeCurve(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChargeCurve", reflect.TypeOf((*MockAPI)(nil).SetChargeCurve), arg0)
+}
+
 // SetLimitSoc mocks base method.
-func (m *MockAPI) SetLimitSoc(soc int) {
+func (m *MockAPI) SetLimitSoc(arg0 int) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetLimitSoc", soc)
+	m.ctrl.Call(m, "SetLimitSoc", arg0)
 }
 
 // SetLimitSoc indicates an expected call of SetLimitSoc.
-func (mr *MockAPIMockRecorder) SetLimitSoc(soc any) *gomock.Call {
+func (mr *MockAPIMockRecorder) SetLimitSoc(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimitSoc", reflect.TypeOf((*MockAPI)(nil).SetLimitSoc), soc)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimitSoc", reflect.TypeOf((*MockAPI)(nil).SetLimitSoc), arg0)
 }
 
 // SetMinSoc mocks base method.
-func (m *MockAPI) SetMinSoc(soc int) {
+func (m *MockAPI) SetMinSoc(arg0 int) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetMinSoc", soc)
+	m.ctrl.Call(m, "SetMinSoc", arg0)
 }
 
 // SetMinSoc indicates an expected call of SetMinSoc.
-func (mr *MockAPIMockRecorder) SetMinSoc(soc any) *gomock.Call {
+func (mr *MockAPIMockRecorder) SetMinSoc(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinSoc", reflect.TypeOf((*MockAPI)(nil).SetMinSoc), soc)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinSoc", reflect.TypeOf((*MockAPI)(nil).SetMinSoc), arg0)
 }
 
 // SetPlanSoc mocks base method.
@@ -178,3 +221,17 @@ func (mr *MockAPIMockRecorder) SetRepeatingPlans(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRepeatingPlans", reflect.TypeOf((*MockAPI)(nil).SetRepeatingPlans), arg0)
 }
+
+// SetSnapshot mocks base method.
+func (m *MockAPI) SetSnapshot(arg0 Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSnapshot", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSnapshot indicates an expected call of SetSnapshot.
+func (mr *MockAPIMockRecorder) SetSnapshot(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSnapshot", reflect.TypeOf((*MockAPI)(nil).SetSnapshot), arg0)
+}
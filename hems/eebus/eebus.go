@@ -7,12 +7,15 @@ import (
 	"time"
 
 	ucapi "github.com/enbility/eebus-go/usecases/api"
+	spineapi "github.com/enbility/spine-go/api"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/circuit"
 	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/server/eebus"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
+	"github.com/samber/lo"
 )
 
 type EEBus struct {
@@ -23,6 +26,12 @@ type EEBus struct {
 	uc *eebus.UseCasesCS
 
 	root api.Circuit
+	site site.API
+
+	compressor spineapi.EntityRemoteInterface // OHPCF
+
+	feedInEnergy  float64 // MGCP feed-in energy in Wh, persisted
+	feedInUpdated time.Time
 
 	status        status
 	statusUpdated time.Time
@@ -31,6 +40,10 @@ type EEBus struct {
 	failsafeLimit    float64
 	failsafeDuration time.Duration
 
+	productionLimit         *ucapi.LoadLimit // LPP
+	productionLimitUpdated  time.Time
+	failsafeProductionLimit *float64
+
 	heartbeat *util.Value[struct{}]
 }
 
@@ -39,13 +52,18 @@ type Limits struct {
 	ConsumptionLimit                    float64
 	FailsafeConsumptionActivePowerLimit float64
 	FailsafeDurationMinimum             time.Duration
+
+	// feed-in limitation, failsafe limit is only applied if configured
+	ContractualProductionNominalMax    float64
+	FailsafeProductionActivePowerLimit *float64
 }
 
 // New creates an EEBus HEMS from generic config
 func New(ctx context.Context, other map[string]interface{}, site site.API) (*EEBus, error) {
 	cc := struct {
-		Ski    string
-		Limits `mapstructure:",squash"`
+		Ski         string
+		HeatPumpSki string
+		Limits      `mapstructure:",squash"`
 	}{
 		Limits: Limits{
 			ContractualConsumptionNominalMax:    24800,
@@ -82,11 +100,11 @@ func New(ctx context.Context, other map[string]interface{}, site site.API) (*EEB
 	}
 	site.SetCircuit(lpc)
 
-	return NewEEBus(ctx, cc.Ski, cc.Limits, lpc)
+	return NewEEBus(ctx, cc.Ski, cc.HeatPumpSki, cc.Limits, lpc, site)
 }
 
 // NewEEBus creates EEBus charger
-func NewEEBus(ctx context.Context, ski string, limits Limits, root api.Circuit) (*EEBus, error) {
+func NewEEBus(ctx context.Context, ski, heatPumpSki string, limits Limits, root api.Circuit, site site.API) (*EEBus, error) {
 	if eebus.Instance == nil {
 		return nil, errors.New("eebus not configured")
 	}
@@ -94,6 +112,7 @@ func NewEEBus(ctx context.Context, ski string, limits Limits, root api.Circuit)
 	c := &EEBus{
 		log:       util.NewLogger("eebus"),
 		root:      root,
+		site:      site,
 		uc:        eebus.Instance.ControllableSystem(),
 		Connector: eebus.NewConnector(),
 		heartbeat: util.NewValue[struct{}](2 * time.Minute), // LPC-031
@@ -105,6 +124,13 @@ func NewEEBus(ctx context.Context, ski string, limits Limits, root api.Circuit)
 
 		failsafeLimit:    limits.FailsafeConsumptionActivePowerLimit,
 		failsafeDuration: limits.FailsafeDurationMinimum,
+
+		failsafeProductionLimit: limits.FailsafeProductionActivePowerLimit,
+	}
+
+	// continue feed-in energy counter
+	if v, err := settings.Float(feedInEnergyKey); err == nil {
+		c.feedInEnergy = v
 	}
 
 	// simulate a received heartbeat
@@ -120,6 +146,13 @@ func NewEEBus(ctx context.Context, ski string, limits Limits, root api.Circuit)
 		return nil, err
 	}
 
+	// heat pump offering compressor flexibility
+	if heatPumpSki != "" {
+		if err := eebus.Instance.RegisterDevice(heatPumpSki, "", c); err != nil {
+			return nil, err
+		}
+	}
+
 	// scenarios
 	for _, s := range c.uc.LPC.RemoteEntitiesScenarios() {
 		c.log.DEBUG.Println("LPC RemoteEntitiesScenarios:", s.Scenarios)
@@ -145,6 +178,20 @@ func NewEEBus(ctx context.Context, ski string, limits Limits, root api.Circuit)
 		c.log.ERROR.Println("LPC SetFailsafeDurationMinimum:", err)
 	}
 
+	if limits.ContractualProductionNominalMax > 0 {
+		if err := c.uc.LPP.SetProductionNominalMax(limits.ContractualProductionNominalMax); err != nil {
+			c.log.ERROR.Println("LPP SetProductionNominalMax:", err)
+		}
+	}
+	if c.failsafeProductionLimit != nil {
+		if err := c.uc.LPP.SetFailsafeProductionActivePowerLimit(*c.failsafeProductionLimit, true); err != nil {
+			c.log.ERROR.Println("LPP SetFailsafeProductionActivePowerLimit:", err)
+		}
+	}
+	if err := c.uc.LPP.SetFailsafeDurationMinimum(c.failsafeDuration, true); err != nil {
+		c.log.ERROR.Println("LPP SetFailsafeDurationMinimum:", err)
+	}
+
 	return c, nil
 }
 
@@ -153,6 +200,9 @@ func (c *EEBus) Run() {
 		if err := c.run(); err != nil {
 			c.log.ERROR.Println(err)
 		}
+
+		c.updateProductionLimit()
+		c.updateMonitoring()
 	}
 }
 
@@ -224,4 +274,33 @@ func (c *EEBus) setStatusAndLimit(status status, limit float64) {
 
 func (c *EEBus) setLimit(limit float64) {
 	c.root.SetMaxPower(limit)
+	c.root.SetCurtailed(limit > 0)
+}
+
+// updateProductionLimit applies the LPP production limit as site feed-in limit
+func (c *EEBus) updateProductionLimit() {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	var limit *float64
+
+	switch {
+	case c.status == StatusFailsafe && c.failsafeProductionLimit != nil:
+		limit = c.failsafeProductionLimit
+
+	case c.productionLimit != nil && c.productionLimit.IsActive:
+		if d := c.productionLimit.Duration; d == 0 || time.Since(c.productionLimitUpdated) <= d {
+			limit = &c.productionLimit.Value
+		}
+	}
+
+	if current := c.site.GetFeedInLimitExternal(); limit == nil && current == nil || limit != nil && current != nil && *limit == *current {
+		return
+	}
+
+	if limit != nil {
+		limit = lo.ToPtr(*limit)
+	}
+
+	c.site.SetFeedInLimitExternal(limit)
 }
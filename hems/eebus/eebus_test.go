@@ -0,0 +1,48 @@
+package eebus
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type feedInSite struct {
+	site.API
+	limit *float64
+}
+
+func (s *feedInSite) GetFeedInLimitExternal() *float64 {
+	return s.limit
+}
+
+func (s *feedInSite) SetFeedInLimitExternal(limit *float64) {
+	s.limit = limit
+}
+
+func TestFailsafeProductionLimit(t *testing.T) {
+	s := new(feedInSite)
+	c := &EEBus{
+		log:    util.NewLogger("test"),
+		site:   s,
+		status: StatusFailsafe,
+	}
+
+	// no failsafe limit configured
+	c.updateProductionLimit()
+	assert.Nil(t, s.limit)
+
+	// zero feed-in is a valid failsafe limit
+	c.failsafeProductionLimit = lo.ToPtr(0.0)
+	c.updateProductionLimit()
+	require.NotNil(t, s.limit)
+	assert.Equal(t, 0.0, *s.limit)
+
+	// limit removed when leaving failsafe
+	c.status = StatusUnlimited
+	c.updateProductionLimit()
+	assert.Nil(t, s.limit)
+}
@@ -1,10 +1,14 @@
 package eebus
 
 import (
+	"time"
+
 	eebusapi "github.com/enbility/eebus-go/api"
 	"github.com/enbility/eebus-go/usecases/cs/lpc"
+	"github.com/enbility/eebus-go/usecases/cs/lpp"
 	spineapi "github.com/enbility/spine-go/api"
 	"github.com/evcc-io/evcc/server/eebus"
+	"github.com/evcc-io/evcc/server/eebus/usecases"
 )
 
 var _ eebus.Device = (*EEBus)(nil)
@@ -54,47 +58,51 @@ func (c *EEBus) UseCaseEvent(_ spineapi.DeviceRemoteInterface, entity spineapi.E
 	case lpc.DataUpdateHeartbeat:
 		c.dataUpdateHeartbeat()
 
-		// // Load control obligation limit data update received
-		// //
-		// // Use `ProductionLimit` to get the current data
-		// //
-		// // Use Case LPC, Scenario 1
-		// case lpp.DataUpdateLimit:
-		// 	c.dataUpdateLimit()
-
-		// // An incoming load control obligation limit needs to be approved or denied
-		// //
-		// // Use `PendingProductionLimits` to get the currently pending write approval requests
-		// // and invoke `ApproveOrDenyProductionLimit` for each
-		// //
-		// // Use Case LPC, Scenario 1
-		// case lpp.WriteApprovalRequired:
-		// 	c.writeApprovalRequired()
-
-		// // Failsafe limit for the produced active (real) power of the
-		// // Controllable System data update received
-		// //
-		// // Use `FailsafeProductionActivePowerLimit` to get the current data
-		// //
-		// // Use Case LPC, Scenario 2
-		// case lpp.DataUpdateFailsafeProductionActivePowerLimit:
-		// 	c.dataUpdateFailsafeProductionActivePowerLimit()
-
-		// // Minimum time the Controllable System remains in "failsafe state" unless conditions
-		// // specified in this Use Case permit leaving the "failsafe state" data update received
-		// //
-		// // Use `FailsafeDurationMinimum` to get the current data
-		// //
-		// // Use Case LPC, Scenario 2
-		// case lpp.DataUpdateFailsafeDurationMinimum:
-		// 	c.dataUpdateFailsafeDurationMinimum()
-
-		// // Indicates a notify heartbeat event the application should care of.
-		// // E.g. going into or out of the Failsafe state
-		// //
-		// // Use Case LPP, Scenario 3
-		// case lpp.DataUpdateHeartbeat:
-		// 	c.dataUpdateHeartbeat()
+	// Power sequence offered by the heat pump compressor was updated
+	//
+	// Use `Flexibility` to get the current data
+	//
+	// Use Case OHPCF, Scenario 1
+	case usecases.OHPCFDataUpdateFlexibility:
+		c.dataUpdateFlexibility(entity)
+
+	// The compressor entity was removed
+	//
+	// Use Case OHPCF, Scenario 1
+	case usecases.OHPCFCompressorDisconnected:
+		c.compressorDisconnected(entity)
+
+	// Production limit data update received
+	//
+	// Use `ProductionLimit` to get the current data
+	//
+	// Use Case LPP, Scenario 1
+	case lpp.DataUpdateLimit:
+		c.dataUpdateProductionLimit()
+
+	// An incoming production limit needs to be approved or denied
+	//
+	// Use `PendingProductionLimits` to get the currently pending write approval requests
+	// and invoke `ApproveOrDenyProductionLimit` for each
+	//
+	// Use Case LPP, Scenario 1
+	case lpp.WriteApprovalRequired:
+		c.productionWriteApprovalRequired()
+
+	// Failsafe limit for the produced active (real) power of the
+	// Controllable System data update received
+	//
+	// Use `FailsafeProductionActivePowerLimit` to get the current data
+	//
+	// Use Case LPP, Scenario 2
+	case lpp.DataUpdateFailsafeProductionActivePowerLimit:
+		c.dataUpdateFailsafeProductionActivePowerLimit()
+
+	// Indicates a notify heartbeat event the application should care of.
+	//
+	// Use Case LPP, Scenario 3
+	case lpp.DataUpdateHeartbeat:
+		c.dataUpdateHeartbeat()
 	}
 }
 
@@ -156,8 +164,57 @@ func (c *EEBus) dataUpdateHeartbeat() {
 	c.heartbeat.Set(struct{}{})
 }
 
-// func (c *EEBus)dataUpdateLimit(){}
-// func (c *EEBus)writeApprovalRequired(){}
-// func (c *EEBus)dataUpdateFailsafeProductionActivePowerLimit(){}
-// func (c *EEBus)dataUpdateFailsafeDurationMinimum(){}
-// func (c *EEBus)dataUpdateHeartbeat(){}
+func (c *EEBus) dataUpdateProductionLimit() {
+	limit, err := c.uc.LPP.ProductionLimit()
+	if err != nil {
+		c.log.ERROR.Println("LPP.ProductionLimit:", err)
+		return
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.productionLimit = &limit
+	c.productionLimitUpdated = time.Now()
+}
+
+func (c *EEBus) productionWriteApprovalRequired() {
+	for msg, limit := range c.uc.LPP.PendingProductionLimits() {
+		c.log.DEBUG.Println("LPP.PendingProductionLimit:", msg, limit)
+		c.uc.LPP.ApproveOrDenyProductionLimit(msg, true, "")
+
+		c.mux.Lock()
+		c.productionLimit = &limit
+		c.productionLimitUpdated = time.Now()
+		c.mux.Unlock()
+	}
+}
+
+func (c *EEBus) dataUpdateFailsafeProductionActivePowerLimit() {
+	limit, _, err := c.uc.LPP.FailsafeProductionActivePowerLimit()
+	if err != nil {
+		c.log.ERROR.Println("LPP.FailsafeProductionActivePowerLimit:", err)
+		return
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.failsafeProductionLimit = &limit
+}
+
+func (c *EEBus) dataUpdateFlexibility(entity spineapi.EntityRemoteInterface) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.compressor = entity
+}
+
+func (c *EEBus) compressorDisconnected(entity spineapi.EntityRemoteInterface) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.compressor == entity {
+		c.compressor = nil
+	}
+}
@@ -0,0 +1,127 @@
+package eebus
+
+import (
+	"slices"
+	"time"
+
+	"github.com/enbility/spine-go/model"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// feedInEnergyKey is the settings key of the MGCP feed-in energy
+const feedInEnergyKey = "eebus.feedInEnergy"
+
+// gridMeter returns the site grid meter if configured
+func (c *EEBus) gridMeter() api.Meter {
+	ref := c.site.GetGridMeterRef()
+	if ref == "" {
+		return nil
+	}
+
+	dev, err := config.Meters().ByName(ref)
+	if err != nil {
+		return nil
+	}
+
+	return dev.Instance()
+}
+
+// updateMonitoring publishes loadpoint consumption (MPC) and grid connection point (MGCP) measurements
+func (c *EEBus) updateMonitoring() {
+	var power float64
+	for _, lp := range c.site.Loadpoints() {
+		power += lp.GetChargePower()
+	}
+
+	if err := c.uc.MPC.SetPower(power); err != nil {
+		c.log.ERROR.Println("MPC SetPower:", err)
+	}
+
+	meter := c.gridMeter()
+	if meter == nil {
+		return
+	}
+
+	gridPower, err := meter.CurrentPower()
+	if err != nil {
+		c.log.DEBUG.Println("grid power:", err)
+		return
+	}
+
+	if err := c.uc.GCP.SetPower(gridPower); err != nil {
+		c.log.ERROR.Println("MGCP SetPower:", err)
+	}
+
+	c.updateFeedIn(gridPower, time.Now())
+
+	if m, ok := meter.(api.MeterEnergy); ok {
+		if energy, err := m.TotalEnergy(); err == nil {
+			if err := c.uc.GCP.SetEnergyConsumed(energy * 1e3); err != nil {
+				c.log.ERROR.Println("MGCP SetEnergyConsumed:", err)
+			}
+		}
+	}
+
+	if m, ok := meter.(api.PhaseCurrents); ok {
+		if l1, l2, l3, err := m.Currents(); err == nil {
+			if err := c.uc.GCP.SetCurrentPerPhase(l1, l2, l3); err != nil {
+				c.log.ERROR.Println("MGCP SetCurrentPerPhase:", err)
+			}
+		}
+	}
+
+	if m, ok := meter.(api.PhaseVoltages); ok {
+		if l1, l2, l3, err := m.Voltages(); err == nil {
+			if err := c.uc.GCP.SetVoltagePerPhase(l1, l2, l3); err != nil {
+				c.log.ERROR.Println("MGCP SetVoltagePerPhase:", err)
+			}
+		}
+	}
+
+	c.updateFlexibility(gridPower)
+}
+
+// updateFeedIn publishes the feed-in energy integrated from grid power since meters provide no feed-in counter.
+// The total is persisted such that the counter continues after restart instead of being reset.
+func (c *EEBus) updateFeedIn(gridPower float64, now time.Time) {
+	if !c.feedInUpdated.IsZero() {
+		c.feedInEnergy += max(0, -gridPower) * now.Sub(c.feedInUpdated).Hours()
+	}
+	c.feedInUpdated = now
+
+	settings.SetFloat(feedInEnergyKey, c.feedInEnergy)
+
+	if err := c.uc.GCP.SetEnergyFeedIn(c.feedInEnergy); err != nil {
+		c.log.ERROR.Println("MGCP SetEnergyFeedIn:", err)
+	}
+}
+
+// updateFlexibility starts the heat pump compressor's power sequence once grid feed-in covers its expected power
+func (c *EEBus) updateFlexibility(gridPower float64) {
+	c.mux.RLock()
+	compressor := c.compressor
+	c.mux.RUnlock()
+
+	if compressor == nil {
+		return
+	}
+
+	flex, err := c.uc.OHPCF.Flexibility(compressor)
+	if err != nil {
+		c.log.DEBUG.Println("OHPCF Flexibility:", err)
+		return
+	}
+
+	if !flex.Controllable || flex.Power <= 0 || -gridPower < flex.Power ||
+		!slices.Contains([]model.PowerSequenceStateType{model.PowerSequenceStateTypeInactive, model.PowerSequenceStateTypeScheduled}, flex.State) {
+		return
+	}
+
+	c.log.DEBUG.Printf("OHPCF starting compressor: %.0fW for %v", flex.Power, flex.Duration)
+
+	if err := c.uc.OHPCF.StartFlexibility(compressor); err != nil {
+		c.log.ERROR.Println("OHPCF StartFlexibility:", err)
+	}
+}
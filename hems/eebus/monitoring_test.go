@@ -0,0 +1,84 @@
+package eebus
+
+import (
+	"testing"
+	"time"
+
+	spinemocks "github.com/enbility/spine-go/mocks"
+	"github.com/enbility/spine-go/model"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/server/eebus"
+	"github.com/evcc-io/evcc/server/eebus/usecases"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFeedInEnergy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	gcp := usecases.NewMockGcpMGCPInterface(ctrl)
+	c := &EEBus{
+		log: util.NewLogger("test"),
+		uc:  &eebus.UseCasesCS{GCP: gcp},
+	}
+
+	now := time.Now()
+
+	gcp.EXPECT().SetEnergyFeedIn(0.0)
+	c.updateFeedIn(-1000, now)
+
+	// feed-in is integrated over time
+	now = now.Add(30 * time.Minute)
+	gcp.EXPECT().SetEnergyFeedIn(500.0)
+	c.updateFeedIn(-1000, now)
+
+	// grid consumption does not count
+	now = now.Add(30 * time.Minute)
+	gcp.EXPECT().SetEnergyFeedIn(500.0)
+	c.updateFeedIn(2000, now)
+
+	// counter is persisted
+	v, err := settings.Float(feedInEnergyKey)
+	require.NoError(t, err)
+	assert.Equal(t, 500.0, v)
+}
+
+func TestFlexibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ohpcf := usecases.NewMockCemOHPCFInterface(ctrl)
+	c := &EEBus{
+		log: util.NewLogger("test"),
+		uc:  &eebus.UseCasesCS{OHPCF: ohpcf},
+	}
+
+	compressor := spinemocks.NewEntityRemoteInterface(t)
+	c.UseCaseEvent(nil, compressor, usecases.OHPCFDataUpdateFlexibility)
+
+	flex := usecases.Flexibility{
+		State:        model.PowerSequenceStateTypeInactive,
+		Power:        1500,
+		Duration:     time.Hour,
+		Controllable: true,
+	}
+
+	// insufficient feed-in
+	ohpcf.EXPECT().Flexibility(compressor).Return(flex, nil)
+	c.updateFlexibility(-1000)
+
+	// feed-in covers compressor power
+	ohpcf.EXPECT().Flexibility(compressor).Return(flex, nil)
+	ohpcf.EXPECT().StartFlexibility(compressor).Return(nil)
+	c.updateFlexibility(-2000)
+
+	// running sequence is not restarted
+	flex.State = model.PowerSequenceStateTypeRunning
+	ohpcf.EXPECT().Flexibility(compressor).Return(flex, nil)
+	c.updateFlexibility(-2000)
+
+	// compressor removed
+	c.UseCaseEvent(nil, compressor, usecases.OHPCFCompressorDisconnected)
+	c.updateFlexibility(-2000)
+}
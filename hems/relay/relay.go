@@ -96,6 +96,7 @@ func (c *Relay) run() error {
 	}
 
 	c.root.SetMaxPower(power)
+	c.root.SetCurtailed(limit)
 
 	return nil
 }
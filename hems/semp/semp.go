@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/machine"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -30,6 +32,9 @@ const (
 	sempDeviceId     = "F-%s-%.12x-00" // 6 bytes
 	sempSerialNumber = "%s-%d"
 	sempCharger      = "EVCharger"
+	sempHeatPump     = "HeatPump"
+	sempHeater       = "Heater"
+	sempOther        = "Other"
 	basePath         = "/semp"
 	maxAge           = 1800
 )
@@ -48,6 +53,7 @@ type SEMP struct {
 	hostURI      string
 	port         int
 	site         site.API
+	chargers     map[loadpoint.API]api.Charger
 }
 
 // New generates SEMP Gateway listening at /semp endpoint
@@ -96,6 +102,14 @@ func New(conf map[string]interface{}, site site.API, httpd *server.HTTPd) (*SEMP
 		vid:          cc.VendorID,
 		did:          did,
 		controllable: cc.AllowControl,
+		chargers:     make(map[loadpoint.API]api.Charger),
+	}
+
+	// resolve loadpoint chargers
+	for _, lp := range site.Loadpoints() {
+		if dev, err := config.Chargers().ByName(lp.GetChargerRef()); err == nil {
+			s.chargers[lp] = dev.Instance()
+		}
 	}
 
 	// find external port
@@ -363,17 +377,63 @@ func (s *SEMP) deviceID(id int) string {
 	return fmt.Sprintf(sempDeviceId, s.vid, ^uint64(0xffff<<48)&(binary.BigEndian.Uint64(did)+uint64(id)))
 }
 
+// sempIconTypes maps device icons to SEMP device types
+var sempIconTypes = map[string]string{
+	"heatpump":    sempHeatPump,
+	"heater":      sempHeater,
+	"waterheater": sempHeater,
+	"dishwasher":  "DishWasher",
+	"dryer":       "Dryer",
+	"laundry":     "WashingMachine",
+	"laundry2":    "WashingMachine",
+	"pump":        "Pump",
+	"cooler":      "Fridge",
+	"climate":     "AirConditioning",
+}
+
+// charger returns the loadpoint's charger
+func (s *SEMP) charger(lp loadpoint.API) api.Charger {
+	return s.chargers[lp]
+}
+
+// deviceType returns the SEMP device type of the loadpoint's charger
+func (s *SEMP) deviceType(charger api.Charger) string {
+	var features []api.Feature
+	if fd, ok := charger.(api.FeatureDescriber); ok {
+		features = fd.Features()
+	}
+
+	heating := slices.Contains(features, api.Heating)
+	if !heating && !slices.Contains(features, api.IntegratedDevice) {
+		return sempCharger
+	}
+
+	if id, ok := charger.(api.IconDescriber); ok {
+		if typ, ok := sempIconTypes[id.Icon()]; ok {
+			return typ
+		}
+	}
+
+	if heating {
+		return sempHeatPump
+	}
+
+	return sempOther
+}
+
 func (s *SEMP) deviceInfo(id int, lp loadpoint.API) DeviceInfo {
 	method := MethodEstimation
 	if lp.HasChargeMeter() {
 		method = MethodMeasurement
 	}
 
+	charger := s.charger(lp)
+
 	res := DeviceInfo{
 		Identification: Identification{
 			DeviceID:     s.deviceID(id),
 			DeviceName:   lp.GetTitle(),
-			DeviceType:   sempCharger,
+			DeviceType:   s.deviceType(charger),
 			DeviceSerial: s.serialNumber(id),
 			DeviceVendor: "github.com/evcc-io/evcc",
 		},
@@ -388,6 +448,12 @@ func (s *SEMP) deviceInfo(id int, lp loadpoint.API) DeviceInfo {
 		},
 	}
 
+	// appliance programs must not be interrupted
+	if dl, ok := charger.(api.DeferrableLoad); ok {
+		res.Capabilities.InterruptionsAllowed = false
+		res.Characteristics.MinOnTime = int(dl.ProgramDuration() / time.Second)
+	}
+
 	return res
 }
 
@@ -434,11 +500,68 @@ func (s *SEMP) allDeviceStatus() (res []DeviceStatus) {
 	return res
 }
 
+// planTimeframe creates a mandatory timeframe for the loadpoint's active charge plan
+func (s *SEMP) planTimeframe(id int, lp loadpoint.API, ev bool) (Timeframe, bool) {
+	planTime := lp.EffectivePlanTime()
+	if planTime.IsZero() {
+		return Timeframe{}, false
+	}
+
+	maxPower := lp.EffectiveMaxPower()
+	goal, _ := lp.GetPlanGoal()
+
+	requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower, planTime)
+	if requiredDuration <= 0 {
+		return Timeframe{}, false
+	}
+
+	// overrunning plans are scheduled as soon as possible
+	latestEnd := max(time.Until(planTime), requiredDuration)
+
+	res := Timeframe{
+		DeviceID:      s.deviceID(id),
+		EarliestStart: 0,
+		LatestEnd:     int(latestEnd / time.Second),
+	}
+
+	if !ev {
+		runningTime := int(requiredDuration / time.Second)
+		res.MinRunningTime = &runningTime
+		res.MaxRunningTime = &runningTime
+
+		return res, true
+	}
+
+	minEnergy := int(maxPower * requiredDuration.Hours())
+	maxEnergy := max(minEnergy, int(lp.GetRemainingEnergy()))
+	maxPowerConsumption := int(maxPower)
+	minPowerConsumption := int(lp.EffectiveMinPower())
+
+	res.MinEnergy = &minEnergy
+	res.MaxEnergy = &maxEnergy
+	res.MaxPowerConsumption = &maxPowerConsumption
+	res.MinPowerConsumption = &minPowerConsumption
+
+	return res, true
+}
+
 func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 	mode := lp.GetMode()
 	charging := lp.GetStatus() == api.StatusC
 	connected := charging || lp.GetStatus() == api.StatusB
 
+	if mode == api.ModeOff || !connected {
+		return res
+	}
+
+	ev := s.deviceType(s.charger(lp)) == sempCharger
+
+	// charge plans take precedence and are announced as mandatory energy demand
+	if tf, ok := s.planTimeframe(id, lp, ev); ok {
+		res.Timeframe = append(res.Timeframe, tf)
+		return res
+	}
+
 	// remaining max demand duration in seconds
 	chargeRemainingDuration := lp.GetRemainingDuration()
 	latestEnd := int(chargeRemainingDuration / time.Second)
@@ -446,6 +569,22 @@ func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 		latestEnd = 24 * 3600
 	}
 
+	// devices other than EV chargers are scheduled by running time
+	if !ev {
+		if mode == api.ModeMinPV || mode == api.ModePV {
+			minRunningTime, maxRunningTime := 0, latestEnd
+			res.Timeframe = append(res.Timeframe, Timeframe{
+				DeviceID:       s.deviceID(id),
+				EarliestStart:  0,
+				LatestEnd:      latestEnd,
+				MinRunningTime: &minRunningTime,
+				MaxRunningTime: &maxRunningTime,
+			})
+		}
+
+		return res
+	}
+
 	// remaining max energy demand in Wh
 	chargeRemainingEnergy := lp.GetRemainingEnergy()
 	maxEnergy := int(chargeRemainingEnergy)
@@ -466,7 +605,7 @@ func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 		minPowerConsumption = maxPowerConsumption
 	}
 
-	if mode != api.ModeOff && connected && maxEnergy > 0 {
+	if maxEnergy > 0 {
 		res = PlanningRequest{
 			Timeframe: []Timeframe{{
 				DeviceID:            s.deviceID(id),
@@ -0,0 +1,163 @@
+package semp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testCharger struct {
+	*api.MockCharger
+	features []api.Feature
+	icon     string
+}
+
+func (c *testCharger) Features() []api.Feature {
+	return c.features
+}
+
+func (c *testCharger) Icon() string {
+	return c.icon
+}
+
+type testAppliance struct {
+	*testCharger
+}
+
+func (c *testAppliance) ProgramDuration() time.Duration {
+	return 2 * time.Hour
+}
+
+func (c *testAppliance) ProgramRunning() bool {
+	return false
+}
+
+func newTestSEMP(lp loadpoint.API, charger api.Charger) *SEMP {
+	return &SEMP{
+		vid:      "28081973",
+		did:      make([]byte, 6),
+		chargers: map[loadpoint.API]api.Charger{lp: charger},
+	}
+}
+
+func TestDeviceType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	for _, tc := range []struct {
+		features []api.Feature
+		icon     string
+		typ      string
+	}{
+		{nil, "", sempCharger},
+		{nil, "heatpump", sempCharger},
+		{[]api.Feature{api.Heating}, "", sempHeatPump},
+		{[]api.Feature{api.Heating}, "waterheater", sempHeater},
+		{[]api.Feature{api.IntegratedDevice}, "", sempOther},
+		{[]api.Feature{api.IntegratedDevice}, "dishwasher", "DishWasher"},
+		{[]api.Feature{api.IntegratedDevice}, "laundry", "WashingMachine"},
+	} {
+		charger := &testCharger{api.NewMockCharger(ctrl), tc.features, tc.icon}
+		assert.Equal(t, tc.typ, new(SEMP).deviceType(charger), "%v %s", tc.features, tc.icon)
+	}
+}
+
+func TestDeviceInfoAppliance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().HasChargeMeter().Return(true)
+	lp.EXPECT().GetTitle().Return("dishwasher")
+	lp.EXPECT().EffectiveMinPower().Return(2000.0)
+	lp.EXPECT().EffectiveMaxPower().Return(2000.0)
+
+	charger := &testAppliance{&testCharger{api.NewMockCharger(ctrl), []api.Feature{api.IntegratedDevice}, "dishwasher"}}
+	s := newTestSEMP(lp, charger)
+
+	res := s.deviceInfo(0, lp)
+	assert.Equal(t, "DishWasher", res.Identification.DeviceType)
+	assert.False(t, res.Capabilities.InterruptionsAllowed)
+	assert.Equal(t, 7200, res.Characteristics.MinOnTime)
+}
+
+func TestPlanningRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ev := &testCharger{MockCharger: api.NewMockCharger(ctrl)}
+	heating := &testCharger{api.NewMockCharger(ctrl), []api.Feature{api.Heating}, "heatpump"}
+
+	newLoadpoint := func(mode api.ChargeMode, planTime time.Time) *loadpoint.MockAPI {
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().GetMode().Return(mode).AnyTimes()
+		lp.EXPECT().GetStatus().Return(api.StatusB).AnyTimes()
+		lp.EXPECT().EffectivePlanTime().Return(planTime).AnyTimes()
+		lp.EXPECT().EffectiveMinPower().Return(1400.0).AnyTimes()
+		lp.EXPECT().EffectiveMaxPower().Return(11000.0).AnyTimes()
+		lp.EXPECT().GetPlanGoal().Return(80.0, true).AnyTimes()
+		lp.EXPECT().GetPlanRequiredDuration(80.0, 11000.0, planTime).Return(2 * time.Hour).AnyTimes()
+		lp.EXPECT().GetRemainingEnergy().Return(30000.0).AnyTimes()
+		lp.EXPECT().GetRemainingDuration().Return(time.Duration(0)).AnyTimes()
+		return lp
+	}
+
+	t.Run("off", func(t *testing.T) {
+		lp := newLoadpoint(api.ModeOff, time.Time{})
+		assert.Empty(t, newTestSEMP(lp, ev).planningRequest(0, lp).Timeframe)
+	})
+
+	t.Run("ev plan", func(t *testing.T) {
+		lp := newLoadpoint(api.ModePV, time.Now().Add(4*time.Hour))
+
+		res := newTestSEMP(lp, ev).planningRequest(0, lp).Timeframe
+		require.Len(t, res, 1)
+
+		tf := res[0]
+		assert.InDelta(t, 4*3600, tf.LatestEnd, 5)
+		assert.Equal(t, 22000, *tf.MinEnergy)
+		assert.Equal(t, 30000, *tf.MaxEnergy)
+		assert.Equal(t, 1400, *tf.MinPowerConsumption)
+		assert.Equal(t, 11000, *tf.MaxPowerConsumption)
+		assert.Nil(t, tf.MinRunningTime)
+	})
+
+	t.Run("overrunning plan", func(t *testing.T) {
+		lp := newLoadpoint(api.ModePV, time.Now().Add(time.Hour))
+
+		res := newTestSEMP(lp, ev).planningRequest(0, lp).Timeframe
+		require.Len(t, res, 1)
+		assert.Equal(t, 2*3600, res[0].LatestEnd)
+	})
+
+	t.Run("heating plan", func(t *testing.T) {
+		lp := newLoadpoint(api.ModePV, time.Now().Add(4*time.Hour))
+
+		res := newTestSEMP(lp, heating).planningRequest(0, lp).Timeframe
+		require.Len(t, res, 1)
+
+		tf := res[0]
+		assert.Equal(t, 2*3600, *tf.MinRunningTime)
+		assert.Equal(t, 2*3600, *tf.MaxRunningTime)
+		assert.Nil(t, tf.MinEnergy)
+	})
+
+	t.Run("heating pv", func(t *testing.T) {
+		lp := newLoadpoint(api.ModePV, time.Time{})
+
+		res := newTestSEMP(lp, heating).planningRequest(0, lp).Timeframe
+		require.Len(t, res, 1)
+
+		tf := res[0]
+		assert.Equal(t, 24*3600, tf.LatestEnd)
+		assert.Equal(t, 0, *tf.MinRunningTime)
+		assert.Equal(t, 24*3600, *tf.MaxRunningTime)
+	})
+
+	t.Run("heating now", func(t *testing.T) {
+		lp := newLoadpoint(api.ModeNow, time.Time{})
+		assert.Empty(t, newTestSEMP(lp, heating).planningRequest(0, lp).Timeframe)
+	})
+}
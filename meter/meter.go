@@ -2,6 +2,7 @@ package meter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/evcc-io/evcc/api"
@@ -29,6 +30,9 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		Soc               *plugin.Config // optional
 		LimitSoc          *plugin.Config // optional
 		BatteryMode       *plugin.Config // optional
+
+		// pv
+		Curtail *plugin.Config // optional
 	}{
 		batterySocLimits: batterySocLimits{
 			MinSoc: 20,
@@ -80,6 +84,24 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		}
 	}
 
+	// pv curtailment
+	if cc.Curtail != nil {
+		if cc.MaxACPower == 0 {
+			return nil, errors.New("curtail requires maxacpower")
+		}
+
+		if socG != nil || batModeS != nil {
+			return nil, errors.New("curtail not supported for battery meters")
+		}
+
+		curtailS, err := cc.Curtail.IntSetter(ctx, "curtail")
+		if err != nil {
+			return nil, fmt.Errorf("curtail: %w", err)
+		}
+
+		return NewPVCurtailer(m, cc.MaxACPower, curtailS).Decorate(energyG, currentsG, voltagesG, powersG), nil
+	}
+
 	res := m.Decorate(energyG, currentsG, voltagesG, powersG, socG, cc.batteryCapacity.Decorator(), cc.batteryMaxACPower.Decorator(), batModeS)
 
 	return res, nil
@@ -0,0 +1,45 @@
+package meter
+
+import (
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+//go:generate go tool decorate -f decoratePVCurtailer -b *PVCurtailer -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)"
+
+// PVCurtailer is a pv meter that limits the inverter's active power output
+type PVCurtailer struct {
+	api.Meter
+	maxACPower float64
+	curtailS   func(int64) error
+}
+
+var _ api.PVCurtailer = (*PVCurtailer)(nil)
+
+// NewPVCurtailer creates a curtailable pv meter
+func NewPVCurtailer(m api.Meter, maxACPower float64, curtailS func(int64) error) *PVCurtailer {
+	return &PVCurtailer{
+		Meter:      m,
+		maxACPower: maxACPower,
+		curtailS:   curtailS,
+	}
+}
+
+// Decorate attaches additional capabilities to the pv meter
+func (m *PVCurtailer) Decorate(
+	totalEnergy func() (float64, error),
+	currents, voltages, powers func() (float64, float64, float64, error),
+) api.Meter {
+	return decoratePVCurtailer(m, totalEnergy, currents, voltages, powers)
+}
+
+// MaxACPower implements the api.MaxACPowerGetter interface
+func (m *PVCurtailer) MaxACPower() float64 {
+	return m.maxACPower
+}
+
+// Curtail implements the api.PVCurtailer interface
+func (m *PVCurtailer) Curtail(percent float64) error {
+	return m.curtailS(int64(math.Round(percent)))
+}
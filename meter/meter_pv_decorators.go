@@ -0,0 +1,221 @@
+package meter
+
+// Code generated by github.com/evcc-io/evcc/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decoratePVCurtailer(base *PVCurtailer, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseVoltages func() (float64, float64, float64, error), phasePowers func() (float64, float64, float64, error)) api.Meter {
+	switch {
+	case meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
+		return base
+
+	case meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
+		return &struct {
+			*PVCurtailer
+			api.MeterEnergy
+		}{
+			PVCurtailer: base,
+			MeterEnergy: &decoratePVCurtailerMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
+		return &struct {
+			*PVCurtailer
+			api.PhaseCurrents
+		}{
+			PVCurtailer: base,
+			PhaseCurrents: &decoratePVCurtailerPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
+		return &struct {
+			*PVCurtailer
+			api.MeterEnergy
+			api.PhaseCurrents
+		}{
+			PVCurtailer: base,
+			MeterEnergy: &decoratePVCurtailerMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decoratePVCurtailerPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
+		return &struct {
+			*PVCurtailer
+			api.PhaseVoltages
+		}{
+			PVCurtailer: base,
+			PhaseVoltages: &decoratePVCurtailerPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
+		return &struct {
+			*PVCurtailer
+			api.MeterEnergy
+			api.PhaseVoltages
+		}{
+			PVCurtailer: base,
+			MeterEnergy: &decoratePVCurtailerMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseVoltages: &decoratePVCurtailerPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
+		return &struct {
+			*PVCurtailer
+			api.PhaseCurrents
+			api.PhaseVoltages
+		}{
+			PVCurtailer: base,
+			PhaseCurrents: &decoratePVCurtailerPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseVoltages: &decoratePVCurtailerPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
+		return &struct {
+			*PVCurtailer
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.PhaseVoltages
+		}{
+			PVCurtailer: base,
+			MeterEnergy: &decoratePVCurtailerMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decoratePVCurtailerPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseVoltages: &decoratePVCurtailerPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
+		return &struct {
+			*PVCurtailer
+			api.PhaseCurrents
+			api.PhasePowers
+		}{
+			PVCurtailer: base,
+			PhaseCurrents: &decoratePVCurtailerPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decoratePVCurtailerPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+		}
+
+	case meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
+		return &struct {
+			*PVCurtailer
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.PhasePowers
+		}{
+			PVCurtailer: base,
+			MeterEnergy: &decoratePVCurtailerMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decoratePVCurtailerPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decoratePVCurtailerPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+		}
+
+	case meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
+		return &struct {
+			*PVCurtailer
+			api.PhaseCurrents
+			api.PhasePowers
+			api.PhaseVoltages
+		}{
+			PVCurtailer: base,
+			PhaseCurrents: &decoratePVCurtailerPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decoratePVCurtailerPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+			PhaseVoltages: &decoratePVCurtailerPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
+		return &struct {
+			*PVCurtailer
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.PhasePowers
+			api.PhaseVoltages
+		}{
+			PVCurtailer: base,
+			MeterEnergy: &decoratePVCurtailerMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decoratePVCurtailerPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decoratePVCurtailerPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+			PhaseVoltages: &decoratePVCurtailerPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decoratePVCurtailerMeterEnergyImpl struct {
+	meterEnergy func() (float64, error)
+}
+
+func (impl *decoratePVCurtailerMeterEnergyImpl) TotalEnergy() (float64, error) {
+	return impl.meterEnergy()
+}
+
+type decoratePVCurtailerPhaseCurrentsImpl struct {
+	phaseCurrents func() (float64, float64, float64, error)
+}
+
+func (impl *decoratePVCurtailerPhaseCurrentsImpl) Currents() (float64, float64, float64, error) {
+	return impl.phaseCurrents()
+}
+
+type decoratePVCurtailerPhasePowersImpl struct {
+	phasePowers func() (float64, float64, float64, error)
+}
+
+func (impl *decoratePVCurtailerPhasePowersImpl) Powers() (float64, float64, float64, error) {
+	return impl.phasePowers()
+}
+
+type decoratePVCurtailerPhaseVoltagesImpl struct {
+	phaseVoltages func() (float64, float64, float64, error)
+}
+
+func (impl *decoratePVCurtailerPhaseVoltagesImpl) Voltages() (float64, float64, float64, error) {
+	return impl.phaseVoltages()
+}
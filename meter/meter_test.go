@@ -28,3 +28,44 @@ func TestACPower(t *testing.T) {
 	_, ok = m.(api.MaxACPowerGetter)
 	assert.True(t, ok, "api.MaxACPowerGetter")
 }
+
+func TestPVCurtailer(t *testing.T) {
+	m, err := NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"maxacpower": 10000,
+		"power": map[string]any{
+			"source": "const",
+			"value":  5000,
+		},
+		"energy": map[string]any{
+			"source": "const",
+			"value":  123,
+		},
+		"curtail": map[string]any{
+			"source": "js",
+			"script": "curtail",
+		},
+	})
+	require.NoError(t, err)
+
+	_, ok := m.(api.MeterEnergy)
+	assert.True(t, ok, "api.MeterEnergy")
+	_, ok = m.(api.PhaseCurrents)
+	assert.False(t, ok, "api.PhaseCurrents")
+
+	mp, ok := m.(api.MaxACPowerGetter)
+	require.True(t, ok, "api.MaxACPowerGetter")
+	assert.Equal(t, 10000.0, mp.MaxACPower())
+
+	c, ok := m.(api.PVCurtailer)
+	require.True(t, ok, "api.PVCurtailer")
+	require.NoError(t, c.Curtail(49.6))
+
+	// battery meters cannot be curtailed
+	_, err = NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"maxacpower": 10000,
+		"power":      map[string]any{"source": "const", "value": 0},
+		"soc":        map[string]any{"source": "const", "value": 50},
+		"curtail":    map[string]any{"source": "js", "script": "curtail"},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"github.com/andig/gosunspec/smdx"
+	"github.com/andig/gosunspec/typelabel"
+)
+
+// model 704 (DER AC controls) is not part of gosunspec
+func init() {
+	if smdx.GetModel(704) != nil {
+		return
+	}
+
+	point := func(id string, offset uint16, typ, sf, access string) smdx.PointElement {
+		return smdx.PointElement{Id: id, Label: id, Offset: offset, Type: typ, ScaleFactor: sf, Access: access}
+	}
+
+	smdx.RegisterModel(&smdx.ModelElement{
+		Id:     704,
+		Name:   "DERCtlAC",
+		Length: 65,
+		Blocks: []smdx.BlockElement{
+			{
+				Length: 65,
+				Type:   "fixed",
+				Points: []smdx.PointElement{
+					point("PFWInjEna", 0, typelabel.Enum16, "", "rw"),
+					point("PFWInjEnaRvrt", 1, typelabel.Enum16, "", "rw"),
+					point("PFWInjRvrtTms", 2, typelabel.Uint32, "", "rw"),
+					point("PFWInjRvrtRem", 4, typelabel.Uint32, "", "r"),
+					point("PFWAbsEna", 6, typelabel.Enum16, "", "rw"),
+					point("PFWAbsEnaRvrt", 7, typelabel.Enum16, "", "rw"),
+					point("PFWAbsRvrtTms", 8, typelabel.Uint32, "", "rw"),
+					point("PFWAbsRvrtRem", 10, typelabel.Uint32, "", "r"),
+					point("WMaxLimPctEna", 12, typelabel.Enum16, "", "rw"),
+					point("WMaxLimPct", 13, typelabel.Uint16, "WMaxLimPct_SF", "rw"),
+					point("WMaxLimPctRvrt", 14, typelabel.Uint16, "WMaxLimPct_SF", "rw"),
+					point("WMaxLimPctEnaRvrt", 15, typelabel.Enum16, "", "rw"),
+					point("WMaxLimPctRvrtTms", 16, typelabel.Uint32, "", "rw"),
+					point("WMaxLimPctRvrtRem", 18, typelabel.Uint32, "", "r"),
+					point("WSetEna", 20, typelabel.Enum16, "", "rw"),
+					point("WSetMod", 21, typelabel.Enum16, "", "rw"),
+					point("WSet", 22, typelabel.Int32, "WSet_SF", "rw"),
+					point("WSetRvrt", 24, typelabel.Int32, "WSet_SF", "rw"),
+					point("WSetPct", 26, typelabel.Int16, "WSetPct_SF", "rw"),
+					point("WSetPctRvrt", 27, typelabel.Int16, "WSetPct_SF", "rw"),
+					point("WSetEnaRvrt", 28, typelabel.Enum16, "", "rw"),
+					point("WSetRvrtTms", 29, typelabel.Uint32, "", "rw"),
+					point("WSetRvrtRem", 31, typelabel.Uint32, "", "r"),
+					point("VarSetEna", 33, typelabel.Enum16, "", "rw"),
+					point("VarSetMod", 34, typelabel.Enum16, "", "rw"),
+					point("VarSetPri", 35, typelabel.Enum16, "", "rw"),
+					point("VarSet", 36, typelabel.Int32, "VarSet_SF", "rw"),
+					point("VarSetRvrt", 38, typelabel.Int32, "VarSet_SF", "rw"),
+					point("VarSetPct", 40, typelabel.Int16, "VarSetPct_SF", "rw"),
+					point("VarSetPctRvrt", 41, typelabel.Int16, "VarSetPct_SF", "rw"),
+					point("VarSetEnaRvrt", 42, typelabel.Enum16, "", "rw"),
+					point("VarSetRvrtTms", 43, typelabel.Uint32, "", "rw"),
+					point("VarSetRvrtRem", 45, typelabel.Uint32, "", "r"),
+					point("WRmp", 47, typelabel.Uint16, "", "rw"),
+					point("WRmpRef", 48, typelabel.Enum16, "", "rw"),
+					point("VarRmp", 49, typelabel.Uint16, "", "rw"),
+					point("AntiIslEna", 50, typelabel.Enum16, "", "rw"),
+					point("PF_SF", 51, typelabel.ScaleFactor, "", "r"),
+					point("WMaxLimPct_SF", 52, typelabel.ScaleFactor, "", "r"),
+					point("WSet_SF", 53, typelabel.ScaleFactor, "", "r"),
+					point("WSetPct_SF", 54, typelabel.ScaleFactor, "", "r"),
+					point("VarSet_SF", 55, typelabel.ScaleFactor, "", "r"),
+					point("VarSetPct_SF", 56, typelabel.ScaleFactor, "", "r"),
+					point("PFWInj_PF", 57, typelabel.Uint16, "PF_SF", "rw"),
+					point("PFWInj_Ext", 58, typelabel.Enum16, "", "rw"),
+					point("PFWInjRvrt_PF", 59, typelabel.Uint16, "PF_SF", "rw"),
+					point("PFWInjRvrt_Ext", 60, typelabel.Enum16, "", "rw"),
+					point("PFWAbs_PF", 61, typelabel.Uint16, "PF_SF", "rw"),
+					point("PFWAbs_Ext", 62, typelabel.Enum16, "", "rw"),
+					point("PFWAbsRvrt_PF", 63, typelabel.Uint16, "PF_SF", "rw"),
+					point("PFWAbsRvrt_Ext", 64, typelabel.Enum16, "", "rw"),
+				},
+			},
+		},
+	})
+}
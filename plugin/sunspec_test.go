@@ -0,0 +1,30 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/andig/gosunspec/impl"
+	"github.com/andig/gosunspec/smdx"
+	"github.com/andig/gosunspec/typelen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSunspecModel704(t *testing.T) {
+	me := smdx.GetModel(704)
+	require.NotNil(t, me)
+
+	// points are contiguous and fill the model
+	var offset uint16
+	for _, p := range me.Blocks[0].Points {
+		assert.Equal(t, offset, p.Offset, p.Id)
+		offset += typelen.Length(p.Type)
+	}
+	assert.Equal(t, me.Length, offset)
+
+	b := impl.NewContiguousModel(me, me.Length, nil).MustBlock(0)
+	for _, id := range []string{"WMaxLimPctEna", "WMaxLimPct", "WMaxLimPct_SF"} {
+		_, err := b.Point(id)
+		assert.NoError(t, err, id)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	shiputil "github.com/enbility/ship-go/util"
 	spineapi "github.com/enbility/spine-go/api"
 	"github.com/enbility/spine-go/model"
+	"github.com/evcc-io/evcc/server/eebus/usecases"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/machine"
 )
@@ -47,9 +48,12 @@ type UseCasesEVSE struct {
 	OscEV  ucapi.CemOSCEVInterface
 }
 type UseCasesCS struct {
-	LPC  ucapi.CsLPCInterface
-	LPP  ucapi.CsLPPInterface
-	MGCP ucapi.MaMGCPInterface
+	LPC   ucapi.CsLPCInterface
+	LPP   ucapi.CsLPPInterface
+	MGCP  ucapi.MaMGCPInterface
+	MPC   usecases.MuMPCInterface    // consumption monitoring
+	GCP   usecases.GcpMGCPInterface  // grid connection point monitoring
+	OHPCF usecases.CemOHPCFInterface // heat pump compressor flexibility
 }
 
 type EEBus struct {
@@ -105,7 +109,7 @@ func NewServer(other Config) (*EEBus, error) {
 	configuration, err := eebusapi.NewConfiguration(
 		BrandName, BrandName, Model, serial,
 		model.DeviceTypeTypeEnergyManagementSystem,
-		[]model.EntityTypeType{model.EntityTypeTypeCEM},
+		[]model.EntityTypeType{model.EntityTypeTypeCEM, model.EntityTypeTypeGridConnectionPointOfPremises},
 		port, certificate, time.Second*4,
 	)
 	if err != nil {
@@ -138,6 +142,7 @@ func NewServer(other Config) (*EEBus, error) {
 	}
 
 	localEntity := c.service.LocalDevice().EntityForType(model.EntityTypeTypeCEM)
+	gcpEntity := c.service.LocalDevice().EntityForType(model.EntityTypeTypeGridConnectionPointOfPremises)
 
 	// evse
 	c.evseUC = UseCasesEVSE{
@@ -151,9 +156,12 @@ func NewServer(other Config) (*EEBus, error) {
 
 	// controllable system
 	c.csUC = UseCasesCS{
-		LPC:  lpc.NewLPC(localEntity, c.ucCallback),
-		LPP:  lpp.NewLPP(localEntity, c.ucCallback),
-		MGCP: mgcp.NewMGCP(localEntity, c.ucCallback),
+		LPC:   lpc.NewLPC(localEntity, c.ucCallback),
+		LPP:   lpp.NewLPP(localEntity, c.ucCallback),
+		MGCP:  mgcp.NewMGCP(localEntity, c.ucCallback),
+		MPC:   usecases.NewMPC(localEntity, c.ucCallback),
+		GCP:   usecases.NewMGCP(gcpEntity, c.ucCallback),
+		OHPCF: usecases.NewOHPCF(localEntity, c.ucCallback),
 	}
 
 	// register use cases
@@ -162,6 +170,7 @@ func NewServer(other Config) (*EEBus, error) {
 		c.evseUC.EvCem, c.evseUC.OpEV,
 		c.evseUC.OscEV, c.evseUC.EvSoc,
 		c.csUC.LPC, c.csUC.LPP, c.csUC.MGCP,
+		c.csUC.MPC, c.csUC.GCP, c.csUC.OHPCF,
 	} {
 		c.service.AddUseCase(uc)
 	}
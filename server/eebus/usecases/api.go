@@ -0,0 +1,60 @@
+package usecases
+
+//go:generate go tool mockgen -package usecases -destination mock.go github.com/evcc-io/evcc/server/eebus/usecases GcpMGCPInterface,CemOHPCFInterface
+
+import (
+	"time"
+
+	eebusapi "github.com/enbility/eebus-go/api"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// Monitor provides the measurements common to MPC and MGCP
+type Monitor interface {
+	// SetPower sets the momentary active power in W, positive values denote consumption
+	SetPower(power float64) error
+	// SetEnergyConsumed sets the total consumed energy in Wh
+	SetEnergyConsumed(energy float64) error
+	// SetCurrentPerPhase sets the momentary phase specific current in A
+	SetCurrentPerPhase(l1, l2, l3 float64) error
+	// SetVoltagePerPhase sets the momentary phase specific voltage in V
+	SetVoltagePerPhase(l1, l2, l3 float64) error
+}
+
+// MuMPCInterface is the monitored unit side of the Monitoring of Power Consumption use case
+type MuMPCInterface interface {
+	eebusapi.UseCaseInterface
+	Monitor
+
+	// SetPowerPerPhase sets the momentary phase specific active power in W
+	SetPowerPerPhase(l1, l2, l3 float64) error
+}
+
+// GcpMGCPInterface is the grid connection point side of the Monitoring of Grid Connection Point use case
+type GcpMGCPInterface interface {
+	eebusapi.UseCaseInterface
+	Monitor
+
+	// SetEnergyFeedIn sets the total energy fed into the grid in Wh
+	SetEnergyFeedIn(energy float64) error
+}
+
+// Flexibility is the power sequence a heat pump compressor offers for scheduling
+type Flexibility struct {
+	SequenceId   model.PowerSequenceIdType
+	State        model.PowerSequenceStateType
+	Power        float64       // expected power in W
+	Duration     time.Duration // expected run time
+	Controllable bool          // sequence can be started remotely
+}
+
+// CemOHPCFInterface is the energy manager side of the Optimization of Self-Consumption by Heat Pump Compressor Flexibility use case
+type CemOHPCFInterface interface {
+	eebusapi.UseCaseInterface
+
+	// Flexibility returns the power sequence currently offered by the compressor
+	Flexibility(entity spineapi.EntityRemoteInterface) (Flexibility, error)
+	// StartFlexibility schedules the offered power sequence to start immediately
+	StartFlexibility(entity spineapi.EntityRemoteInterface) error
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/usecases/usecase"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// MGCP implements the grid connection point actor of the Monitoring of Grid Connection Point use case.
+// It requires a separate local entity since its measurements would otherwise collide with MPC.
+type MGCP struct {
+	*usecase.UseCaseBase
+	*monitor
+
+	energyFeedIn []model.MeasurementIdType
+}
+
+var _ GcpMGCPInterface = (*MGCP)(nil)
+
+func NewMGCP(localEntity spineapi.EntityLocalInterface, eventCB api.EntityEventCallback) *MGCP {
+	validActorTypes := []model.UseCaseActorType{model.UseCaseActorTypeMonitoringAppliance}
+	validEntityTypes := []model.EntityTypeType{
+		model.EntityTypeTypeGridGuard,
+		model.EntityTypeTypeCEM,
+	}
+	useCaseScenarios := []api.UseCaseScenario{
+		{Scenario: model.UseCaseScenarioSupportType(2), Mandatory: true},  // power
+		{Scenario: model.UseCaseScenarioSupportType(3), Mandatory: true},  // energy feed-in
+		{Scenario: model.UseCaseScenarioSupportType(4), Mandatory: true},  // energy consumed
+		{Scenario: model.UseCaseScenarioSupportType(5), Mandatory: false}, // current
+		{Scenario: model.UseCaseScenarioSupportType(6), Mandatory: false}, // voltage
+	}
+
+	usecase := usecase.NewUseCaseBase(
+		localEntity,
+		model.UseCaseActorTypeGridConnectionPoint,
+		model.UseCaseNameTypeMonitoringOfGridConnectionPoint,
+		"1.0.0",
+		"release",
+		useCaseScenarios,
+		eventCB,
+		MGCPUseCaseSupportUpdate,
+		validActorTypes,
+		validEntityTypes,
+	)
+
+	return &MGCP{
+		UseCaseBase: usecase,
+		monitor:     &monitor{entity: localEntity},
+	}
+}
+
+func (e *MGCP) AddFeatures() {
+	e.addFeatures()
+
+	e.power = e.addMeasurement(model.MeasurementTypeTypePower, model.ScopeTypeTypeACPowerTotal, model.UnitOfMeasurementTypeW, model.ElectricalConnectionPhaseNameTypeAbc)
+	e.energyFeedIn = e.addMeasurement(model.MeasurementTypeTypeEnergy, model.ScopeTypeTypeGridFeedIn, model.UnitOfMeasurementTypeWh, model.ElectricalConnectionPhaseNameTypeAbc)
+	e.energyConsumed = e.addMeasurement(model.MeasurementTypeTypeEnergy, model.ScopeTypeTypeGridConsumption, model.UnitOfMeasurementTypeWh, model.ElectricalConnectionPhaseNameTypeAbc)
+	e.currentPerPhase = e.addMeasurement(model.MeasurementTypeTypeCurrent, model.ScopeTypeTypeACCurrent, model.UnitOfMeasurementTypeA, acPhases...)
+	e.voltagePerPhase = e.addMeasurement(model.MeasurementTypeTypeVoltage, model.ScopeTypeTypeACVoltage, model.UnitOfMeasurementTypeV, acPhases...)
+}
+
+// SetEnergyFeedIn implements the GcpMGCPInterface interface
+func (e *MGCP) SetEnergyFeedIn(energy float64) error {
+	return e.update(e.energyFeedIn, energy)
+}
@@ -0,0 +1,373 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/evcc-io/evcc/server/eebus/usecases (interfaces: GcpMGCPInterface,CemOHPCFInterface)
+//
+// Generated by this command:
+//
+//	mockgen -package usecases -destination mock.go github.com/evcc-io/evcc/server/eebus/usecases GcpMGCPInterface,CemOHPCFInterface
+//
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	reflect "reflect"
+
+	api "github.com/enbility/eebus-go/api"
+	api0 "github.com/enbility/spine-go/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGcpMGCPInterface is a mock of GcpMGCPInterface interface.
+type MockGcpMGCPInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGcpMGCPInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockGcpMGCPInterfaceMockRecorder is the mock recorder for MockGcpMGCPInterface.
+type MockGcpMGCPInterfaceMockRecorder struct {
+	mock *MockGcpMGCPInterface
+}
+
+// NewMockGcpMGCPInterface creates a new mock instance.
+func NewMockGcpMGCPInterface(ctrl *gomock.Controller) *MockGcpMGCPInterface {
+	mock := &MockGcpMGCPInterface{ctrl: ctrl}
+	mock.recorder = &MockGcpMGCPInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGcpMGCPInterface) EXPECT() *MockGcpMGCPInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddFeatures mocks base method.
+func (m *MockGcpMGCPInterface) AddFeatures() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddFeatures")
+}
+
+// AddFeatures indicates an expected call of AddFeatures.
+func (mr *MockGcpMGCPInterfaceMockRecorder) AddFeatures() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFeatures", reflect.TypeOf((*MockGcpMGCPInterface)(nil).AddFeatures))
+}
+
+// AddUseCase mocks base method.
+func (m *MockGcpMGCPInterface) AddUseCase() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddUseCase")
+}
+
+// AddUseCase indicates an expected call of AddUseCase.
+func (mr *MockGcpMGCPInterfaceMockRecorder) AddUseCase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUseCase", reflect.TypeOf((*MockGcpMGCPInterface)(nil).AddUseCase))
+}
+
+// AvailableScenariosForEntity mocks base method.
+func (m *MockGcpMGCPInterface) AvailableScenariosForEntity(entity api0.EntityRemoteInterface) []uint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailableScenariosForEntity", entity)
+	ret0, _ := ret[0].([]uint)
+	return ret0
+}
+
+// AvailableScenariosForEntity indicates an expected call of AvailableScenariosForEntity.
+func (mr *MockGcpMGCPInterfaceMockRecorder) AvailableScenariosForEntity(entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailableScenariosForEntity", reflect.TypeOf((*MockGcpMGCPInterface)(nil).AvailableScenariosForEntity), entity)
+}
+
+// IsCompatibleEntityType mocks base method.
+func (m *MockGcpMGCPInterface) IsCompatibleEntityType(entity api0.EntityRemoteInterface) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCompatibleEntityType", entity)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCompatibleEntityType indicates an expected call of IsCompatibleEntityType.
+func (mr *MockGcpMGCPInterfaceMockRecorder) IsCompatibleEntityType(entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCompatibleEntityType", reflect.TypeOf((*MockGcpMGCPInterface)(nil).IsCompatibleEntityType), entity)
+}
+
+// IsScenarioAvailableAtEntity mocks base method.
+func (m *MockGcpMGCPInterface) IsScenarioAvailableAtEntity(entity api0.EntityRemoteInterface, scenario uint) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsScenarioAvailableAtEntity", entity, scenario)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsScenarioAvailableAtEntity indicates an expected call of IsScenarioAvailableAtEntity.
+func (mr *MockGcpMGCPInterfaceMockRecorder) IsScenarioAvailableAtEntity(entity, scenario any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsScenarioAvailableAtEntity", reflect.TypeOf((*MockGcpMGCPInterface)(nil).IsScenarioAvailableAtEntity), entity, scenario)
+}
+
+// RemoteEntitiesScenarios mocks base method.
+func (m *MockGcpMGCPInterface) RemoteEntitiesScenarios() []api.RemoteEntityScenarios {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteEntitiesScenarios")
+	ret0, _ := ret[0].([]api.RemoteEntityScenarios)
+	return ret0
+}
+
+// RemoteEntitiesScenarios indicates an expected call of RemoteEntitiesScenarios.
+func (mr *MockGcpMGCPInterfaceMockRecorder) RemoteEntitiesScenarios() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteEntitiesScenarios", reflect.TypeOf((*MockGcpMGCPInterface)(nil).RemoteEntitiesScenarios))
+}
+
+// RemoveUseCase mocks base method.
+func (m *MockGcpMGCPInterface) RemoveUseCase() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveUseCase")
+}
+
+// RemoveUseCase indicates an expected call of RemoveUseCase.
+func (mr *MockGcpMGCPInterfaceMockRecorder) RemoveUseCase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUseCase", reflect.TypeOf((*MockGcpMGCPInterface)(nil).RemoveUseCase))
+}
+
+// SetCurrentPerPhase mocks base method.
+func (m *MockGcpMGCPInterface) SetCurrentPerPhase(l1, l2, l3 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCurrentPerPhase", l1, l2, l3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCurrentPerPhase indicates an expected call of SetCurrentPerPhase.
+func (mr *MockGcpMGCPInterfaceMockRecorder) SetCurrentPerPhase(l1, l2, l3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentPerPhase", reflect.TypeOf((*MockGcpMGCPInterface)(nil).SetCurrentPerPhase), l1, l2, l3)
+}
+
+// SetEnergyConsumed mocks base method.
+func (m *MockGcpMGCPInterface) SetEnergyConsumed(energy float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnergyConsumed", energy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEnergyConsumed indicates an expected call of SetEnergyConsumed.
+func (mr *MockGcpMGCPInterfaceMockRecorder) SetEnergyConsumed(energy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnergyConsumed", reflect.TypeOf((*MockGcpMGCPInterface)(nil).SetEnergyConsumed), energy)
+}
+
+// SetEnergyFeedIn mocks base method.
+func (m *MockGcpMGCPInterface) SetEnergyFeedIn(energy float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnergyFeedIn", energy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEnergyFeedIn indicates an expected call of SetEnergyFeedIn.
+func (mr *MockGcpMGCPInterfaceMockRecorder) SetEnergyFeedIn(energy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnergyFeedIn", reflect.TypeOf((*MockGcpMGCPInterface)(nil).SetEnergyFeedIn), energy)
+}
+
+// SetPower mocks base method.
+func (m *MockGcpMGCPInterface) SetPower(power float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPower", power)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPower indicates an expected call of SetPower.
+func (mr *MockGcpMGCPInterfaceMockRecorder) SetPower(power any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPower", reflect.TypeOf((*MockGcpMGCPInterface)(nil).SetPower), power)
+}
+
+// SetVoltagePerPhase mocks base method.
+func (m *MockGcpMGCPInterface) SetVoltagePerPhase(l1, l2, l3 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVoltagePerPhase", l1, l2, l3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVoltagePerPhase indicates an expected call of SetVoltagePerPhase.
+func (mr *MockGcpMGCPInterfaceMockRecorder) SetVoltagePerPhase(l1, l2, l3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVoltagePerPhase", reflect.TypeOf((*MockGcpMGCPInterface)(nil).SetVoltagePerPhase), l1, l2, l3)
+}
+
+// UpdateUseCaseAvailability mocks base method.
+func (m *MockGcpMGCPInterface) UpdateUseCaseAvailability(available bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateUseCaseAvailability", available)
+}
+
+// UpdateUseCaseAvailability indicates an expected call of UpdateUseCaseAvailability.
+func (mr *MockGcpMGCPInterfaceMockRecorder) UpdateUseCaseAvailability(available any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUseCaseAvailability", reflect.TypeOf((*MockGcpMGCPInterface)(nil).UpdateUseCaseAvailability), available)
+}
+
+// MockCemOHPCFInterface is a mock of CemOHPCFInterface interface.
+type MockCemOHPCFInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCemOHPCFInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCemOHPCFInterfaceMockRecorder is the mock recorder for MockCemOHPCFInterface.
+type MockCemOHPCFInterfaceMockRecorder struct {
+	mock *MockCemOHPCFInterface
+}
+
+// NewMockCemOHPCFInterface creates a new mock instance.
+func NewMockCemOHPCFInterface(ctrl *gomock.Controller) *MockCemOHPCFInterface {
+	mock := &MockCemOHPCFInterface{ctrl: ctrl}
+	mock.recorder = &MockCemOHPCFInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCemOHPCFInterface) EXPECT() *MockCemOHPCFInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddFeatures mocks base method.
+func (m *MockCemOHPCFInterface) AddFeatures() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddFeatures")
+}
+
+// AddFeatures indicates an expected call of AddFeatures.
+func (mr *MockCemOHPCFInterfaceMockRecorder) AddFeatures() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFeatures", reflect.TypeOf((*MockCemOHPCFInterface)(nil).AddFeatures))
+}
+
+// AddUseCase mocks base method.
+func (m *MockCemOHPCFInterface) AddUseCase() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddUseCase")
+}
+
+// AddUseCase indicates an expected call of AddUseCase.
+func (mr *MockCemOHPCFInterfaceMockRecorder) AddUseCase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUseCase", reflect.TypeOf((*MockCemOHPCFInterface)(nil).AddUseCase))
+}
+
+// AvailableScenariosForEntity mocks base method.
+func (m *MockCemOHPCFInterface) AvailableScenariosForEntity(entity api0.EntityRemoteInterface) []uint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailableScenariosForEntity", entity)
+	ret0, _ := ret[0].([]uint)
+	return ret0
+}
+
+// AvailableScenariosForEntity indicates an expected call of AvailableScenariosForEntity.
+func (mr *MockCemOHPCFInterfaceMockRecorder) AvailableScenariosForEntity(entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailableScenariosForEntity", reflect.TypeOf((*MockCemOHPCFInterface)(nil).AvailableScenariosForEntity), entity)
+}
+
+// Flexibility mocks base method.
+func (m *MockCemOHPCFInterface) Flexibility(entity api0.EntityRemoteInterface) (Flexibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flexibility", entity)
+	ret0, _ := ret[0].(Flexibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Flexibility indicates an expected call of Flexibility.
+func (mr *MockCemOHPCFInterfaceMockRecorder) Flexibility(entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flexibility", reflect.TypeOf((*MockCemOHPCFInterface)(nil).Flexibility), entity)
+}
+
+// IsCompatibleEntityType mocks base method.
+func (m *MockCemOHPCFInterface) IsCompatibleEntityType(entity api0.EntityRemoteInterface) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCompatibleEntityType", entity)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCompatibleEntityType indicates an expected call of IsCompatibleEntityType.
+func (mr *MockCemOHPCFInterfaceMockRecorder) IsCompatibleEntityType(entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCompatibleEntityType", reflect.TypeOf((*MockCemOHPCFInterface)(nil).IsCompatibleEntityType), entity)
+}
+
+// IsScenarioAvailableAtEntity mocks base method.
+func (m *MockCemOHPCFInterface) IsScenarioAvailableAtEntity(entity api0.EntityRemoteInterface, scenario uint) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsScenarioAvailableAtEntity", entity, scenario)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsScenarioAvailableAtEntity indicates an expected call of IsScenarioAvailableAtEntity.
+func (mr *MockCemOHPCFInterfaceMockRecorder) IsScenarioAvailableAtEntity(entity, scenario any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsScenarioAvailableAtEntity", reflect.TypeOf((*MockCemOHPCFInterface)(nil).IsScenarioAvailableAtEntity), entity, scenario)
+}
+
+// RemoteEntitiesScenarios mocks base method.
+func (m *MockCemOHPCFInterface) RemoteEntitiesScenarios() []api.RemoteEntityScenarios {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteEntitiesScenarios")
+	ret0, _ := ret[0].([]api.RemoteEntityScenarios)
+	return ret0
+}
+
+// RemoteEntitiesScenarios indicates an expected call of RemoteEntitiesScenarios.
+func (mr *MockCemOHPCFInterfaceMockRecorder) RemoteEntitiesScenarios() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteEntitiesScenarios", reflect.TypeOf((*MockCemOHPCFInterface)(nil).RemoteEntitiesScenarios))
+}
+
+// RemoveUseCase mocks base method.
+func (m *MockCemOHPCFInterface) RemoveUseCase() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveUseCase")
+}
+
+// RemoveUseCase indicates an expected call of RemoveUseCase.
+func (mr *MockCemOHPCFInterfaceMockRecorder) RemoveUseCase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUseCase", reflect.TypeOf((*MockCemOHPCFInterface)(nil).RemoveUseCase))
+}
+
+// StartFlexibility mocks base method.
+func (m *MockCemOHPCFInterface) StartFlexibility(entity api0.EntityRemoteInterface) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartFlexibility", entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartFlexibility indicates an expected call of StartFlexibility.
+func (mr *MockCemOHPCFInterfaceMockRecorder) StartFlexibility(entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartFlexibility", reflect.TypeOf((*MockCemOHPCFInterface)(nil).StartFlexibility), entity)
+}
+
+// UpdateUseCaseAvailability mocks base method.
+func (m *MockCemOHPCFInterface) UpdateUseCaseAvailability(available bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateUseCaseAvailability", available)
+}
+
+// UpdateUseCaseAvailability indicates an expected call of UpdateUseCaseAvailability.
+func (mr *MockCemOHPCFInterfaceMockRecorder) UpdateUseCaseAvailability(available any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUseCaseAvailability", reflect.TypeOf((*MockCemOHPCFInterface)(nil).UpdateUseCaseAvailability), available)
+}
@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	eebusapi "github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/server"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/samber/lo"
+)
+
+// electricalConnectionId is shared with the LPC/LPP characteristics on the same entity
+const electricalConnectionId = model.ElectricalConnectionIdType(0)
+
+var acPhases = []model.ElectricalConnectionPhaseNameType{
+	model.ElectricalConnectionPhaseNameTypeA,
+	model.ElectricalConnectionPhaseNameTypeB,
+	model.ElectricalConnectionPhaseNameTypeC,
+}
+
+// monitor publishes measurements using the measurement and electrical connection server features of the local entity
+type monitor struct {
+	entity spineapi.EntityLocalInterface
+
+	power, energyConsumed, currentPerPhase, voltagePerPhase []model.MeasurementIdType
+}
+
+// addFeatures adds the server features and the electrical connection description
+func (m *monitor) addFeatures() {
+	f := m.entity.GetOrAddFeature(model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+	f.AddFunctionType(model.FunctionTypeMeasurementDescriptionListData, true, false)
+	f.AddFunctionType(model.FunctionTypeMeasurementListData, true, false)
+
+	f = m.entity.GetOrAddFeature(model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	f.AddFunctionType(model.FunctionTypeElectricalConnectionDescriptionListData, true, false)
+	f.AddFunctionType(model.FunctionTypeElectricalConnectionParameterDescriptionListData, true, false)
+
+	if ec, err := server.NewElectricalConnection(m.entity); err == nil {
+		_ = ec.AddDescription(model.ElectricalConnectionDescriptionDataType{
+			ElectricalConnectionId:  lo.ToPtr(electricalConnectionId),
+			PowerSupplyType:         lo.ToPtr(model.ElectricalConnectionVoltageTypeTypeAc),
+			AcConnectedPhases:       lo.ToPtr(uint(3)),
+			PositiveEnergyDirection: lo.ToPtr(model.EnergyDirectionTypeConsume),
+		})
+	}
+}
+
+// addMeasurement adds a measurement description for each of the given phases and returns the measurement ids
+func (m *monitor) addMeasurement(typ model.MeasurementTypeType, scope model.ScopeTypeType, unit model.UnitOfMeasurementType, phases ...model.ElectricalConnectionPhaseNameType) []model.MeasurementIdType {
+	ms, err := server.NewMeasurement(m.entity)
+	if err != nil {
+		return nil
+	}
+
+	ec, err := server.NewElectricalConnection(m.entity)
+	if err != nil {
+		return nil
+	}
+
+	var res []model.MeasurementIdType
+
+	for _, phase := range phases {
+		id := ms.AddDescription(model.MeasurementDescriptionDataType{
+			MeasurementType: lo.ToPtr(typ),
+			CommodityType:   lo.ToPtr(model.CommodityTypeTypeElectricity),
+			Unit:            lo.ToPtr(unit),
+			ScopeType:       lo.ToPtr(scope),
+		})
+		if id == nil {
+			return nil
+		}
+
+		param := model.ElectricalConnectionParameterDescriptionDataType{
+			ElectricalConnectionId: lo.ToPtr(electricalConnectionId),
+			MeasurementId:          id,
+			VoltageType:            lo.ToPtr(model.ElectricalConnectionVoltageTypeTypeAc),
+			AcMeasuredPhases:       lo.ToPtr(phase),
+		}
+
+		switch typ {
+		case model.MeasurementTypeTypePower:
+			param.AcMeasurementType = lo.ToPtr(model.ElectricalConnectionAcMeasurementTypeTypeReal)
+		case model.MeasurementTypeTypeVoltage:
+			param.AcMeasuredInReferenceTo = lo.ToPtr(model.ElectricalConnectionPhaseNameTypeNeutral)
+		}
+
+		if typ != model.MeasurementTypeTypeEnergy {
+			param.AcMeasurementVariant = lo.ToPtr(model.ElectricalConnectionMeasurandVariantTypeRms)
+		}
+
+		if ec.AddParameterDescription(param) == nil {
+			return nil
+		}
+
+		res = append(res, *id)
+	}
+
+	return res
+}
+
+// update sets the measurement values for the given ids
+func (m *monitor) update(ids []model.MeasurementIdType, values ...float64) error {
+	if len(ids) == 0 || len(ids) != len(values) {
+		return eebusapi.ErrDataNotAvailable
+	}
+
+	ms, err := server.NewMeasurement(m.entity)
+	if err != nil {
+		return err
+	}
+
+	ts := model.NewAbsoluteOrRelativeTimeTypeFromTime(time.Now())
+
+	var errs []error
+	for i, id := range ids {
+		errs = append(errs, ms.UpdateDataForId(model.MeasurementDataType{
+			ValueType:  lo.ToPtr(model.MeasurementValueTypeTypeValue),
+			Timestamp:  ts,
+			Value:      model.NewScaledNumberType(values[i]),
+			ValueState: lo.ToPtr(model.MeasurementValueStateTypeNormal),
+		}, nil, id))
+	}
+
+	return errors.Join(errs...)
+}
+
+// SetPower implements the Monitor interface
+func (m *monitor) SetPower(power float64) error {
+	return m.update(m.power, power)
+}
+
+// SetEnergyConsumed implements the Monitor interface
+func (m *monitor) SetEnergyConsumed(energy float64) error {
+	return m.update(m.energyConsumed, energy)
+}
+
+// SetCurrentPerPhase implements the Monitor interface
+func (m *monitor) SetCurrentPerPhase(l1, l2, l3 float64) error {
+	return m.update(m.currentPerPhase, l1, l2, l3)
+}
+
+// SetVoltagePerPhase implements the Monitor interface
+func (m *monitor) SetVoltagePerPhase(l1, l2, l3 float64) error {
+	return m.update(m.voltagePerPhase, l1, l2, l3)
+}
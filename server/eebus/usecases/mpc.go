@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/usecases/usecase"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// MPC implements the monitored unit actor of the Monitoring of Power Consumption use case
+type MPC struct {
+	*usecase.UseCaseBase
+	*monitor
+
+	powerPerPhase []model.MeasurementIdType
+}
+
+var _ MuMPCInterface = (*MPC)(nil)
+
+func NewMPC(localEntity spineapi.EntityLocalInterface, eventCB api.EntityEventCallback) *MPC {
+	validActorTypes := []model.UseCaseActorType{model.UseCaseActorTypeMonitoringAppliance}
+	validEntityTypes := []model.EntityTypeType{
+		model.EntityTypeTypeGridGuard,
+		model.EntityTypeTypeCEM,
+	}
+	useCaseScenarios := []api.UseCaseScenario{
+		{Scenario: model.UseCaseScenarioSupportType(1), Mandatory: true},  // power
+		{Scenario: model.UseCaseScenarioSupportType(2), Mandatory: false}, // energy
+		{Scenario: model.UseCaseScenarioSupportType(3), Mandatory: false}, // current
+		{Scenario: model.UseCaseScenarioSupportType(4), Mandatory: false}, // voltage
+	}
+
+	usecase := usecase.NewUseCaseBase(
+		localEntity,
+		model.UseCaseActorTypeMonitoredUnit,
+		model.UseCaseNameTypeMonitoringOfPowerConsumption,
+		"1.0.0",
+		"release",
+		useCaseScenarios,
+		eventCB,
+		MPCUseCaseSupportUpdate,
+		validActorTypes,
+		validEntityTypes,
+	)
+
+	return &MPC{
+		UseCaseBase: usecase,
+		monitor:     &monitor{entity: localEntity},
+	}
+}
+
+func (e *MPC) AddFeatures() {
+	e.addFeatures()
+
+	// total power must be added first to match the LPC/LPP characteristic parameter id
+	e.power = e.addMeasurement(model.MeasurementTypeTypePower, model.ScopeTypeTypeACPowerTotal, model.UnitOfMeasurementTypeW, model.ElectricalConnectionPhaseNameTypeAbc)
+	e.powerPerPhase = e.addMeasurement(model.MeasurementTypeTypePower, model.ScopeTypeTypeACPower, model.UnitOfMeasurementTypeW, acPhases...)
+	e.energyConsumed = e.addMeasurement(model.MeasurementTypeTypeEnergy, model.ScopeTypeTypeACEnergyConsumed, model.UnitOfMeasurementTypeWh, model.ElectricalConnectionPhaseNameTypeAbc)
+	e.currentPerPhase = e.addMeasurement(model.MeasurementTypeTypeCurrent, model.ScopeTypeTypeACCurrent, model.UnitOfMeasurementTypeA, acPhases...)
+	e.voltagePerPhase = e.addMeasurement(model.MeasurementTypeTypeVoltage, model.ScopeTypeTypeACVoltage, model.UnitOfMeasurementTypeV, acPhases...)
+}
+
+// SetPowerPerPhase implements the MuMPCInterface interface
+func (e *MPC) SetPowerPerPhase(l1, l2, l3 float64) error {
+	return e.update(e.powerPerPhase, l1, l2, l3)
+}
@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/client"
+	"github.com/enbility/eebus-go/usecases/usecase"
+	"github.com/enbility/ship-go/logging"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/spine"
+)
+
+// OHPCF implements the energy manager actor of the Optimization of Self-Consumption by Heat Pump Compressor Flexibility use case
+type OHPCF struct {
+	*usecase.UseCaseBase
+}
+
+var _ CemOHPCFInterface = (*OHPCF)(nil)
+
+func NewOHPCF(localEntity spineapi.EntityLocalInterface, eventCB api.EntityEventCallback) *OHPCF {
+	validActorTypes := []model.UseCaseActorType{model.UseCaseActorTypeCompressor}
+	validEntityTypes := []model.EntityTypeType{
+		model.EntityTypeTypeCompressor,
+		model.EntityTypeTypeHeatPumpAppliance,
+	}
+	useCaseScenarios := []api.UseCaseScenario{
+		{
+			Scenario:       model.UseCaseScenarioSupportType(1),
+			Mandatory:      true,
+			ServerFeatures: []model.FeatureTypeType{model.FeatureTypeTypeSmartEnergyManagementPs},
+		},
+	}
+
+	usecase := usecase.NewUseCaseBase(
+		localEntity,
+		model.UseCaseActorTypeCEM,
+		model.UseCaseNameTypeOptimizationOfSelfConsumptionByHeatPumpCompressorFlexibility,
+		"1.0.0",
+		"release",
+		useCaseScenarios,
+		eventCB,
+		OHPCFUseCaseSupportUpdate,
+		validActorTypes,
+		validEntityTypes,
+	)
+
+	uc := &OHPCF{
+		UseCaseBase: usecase,
+	}
+
+	_ = spine.Events.Subscribe(uc)
+
+	return uc
+}
+
+func (e *OHPCF) AddFeatures() {
+	_ = e.LocalEntity.GetOrAddFeature(model.FeatureTypeTypeSmartEnergyManagementPs, model.RoleTypeClient)
+}
+
+// HandleEvent handles SPINE events
+func (e *OHPCF) HandleEvent(payload spineapi.EventPayload) {
+	if !e.IsCompatibleEntityType(payload.Entity) {
+		return
+	}
+
+	if payload.EventType == spineapi.EventTypeEntityChange {
+		switch payload.ChangeType {
+		case spineapi.ElementChangeAdd:
+			e.deviceConnected(payload.Entity)
+		case spineapi.ElementChangeRemove:
+			e.EventCB(payload.Ski, payload.Device, payload.Entity, OHPCFCompressorDisconnected)
+		}
+		return
+	}
+
+	if payload.EventType != spineapi.EventTypeDataChange || payload.ChangeType != spineapi.ElementChangeUpdate {
+		return
+	}
+
+	if _, ok := payload.Data.(*model.SmartEnergyManagementPsDataType); ok {
+		e.EventCB(payload.Ski, payload.Device, payload.Entity, OHPCFDataUpdateFlexibility)
+	}
+}
+
+// deviceConnected subscribes to the compressor's power sequences
+func (e *OHPCF) deviceConnected(entity spineapi.EntityRemoteInterface) {
+	ps, err := client.NewSmartEnergyManagementPs(e.LocalEntity, entity)
+	if err != nil {
+		return
+	}
+
+	if !ps.HasSubscription() {
+		if _, err := ps.Subscribe(); err != nil {
+			logging.Log().Error(err)
+		}
+	}
+
+	if _, err := ps.RequestData(); err != nil {
+		logging.Log().Error(err)
+	}
+}
+
+// powerSequence returns the first power sequence offered by the compressor
+func (e *OHPCF) powerSequence(entity spineapi.EntityRemoteInterface) (*client.SmartEnergyManagementPs, *model.SmartEnergyManagementPsPowerSequenceType, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return nil, nil, api.ErrNoCompatibleEntity
+	}
+
+	ps, err := client.NewSmartEnergyManagementPs(e.LocalEntity, entity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := ps.GetData()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, alt := range data.Alternatives {
+		for _, seq := range alt.PowerSequence {
+			if seq.Description != nil && seq.Description.SequenceId != nil {
+				return ps, &seq, nil
+			}
+		}
+	}
+
+	return nil, nil, api.ErrDataNotAvailable
+}
+
+// Flexibility implements the CemOHPCFInterface interface
+func (e *OHPCF) Flexibility(entity spineapi.EntityRemoteInterface) (Flexibility, error) {
+	_, seq, err := e.powerSequence(entity)
+	if err != nil {
+		return Flexibility{}, err
+	}
+
+	return flexibility(seq), nil
+}
+
+// flexibility returns the expected power and run time of a power sequence
+func flexibility(seq *model.SmartEnergyManagementPsPowerSequenceType) Flexibility {
+	res := Flexibility{
+		SequenceId: *seq.Description.SequenceId,
+	}
+
+	if seq.State != nil {
+		if seq.State.State != nil {
+			res.State = *seq.State.State
+		}
+		if seq.State.SequenceRemoteControllable != nil {
+			res.Controllable = *seq.State.SequenceRemoteControllable
+		}
+	}
+
+	for _, slot := range seq.PowerTimeSlot {
+		if slot.Schedule != nil && slot.Schedule.DefaultDuration != nil {
+			if d, err := slot.Schedule.DefaultDuration.GetTimeDuration(); err == nil {
+				res.Duration += d
+			}
+		}
+
+		if slot.ValueList == nil {
+			continue
+		}
+
+		for _, v := range slot.ValueList.Value {
+			if v.Value == nil || v.ValueType == nil {
+				continue
+			}
+
+			switch *v.ValueType {
+			case model.PowerTimeSlotValueTypeTypePower, model.PowerTimeSlotValueTypeTypePowerExpectedValue, model.PowerTimeSlotValueTypeTypePowerMax:
+				res.Power = max(res.Power, v.Value.GetValue())
+			}
+		}
+	}
+
+	return res
+}
+
+// StartFlexibility implements the CemOHPCFInterface interface
+func (e *OHPCF) StartFlexibility(entity spineapi.EntityRemoteInterface) error {
+	ps, seq, err := e.powerSequence(entity)
+	if err != nil {
+		return err
+	}
+
+	if seq.State == nil || seq.State.SequenceRemoteControllable == nil || !*seq.State.SequenceRemoteControllable {
+		return api.ErrNotSupported
+	}
+
+	data := &model.SmartEnergyManagementPsDataType{
+		Alternatives: []model.SmartEnergyManagementPsAlternativesType{{
+			PowerSequence: []model.SmartEnergyManagementPsPowerSequenceType{{
+				Description: &model.PowerSequenceDescriptionDataType{
+					SequenceId: seq.Description.SequenceId,
+				},
+				Schedule: &model.PowerSequenceScheduleDataType{
+					SequenceId: seq.Description.SequenceId,
+					StartTime:  model.NewAbsoluteOrRelativeTimeTypeFromDuration(0),
+				},
+			}},
+		}},
+	}
+
+	_, err = ps.WriteData(data)
+	return err
+}
@@ -0,0 +1,26 @@
+package usecases
+
+import "github.com/enbility/eebus-go/api"
+
+const (
+	// Update of the list of remote entities supporting the MPC use case
+	MPCUseCaseSupportUpdate api.EventType = "mu-mpc-UseCaseSupportUpdate"
+
+	// Update of the list of remote entities supporting the MGCP use case
+	MGCPUseCaseSupportUpdate api.EventType = "gcp-mgcp-UseCaseSupportUpdate"
+
+	// Update of the list of remote entities supporting the OHPCF use case
+	OHPCFUseCaseSupportUpdate api.EventType = "cem-ohpcf-UseCaseSupportUpdate"
+
+	// Power sequence offered by the compressor was updated
+	//
+	// Use `Flexibility` to get the current data
+	//
+	// Use Case OHPCF, Scenario 1
+	OHPCFDataUpdateFlexibility api.EventType = "cem-ohpcf-DataUpdateFlexibility"
+
+	// The compressor entity was removed
+	//
+	// Use Case OHPCF, Scenario 1
+	OHPCFCompressorDisconnected api.EventType = "cem-ohpcf-CompressorDisconnected"
+)
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	eebusapi "github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/server"
+	spineapi "github.com/enbility/spine-go/api"
+	spinemocks "github.com/enbility/spine-go/mocks"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/spine"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func localEntity(t *testing.T, typ model.EntityTypeType) spineapi.EntityLocalInterface {
+	t.Helper()
+
+	device := spine.NewDeviceLocal("brand", "model", "serial", "code", "address", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	entity := spine.NewEntityLocal(device, typ, []model.AddressEntityType{1}, 4*time.Second)
+	device.AddEntity(entity)
+
+	return entity
+}
+
+func measurements(t *testing.T, entity spineapi.EntityLocalInterface, typ model.MeasurementTypeType, scope model.ScopeTypeType) map[model.ElectricalConnectionPhaseNameType]float64 {
+	t.Helper()
+
+	ms, err := server.NewMeasurement(entity)
+	require.NoError(t, err)
+
+	ec, err := server.NewElectricalConnection(entity)
+	require.NoError(t, err)
+
+	data, err := ms.GetDataForFilter(model.MeasurementDescriptionDataType{
+		MeasurementType: lo.ToPtr(typ),
+		CommodityType:   lo.ToPtr(model.CommodityTypeTypeElectricity),
+		ScopeType:       lo.ToPtr(scope),
+	})
+	require.NoError(t, err)
+
+	res := make(map[model.ElectricalConnectionPhaseNameType]float64)
+	for _, d := range data {
+		param, err := ec.GetParameterDescriptionsForFilter(model.ElectricalConnectionParameterDescriptionDataType{
+			MeasurementId: d.MeasurementId,
+		})
+		require.NoError(t, err)
+		require.Len(t, param, 1)
+
+		desc, err := ec.GetDescriptionForParameterDescriptionFilter(model.ElectricalConnectionParameterDescriptionDataType{
+			MeasurementId: d.MeasurementId,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, model.EnergyDirectionTypeConsume, *desc.PositiveEnergyDirection)
+
+		res[*param[0].AcMeasuredPhases] = d.Value.GetValue()
+	}
+
+	return res
+}
+
+func TestMPC(t *testing.T) {
+	entity := localEntity(t, model.EntityTypeTypeCEM)
+
+	uc := NewMPC(entity, nil)
+	uc.AddFeatures()
+
+	require.NoError(t, uc.SetPower(4200))
+	require.NoError(t, uc.SetPowerPerPhase(1000, 1200, 2000))
+	require.NoError(t, uc.SetEnergyConsumed(12345))
+	require.NoError(t, uc.SetCurrentPerPhase(4, 5, 6))
+	require.NoError(t, uc.SetVoltagePerPhase(230, 231, 232))
+
+	// total power is the first parameter as referenced by LPC/LPP characteristics
+	ec, err := server.NewElectricalConnection(entity)
+	require.NoError(t, err)
+	param, err := ec.GetParameterDescriptionsForFilter(model.ElectricalConnectionParameterDescriptionDataType{
+		ParameterId: lo.ToPtr(model.ElectricalConnectionParameterIdType(0)),
+	})
+	require.NoError(t, err)
+	require.Len(t, param, 1)
+	assert.Equal(t, model.ElectricalConnectionPhaseNameTypeAbc, *param[0].AcMeasuredPhases)
+
+	abc := model.ElectricalConnectionPhaseNameTypeAbc
+	a, b, c := model.ElectricalConnectionPhaseNameTypeA, model.ElectricalConnectionPhaseNameTypeB, model.ElectricalConnectionPhaseNameTypeC
+
+	assert.Equal(t, map[model.ElectricalConnectionPhaseNameType]float64{abc: 4200}, measurements(t, entity, model.MeasurementTypeTypePower, model.ScopeTypeTypeACPowerTotal))
+	assert.Equal(t, map[model.ElectricalConnectionPhaseNameType]float64{a: 1000, b: 1200, c: 2000}, measurements(t, entity, model.MeasurementTypeTypePower, model.ScopeTypeTypeACPower))
+	assert.Equal(t, map[model.ElectricalConnectionPhaseNameType]float64{abc: 12345}, measurements(t, entity, model.MeasurementTypeTypeEnergy, model.ScopeTypeTypeACEnergyConsumed))
+	assert.Equal(t, map[model.ElectricalConnectionPhaseNameType]float64{a: 4, b: 5, c: 6}, measurements(t, entity, model.MeasurementTypeTypeCurrent, model.ScopeTypeTypeACCurrent))
+	assert.Equal(t, map[model.ElectricalConnectionPhaseNameType]float64{a: 230, b: 231, c: 232}, measurements(t, entity, model.MeasurementTypeTypeVoltage, model.ScopeTypeTypeACVoltage))
+}
+
+func TestMGCP(t *testing.T) {
+	entity := localEntity(t, model.EntityTypeTypeGridConnectionPointOfPremises)
+
+	uc := NewMGCP(entity, nil)
+	uc.AddFeatures()
+
+	require.NoError(t, uc.SetPower(-3000))
+	require.NoError(t, uc.SetEnergyConsumed(1000))
+	require.NoError(t, uc.SetEnergyFeedIn(2000))
+
+	abc := model.ElectricalConnectionPhaseNameTypeAbc
+
+	assert.Equal(t, map[model.ElectricalConnectionPhaseNameType]float64{abc: -3000}, measurements(t, entity, model.MeasurementTypeTypePower, model.ScopeTypeTypeACPowerTotal))
+	assert.Equal(t, map[model.ElectricalConnectionPhaseNameType]float64{abc: 1000}, measurements(t, entity, model.MeasurementTypeTypeEnergy, model.ScopeTypeTypeGridConsumption))
+	assert.Equal(t, map[model.ElectricalConnectionPhaseNameType]float64{abc: 2000}, measurements(t, entity, model.MeasurementTypeTypeEnergy, model.ScopeTypeTypeGridFeedIn))
+}
+
+func TestOHPCFFlexibility(t *testing.T) {
+	slot := func(d time.Duration, power float64) model.SmartEnergyManagementPsPowerTimeSlotType {
+		return model.SmartEnergyManagementPsPowerTimeSlotType{
+			Schedule: &model.PowerTimeSlotScheduleDataType{
+				DefaultDuration: model.NewDurationType(d),
+			},
+			ValueList: &model.SmartEnergyManagementPsPowerTimeSlotValueListType{
+				Value: []model.PowerTimeSlotValueDataType{{
+					ValueType: lo.ToPtr(model.PowerTimeSlotValueTypeTypePowerExpectedValue),
+					Value:     model.NewScaledNumberType(power),
+				}},
+			},
+		}
+	}
+
+	res := flexibility(&model.SmartEnergyManagementPsPowerSequenceType{
+		Description: &model.PowerSequenceDescriptionDataType{
+			SequenceId: lo.ToPtr(model.PowerSequenceIdType(1)),
+		},
+		State: &model.PowerSequenceStateDataType{
+			State:                      lo.ToPtr(model.PowerSequenceStateTypeInactive),
+			SequenceRemoteControllable: lo.ToPtr(true),
+		},
+		PowerTimeSlot: []model.SmartEnergyManagementPsPowerTimeSlotType{
+			slot(10*time.Minute, 1200),
+			slot(20*time.Minute, 1800),
+		},
+	})
+
+	assert.Equal(t, Flexibility{
+		SequenceId:   1,
+		State:        model.PowerSequenceStateTypeInactive,
+		Power:        1800,
+		Duration:     30 * time.Minute,
+		Controllable: true,
+	}, res)
+}
+
+func TestOHPCFEvents(t *testing.T) {
+	entity := localEntity(t, model.EntityTypeTypeCEM)
+
+	var events []eebusapi.EventType
+	uc := NewOHPCF(entity, func(_ string, _ spineapi.DeviceRemoteInterface, _ spineapi.EntityRemoteInterface, event eebusapi.EventType) {
+		events = append(events, event)
+	})
+	uc.AddFeatures()
+
+	compressor := spinemocks.NewEntityRemoteInterface(t)
+	compressor.EXPECT().EntityType().Return(model.EntityTypeTypeCompressor).Maybe()
+
+	other := spinemocks.NewEntityRemoteInterface(t)
+	other.EXPECT().EntityType().Return(model.EntityTypeTypeEV).Maybe()
+
+	// incompatible entities are rejected
+	_, err := uc.Flexibility(other)
+	assert.ErrorIs(t, err, eebusapi.ErrNoCompatibleEntity)
+	assert.ErrorIs(t, uc.StartFlexibility(other), eebusapi.ErrNoCompatibleEntity)
+
+	// compressor removal is reported
+	for _, e := range []spineapi.EntityRemoteInterface{compressor, other} {
+		uc.HandleEvent(spineapi.EventPayload{
+			EventType:  spineapi.EventTypeEntityChange,
+			ChangeType: spineapi.ElementChangeRemove,
+			Entity:     e,
+		})
+	}
+	assert.Equal(t, []eebusapi.EventType{OHPCFCompressorDisconnected}, events)
+}
@@ -136,12 +136,18 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 		"batterygridchargedelete": {"DELETE", "/batterygridchargelimit", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
 		"batterymode":             {"POST", "/batterymode/{value:[a-z]+}", updateBatteryMode(site)},
 		"batterymodedelete":       {"DELETE", "/batterymode", updateBatteryMode(site)},
+		"batteryoptimizer":        {"POST", "/batteryoptimizer/{value:[01truefalse]+}", boolHandler(site.SetBatteryOptimizer, site.GetBatteryOptimizer)},
+		"batteryschedule":         {"GET", "/batteryschedule", getHandler(site.GetBatterySchedule)},
+		"batteryscheduleoverride": {"POST", "/batteryschedule/{time:[0-9TZ:.+-]+}/{value:[a-z]+}", updateBatteryScheduleOverride(site)},
+		"batteryscheduledelete":   {"DELETE", "/batteryschedule/{time:[0-9TZ:.+-]+}", updateBatteryScheduleOverride(site)},
+		"circuits":                {"GET", "/circuits", getHandler(site.GetCircuitStats)},
 		"prioritysoc":             {"POST", "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc)},
 		"residualpower":           {"POST", "/residualpower/{value:-?[0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower)},
 		"smartcost":               {"POST", "/smartcostlimit/{value:-?[0-9.]+}", updateSmartCostLimit(site)},
 		"smartcostdelete":         {"DELETE", "/smartcostlimit", updateSmartCostLimit(site)},
 		"tariff":                  {"GET", "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
 		"sessions":                {"GET", "/sessions", sessionHandler},
+		"sessionreport":           {"GET", "/sessions/report", sessionReportHandler},
 		"updatesession":           {"PUT", "/session/{id:[0-9]+}", updateSessionHandler},
 		"deletesession":           {"DELETE", "/session/{id:[0-9]+}", deleteSessionHandler},
 		"telemetry":               {"GET", "/settings/telemetry", getHandler(telemetry.Enabled)},
@@ -159,6 +165,7 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 		"plan":           {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc/{value:[0-9]+}/{time:[0-9TZ:.+-]+}", planSocHandler(site)},
 		"plan2":          {"DELETE", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc", planSocRemoveHandler(site)},
 		"repeatingPlans": {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/repeating", addRepeatingPlansHandler(site)},
+		"history":        {"GET", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/history", vehicleHistoryHandler(site)},
 
 		// config ui
 		// "mode":       {"POST", "/mode/{value:[a-z]+}", chargeModeHandler(v)},
@@ -184,10 +191,12 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 			"maxcurrent":           {"POST", "/maxcurrent/{value:[0-9.]+}", floatHandler(lp.SetMaxCurrent, lp.GetMaxCurrent)},
 			"phases":               {"POST", "/phases/{value:[0-9]+}", intHandler(lp.SetPhasesConfigured, lp.GetPhasesConfigured)},
 			"plan":                 {"GET", "/plan", planHandler(lp)},
-			"staticPlanPreview":    {"GET", "/plan/static/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", staticPlanPreviewHandler(lp)},
+			"staticPlanPreview":    {"GET", "/plan/static/preview/{type:(?:soc|energy|temperature)}/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", staticPlanPreviewHandler(lp)},
 			"repeatingPlanPreview": {"GET", "/plan/repeating/preview/{soc:[0-9]+}/{weekdays:[0-6,]+}/{time:[0-2][0-9]:[0-5][0-9]}/{tz:[a-zA-Z0-9_./:-]+}", repeatingPlanPreviewHandler(lp)},
 			"planenergy":           {"POST", "/plan/energy/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", planEnergyHandler(lp)},
 			"planenergy2":          {"DELETE", "/plan/energy", planRemoveHandler(lp)},
+			"plantemperature":      {"POST", "/plan/temperature/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", planTemperatureHandler(lp)},
+			"plantemperature2":     {"DELETE", "/plan/temperature", planRemoveHandler(lp)},
 			"vehicle":              {"POST", "/vehicle/{name:[a-zA-Z0-9_.:-]+}", vehicleSelectHandler(site, lp)},
 			"vehicle2":             {"DELETE", "/vehicle", vehicleRemoveHandler(lp)},
 			"vehicleDetect":        {"PATCH", "/vehicle", vehicleDetectHandler(lp)},
@@ -276,6 +285,10 @@ func (s *HTTPd) RegisterSystemHandler(site *core.Site, valueChan chan<- util.Par
 			"interval":           {"POST", "/interval/{value:[0-9.]+}", settingsSetDurationHandler(keys.Interval)},
 			"updatesponsortoken": {"POST", "/sponsortoken", updateSponsortokenHandler},
 			"deletesponsortoken": {"DELETE", "/sponsortoken", deleteSponsorTokenHandler},
+			"users":              {"GET", "/users", usersHandler},
+			"newuser":            {"POST", "/users", newUserHandler},
+			"updateuser":         {"PUT", "/users/{id:[0-9]+}", updateUserHandler},
+			"deleteuser":         {"DELETE", "/users/{id:[0-9]+}", deleteUserHandler},
 		}
 
 		// yaml handlers
@@ -346,4 +359,42 @@ func (s *HTTPd) RegisterSystemHandler(site *core.Site, valueChan chan<- util.Par
 			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
 		}
 	}
+
+	{ // api/ocpp/files
+		// accessed by the stations using the random file token
+		api := api.PathPrefix("/ocpp/files").Subrouter()
+
+		routes := map[string]route{
+			"download":       {"GET", "/{token:[0-9a-f]+}/{name}", ocppFileDownloadHandler},
+			"upload":         {"POST", "/{token:[0-9a-f]+}", ocppFileUploadHandler},
+			"uploadnamed":    {"POST", "/{token:[0-9a-f]+}/{name}", ocppFileUploadHandler},
+			"uploadput":      {"PUT", "/{token:[0-9a-f]+}", ocppFileUploadHandler},
+			"uploadputnamed": {"PUT", "/{token:[0-9a-f]+}/{name}", ocppFileUploadHandler},
+		}
+
+		for _, r := range routes {
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+		}
+	}
+
+	{ // api/ocpp
+		api := api.PathPrefix("/ocpp").Subrouter()
+		api.Use(ensureAuthHandler(auth))
+
+		routes := map[string]route{
+			"stations":            {"GET", "", ocppStationsHandler},
+			"station":             {"GET", "/{station}", ocppStationHandler},
+			"configuration":       {"GET", "/{station}/configuration", ocppGetConfigurationHandler},
+			"changeconfiguration": {"POST", "/{station}/configuration", ocppChangeConfigurationHandler},
+			"reset":               {"POST", "/{station}/reset/{type:soft|hard}", ocppResetHandler},
+			"trigger":             {"POST", "/{station}/trigger/{message:[a-zA-Z]+}", ocppTriggerHandler},
+			"diagnostics":         {"POST", "/{station}/diagnostics", ocppGetDiagnosticsHandler},
+			"diagnosticsdownload": {"GET", "/{station}/diagnostics/{token:[0-9a-f]+}", ocppDownloadDiagnosticsHandler},
+			"firmware":            {"POST", "/{station}/firmware", ocppUpdateFirmwareHandler},
+		}
+
+		for _, r := range routes {
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+		}
+	}
 }
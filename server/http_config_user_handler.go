@@ -0,0 +1,150 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// decodeUser decodes and validates a user from the request body
+func decodeUser(r *http.Request) (session.User, error) {
+	var res session.User
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		return res, err
+	}
+
+	if res.Name = strings.TrimSpace(res.Name); res.Name == "" {
+		return res, errors.New("missing name")
+	}
+
+	return res, nil
+}
+
+// saveUser creates or updates the user and replaces its tags
+func saveUser(user *session.User) error {
+	return db.Instance.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Tags").Save(user).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", user.ID).Delete(new(session.UserTag)).Error; err != nil {
+			return err
+		}
+
+		for i := range user.Tags {
+			user.Tags[i].ID = 0
+			user.Tags[i].UserID = user.ID
+		}
+
+		if len(user.Tags) == 0 {
+			return nil
+		}
+
+		return tx.Create(&user.Tags).Error
+	})
+}
+
+// usersHandler returns the list of users
+func usersHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	var res session.Users
+	if txn := db.Instance.Preload("Tags").Order("name").Find(&res); txn.Error != nil {
+		jsonError(w, http.StatusInternalServerError, txn.Error)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// newUserHandler creates a new user
+func newUserHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	user, err := decodeUser(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user.ID = 0
+	if err := saveUser(&user); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, user)
+}
+
+// updateUserHandler updates the user with given id
+func updateUserHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 0)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := decodeUser(r)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var existing session.User
+	if txn := db.Instance.First(&existing, id); txn.Error != nil {
+		jsonError(w, http.StatusNotFound, txn.Error)
+		return
+	}
+
+	user.ID = existing.ID
+	if err := saveUser(&user); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, user)
+}
+
+// deleteUserHandler deletes the user with given id
+func deleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 0)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := db.Instance.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(new(session.UserTag)).Error; err != nil {
+			return err
+		}
+		return tx.Delete(new(session.User), id).Error
+	}); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, id)
+}
@@ -50,23 +50,37 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 
 		goal, _ := lp.GetPlanGoal()
 		precondition := lp.GetPlanPreCondDuration()
-		requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
+		requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower, planTime)
 		plan := lp.GetPlan(planTime, requiredDuration, precondition)
+		solarDuration := lp.GetPlanSolarDuration(planTime, requiredDuration)
+
+		var temperature float64
+		if lp.ThermalPlanning() {
+			temperature = goal
+		}
 
 		res := struct {
-			PlanId       int       `json:"planId"`
-			PlanTime     time.Time `json:"planTime"`
-			Duration     int64     `json:"duration"`
-			Precondition int64     `json:"precondition"`
-			Plan         api.Rates `json:"plan"`
-			Power        float64   `json:"power"`
+			PlanId        int       `json:"planId"`
+			PlanTime      time.Time `json:"planTime"`
+			Duration      int64     `json:"duration"`
+			SolarDuration int64     `json:"solarDuration"`
+			GridDuration  int64     `json:"gridDuration"`
+			Precondition  int64     `json:"precondition"`
+			Plan          api.Rates `json:"plan"`
+			Power         float64   `json:"power"`
+			Energy        float64   `json:"energy"`
+			Temperature   float64   `json:"temperature,omitempty"`
 		}{
-			PlanId:       id,
-			PlanTime:     planTime,
-			Duration:     int64(requiredDuration.Seconds()),
-			Precondition: int64(precondition.Seconds()),
-			Plan:         plan,
-			Power:        maxPower,
+			PlanId:        id,
+			PlanTime:      planTime,
+			Duration:      int64(requiredDuration.Seconds()),
+			SolarDuration: int64(solarDuration.Seconds()),
+			GridDuration:  int64((requiredDuration - solarDuration).Seconds()),
+			Precondition:  int64(precondition.Seconds()),
+			Plan:          plan,
+			Power:         maxPower,
+			Energy:        maxPower * requiredDuration.Hours() / 1e3,
+			Temperature:   temperature,
 		}
 
 		jsonResult(w, res)
@@ -108,27 +122,41 @@ func staticPlanPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 				jsonError(w, http.StatusBadRequest, errors.New("energy planning not available for vehicles with known soc and capacity"))
 				return
 			}
+			if lp.ThermalPlanning() {
+				jsonError(w, http.StatusBadRequest, errors.New("energy planning not available for heating devices with thermal storage model"))
+				return
+			}
+		case "temperature":
+			if !lp.ThermalPlanning() {
+				jsonError(w, http.StatusBadRequest, errors.New("temperature planning only available for heating devices with thermal storage model"))
+				return
+			}
 		default:
 			jsonError(w, http.StatusBadRequest, fmt.Errorf("invalid plan type: %s", typ))
 			return
 		}
 
 		maxPower := lp.EffectiveMaxPower()
-		requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
+		requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower, planTime)
 		plan := lp.GetPlan(planTime, requiredDuration, precondition)
+		solarDuration := lp.GetPlanSolarDuration(planTime, requiredDuration)
 
 		res := struct {
-			PlanTime     time.Time `json:"planTime"`
-			Duration     int64     `json:"duration"`
-			Precondition int64     `json:"precondition"`
-			Plan         api.Rates `json:"plan"`
-			Power        float64   `json:"power"`
+			PlanTime      time.Time `json:"planTime"`
+			Duration      int64     `json:"duration"`
+			SolarDuration int64     `json:"solarDuration"`
+			GridDuration  int64     `json:"gridDuration"`
+			Precondition  int64     `json:"precondition"`
+			Plan          api.Rates `json:"plan"`
+			Power         float64   `json:"power"`
 		}{
-			PlanTime:     planTime,
-			Duration:     int64(requiredDuration.Seconds()),
-			Precondition: int64(precondition.Seconds()),
-			Plan:         plan,
-			Power:        maxPower,
+			PlanTime:      planTime,
+			Duration:      int64(requiredDuration.Seconds()),
+			SolarDuration: int64(solarDuration.Seconds()),
+			GridDuration:  int64((requiredDuration - solarDuration).Seconds()),
+			Precondition:  int64(precondition.Seconds()),
+			Plan:          plan,
+			Power:         maxPower,
 		}
 
 		jsonResult(w, res)
@@ -172,21 +200,26 @@ func repeatingPlanPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 		}
 
 		maxPower := lp.EffectiveMaxPower()
-		requiredDuration := lp.GetPlanRequiredDuration(soc, maxPower)
+		requiredDuration := lp.GetPlanRequiredDuration(soc, maxPower, planTime)
 		plan := lp.GetPlan(planTime, requiredDuration, precondition)
+		solarDuration := lp.GetPlanSolarDuration(planTime, requiredDuration)
 
 		res := struct {
-			PlanTime     time.Time `json:"planTime"`
-			Duration     int64     `json:"duration"`
-			Precondition int64     `json:"precondition"`
-			Plan         api.Rates `json:"plan"`
-			Power        float64   `json:"power"`
+			PlanTime      time.Time `json:"planTime"`
+			Duration      int64     `json:"duration"`
+			SolarDuration int64     `json:"solarDuration"`
+			GridDuration  int64     `json:"gridDuration"`
+			Precondition  int64     `json:"precondition"`
+			Plan          api.Rates `json:"plan"`
+			Power         float64   `json:"power"`
 		}{
-			PlanTime:     planTime,
-			Duration:     int64(requiredDuration.Seconds()),
-			Precondition: int64(precondition.Seconds()),
-			Plan:         plan,
-			Power:        maxPower,
+			PlanTime:      planTime,
+			Duration:      int64(requiredDuration.Seconds()),
+			SolarDuration: int64(solarDuration.Seconds()),
+			GridDuration:  int64((requiredDuration - solarDuration).Seconds()),
+			Precondition:  int64(precondition.Seconds()),
+			Plan:          plan,
+			Power:         maxPower,
 		}
 
 		jsonResult(w, res)
@@ -238,6 +271,51 @@ func planEnergyHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// planTemperatureHandler updates plan temperature and time
+func planTemperatureHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := r.URL.Query()
+
+		ts, err := time.Parse(time.RFC3339, vars["time"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		val, err := strconv.ParseFloat(vars["value"], 64)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		precondition, err := parseDuration(query.Get("precondition"))
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.SetPlanTemperature(ts, precondition, val); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ts, precondition, temp := lp.GetPlanTemperature()
+
+		res := struct {
+			Temperature  float64   `json:"temperature"`
+			Precondition int64     `json:"precondition"`
+			Time         time.Time `json:"time"`
+		}{
+			Temperature:  temp,
+			Precondition: int64(precondition.Seconds()),
+			Time:         ts,
+		}
+
+		jsonResult(w, res)
+	}
+}
+
 // planRemoveHandler removes plan time
 func planRemoveHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
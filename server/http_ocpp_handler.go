@@ -0,0 +1,417 @@
+package server
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/charger/ocpp"
+	"github.com/gorilla/mux"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
+)
+
+const (
+	// ocppFileMaxSize limits firmware and diagnostics file sizes
+	ocppFileMaxSize = 64 << 20
+
+	// ocppFileExpiry is the time a firmware or diagnostics file remains available
+	ocppFileExpiry = time.Hour
+)
+
+// ocppFile is a firmware or diagnostics file exchanged with a station
+type ocppFile struct {
+	station string
+	name    string
+	data    []byte
+	created time.Time
+}
+
+// ocppFiles holds firmware files for download and diagnostics files uploaded by stations, keyed by random token
+var ocppFiles = struct {
+	mu    sync.Mutex
+	files map[string]*ocppFile
+}{
+	files: make(map[string]*ocppFile),
+}
+
+// newOcppFile registers a file and returns its token
+func newOcppFile(file *ocppFile) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	token := hex.EncodeToString(b)
+
+	ocppFiles.mu.Lock()
+	defer ocppFiles.mu.Unlock()
+
+	for k, f := range ocppFiles.files {
+		if time.Since(f.created) > ocppFileExpiry {
+			delete(ocppFiles.files, k)
+		}
+	}
+
+	file.created = time.Now()
+	ocppFiles.files[token] = file
+
+	return token
+}
+
+// ocppFileByToken returns a copy of the file for given token
+func ocppFileByToken(token string) (ocppFile, bool) {
+	ocppFiles.mu.Lock()
+	defer ocppFiles.mu.Unlock()
+
+	f, ok := ocppFiles.files[token]
+	if !ok || time.Since(f.created) > ocppFileExpiry {
+		return ocppFile{}, false
+	}
+
+	return *f, true
+}
+
+// setOcppFileData stores the data uploaded for given token once
+func setOcppFileData(token, name string, data []byte) bool {
+	ocppFiles.mu.Lock()
+	defer ocppFiles.mu.Unlock()
+
+	f, ok := ocppFiles.files[token]
+	if !ok || f.data != nil {
+		return false
+	}
+
+	f.name = name
+	f.data = data
+
+	return true
+}
+
+// ocppFileUrl returns the url under which the station can access the file with given token
+func ocppFileUrl(station, token string) (string, error) {
+	cs, ok := ocpp.Started()
+	if !ok {
+		return "", errors.New("ocpp not configured")
+	}
+
+	uri, err := cs.FileServerUrl(station)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/api/ocpp/files/%s", uri, token), nil
+}
+
+// ocppStation returns the charge point for the station route variable
+func ocppStation(r *http.Request) (*ocpp.CP, error) {
+	cs, ok := ocpp.Started()
+	if !ok {
+		return nil, errors.New("ocpp not configured")
+	}
+
+	cp, err := cs.ChargepointByID(mux.Vars(r)["station"])
+	if err != nil {
+		return nil, err
+	}
+
+	if !cp.Connected() {
+		return nil, errors.New("station not connected")
+	}
+
+	return cp, nil
+}
+
+// ocppStationsHandler returns the configured stations
+func ocppStationsHandler(w http.ResponseWriter, r *http.Request) {
+	res := []string{}
+
+	if cs, ok := ocpp.Started(); ok {
+		res = cs.Chargepoints()
+	}
+
+	jsonResult(w, res)
+}
+
+// ocppStationHandler returns the station's boot information and firmware status
+func ocppStationHandler(w http.ResponseWriter, r *http.Request) {
+	cs, ok := ocpp.Started()
+	if !ok {
+		jsonError(w, http.StatusNotFound, errors.New("ocpp not configured"))
+		return
+	}
+
+	cp, err := cs.ChargepointByID(mux.Vars(r)["station"])
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	res := struct {
+		ID                string                        `json:"id"`
+		Connected         bool                          `json:"connected"`
+		OCPP201           bool                          `json:"ocpp201"`
+		Boot              *core.BootNotificationRequest `json:"boot,omitempty"`
+		DiagnosticsStatus string                        `json:"diagnosticsStatus,omitempty"`
+		FirmwareStatus    string                        `json:"firmwareStatus,omitempty"`
+	}{
+		ID:                cp.ID(),
+		Connected:         cp.Connected(),
+		OCPP201:           cp.IsOCPP201(),
+		Boot:              cp.BootNotification(),
+		DiagnosticsStatus: string(cp.DiagnosticsStatus()),
+		FirmwareStatus:    string(cp.FirmwareStatus()),
+	}
+
+	jsonResult(w, res)
+}
+
+// ocppGetConfigurationHandler returns the station's configuration keys
+func ocppGetConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	cp, err := ocppStation(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	res, err := cp.GetConfigurationRequest()
+	if err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// ocppChangeConfigurationHandler changes a station configuration key
+func ocppChangeConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	cp, err := ocppStation(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var req struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+
+	if err := jsonDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Key == "" {
+		jsonError(w, http.StatusBadRequest, errors.New("missing key"))
+		return
+	}
+
+	if err := cp.ChangeConfigurationRequest(req.Key, req.Value); err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	jsonResult(w, req)
+}
+
+// ocppResetHandler resets the station
+func ocppResetHandler(w http.ResponseWriter, r *http.Request) {
+	cp, err := ocppStation(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	typ := core.ResetTypeSoft
+	if mux.Vars(r)["type"] == "hard" {
+		typ = core.ResetTypeHard
+	}
+
+	if err := cp.ResetRequest(typ); err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	jsonResult(w, typ)
+}
+
+// ocppTriggerHandler triggers a station message, optionally for given connector
+func ocppTriggerHandler(w http.ResponseWriter, r *http.Request) {
+	cp, err := ocppStation(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var connector int
+	if s := r.URL.Query().Get("connector"); s != "" {
+		if connector, err = strconv.Atoi(s); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	msg := remotetrigger.MessageTrigger(mux.Vars(r)["message"])
+
+	if err := cp.TriggerMessageRequest(connector, msg); err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	jsonResult(w, msg)
+}
+
+// ocppGetDiagnosticsHandler requests the station to upload its diagnostics
+func ocppGetDiagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	cp, err := ocppStation(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	token := newOcppFile(&ocppFile{station: cp.ID()})
+
+	location, err := ocppFileUrl(cp.ID(), token)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	fileName, err := cp.GetDiagnosticsRequest(location)
+	if err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	res := struct {
+		FileName string `json:"fileName"`
+		Token    string `json:"token"`
+	}{
+		FileName: fileName,
+		Token:    token,
+	}
+
+	jsonResult(w, res)
+}
+
+// ocppDownloadDiagnosticsHandler returns the diagnostics file uploaded by the station
+func ocppDownloadDiagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	f, ok := ocppFileByToken(mux.Vars(r)["token"])
+	if !ok || f.station != mux.Vars(r)["station"] {
+		jsonError(w, http.StatusNotFound, errors.New("unknown diagnostics"))
+		return
+	}
+
+	if f.data == nil {
+		jsonError(w, http.StatusNotFound, errors.New("diagnostics not uploaded yet"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": f.name}))
+	_, _ = w.Write(f.data)
+}
+
+// ocppUpdateFirmwareHandler stores the uploaded firmware file and requests the station to install it
+func ocppUpdateFirmwareHandler(w http.ResponseWriter, r *http.Request) {
+	cp, err := ocppStation(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, ocppFileMaxSize)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	token := newOcppFile(&ocppFile{station: cp.ID(), name: header.Filename, data: data})
+
+	location, err := ocppFileUrl(cp.ID(), token)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+	location += "/" + url.PathEscape(header.Filename)
+
+	if err := cp.UpdateFirmwareRequest(location, time.Now()); err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	jsonResult(w, location)
+}
+
+// ocppFileDownloadHandler serves firmware files to the station
+func ocppFileDownloadHandler(w http.ResponseWriter, r *http.Request) {
+	f, ok := ocppFileByToken(mux.Vars(r)["token"])
+	if !ok || f.data == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, f.name, f.created, bytes.NewReader(f.data))
+}
+
+// ocppFileUploadHandler receives diagnostics files uploaded by the station either as raw or multipart body
+func ocppFileUploadHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if f, ok := ocppFileByToken(vars["token"]); !ok || f.data != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, ocppFileMaxSize)
+
+	name := vars["name"]
+
+	var body io.Reader = r.Body
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "multipart/form-data" {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		body = file
+		if name == "" {
+			name = header.Filename
+		}
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if name == "" {
+		name = "diagnostics"
+	}
+
+	if !setOcppFileData(vars["token"], name, data) {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
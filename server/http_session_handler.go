@@ -28,15 +28,9 @@ func csvResult(ctx context.Context, w http.ResponseWriter, res any, filename str
 	}
 }
 
-// sessionHandler returns the list of charging sessions
-func sessionHandler(w http.ResponseWriter, r *http.Request) {
-	if db.Instance == nil {
-		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
-		return
-	}
-
+// sessionFilter returns the session query and file name for the request's year and month parameters
+func sessionFilter(r *http.Request, filename string) (string, []any, string) {
 	var (
-		res  session.Sessions
 		cond []string
 		args []any
 	)
@@ -46,7 +40,6 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 		args = append(args, val)
 	}
 
-	filename := "session"
 	if year := r.URL.Query().Get("year"); year != "" {
 		filename += "-" + year
 		push("STRFTIME('%Y', created) LIKE ?", year)
@@ -59,12 +52,47 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 
 	// TODO support other databases than Sqlite
 	query := strings.Join(append([]string{"charged_kwh>=0.05"}, cond...), " AND ")
-	if txn := db.Instance.Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
+
+	return query, args, filename
+}
+
+// requestLanguage returns the request's language parameter or preferred language
+func requestLanguage(r *http.Request) string {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		// get request language
+		lang = r.Header.Get("Accept-Language")
+		if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
+			lang = tags[0].String()
+		}
+	}
+	return lang
+}
+
+// sessionHandler returns the list of charging sessions
+func sessionHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	var res session.Sessions
+
+	query, args, filename := sessionFilter(r, "session")
+	if txn := db.Instance.Preload("Slots").Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
+		jsonError(w, http.StatusInternalServerError, txn.Error)
+		return
+	}
+
+	var users session.Users
+	if txn := db.Instance.Find(&users); txn.Error != nil {
 		jsonError(w, http.StatusInternalServerError, txn.Error)
 		return
 	}
 
 	// prepare data
+	res.ResolveUsers(users)
+
 	for i, s := range res {
 		if s.Odometer != nil {
 			odo := math.Round(*s.Odometer*10) / 10
@@ -73,16 +101,14 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.URL.Query().Get("format") == "csv" {
-		lang := r.URL.Query().Get("lang")
-		if lang == "" {
-			// get request language
-			lang = r.Header.Get("Accept-Language")
-			if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
-				lang = tags[0].String()
-			}
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+
+		// export slot breakdown instead of session totals
+		if r.URL.Query().Get("slots") == "true" {
+			csvResult(ctx, w, (*session.SlotSessions)(&res), filename+"-slots")
+			return
 		}
 
-		ctx := context.WithValue(context.Background(), locale.Locale, lang)
 		csvResult(ctx, w, &res, filename)
 		return
 	}
@@ -90,6 +116,47 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 	jsonResult(w, res)
 }
 
+// sessionReportHandler returns the per-user report of charging sessions
+func sessionReportHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	var (
+		sessions session.Sessions
+		users    session.Users
+	)
+
+	query, args, filename := sessionFilter(r, "report")
+	if txn := db.Instance.Where(query, args...).Find(&sessions); txn.Error != nil {
+		jsonError(w, http.StatusInternalServerError, txn.Error)
+		return
+	}
+
+	if txn := db.Instance.Find(&users); txn.Error != nil {
+		jsonError(w, http.StatusInternalServerError, txn.Error)
+		return
+	}
+
+	res := session.NewReport(sessions, users)
+
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+		csvResult(ctx, w, &res, filename)
+
+	case "html":
+		w.Header().Set("Content-Type", "text/html")
+		if err := res.WriteHTML(w, filename); err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+		}
+
+	default:
+		jsonResult(w, res)
+	}
+}
+
 // deleteSessionHandler removes session in sessions table with given id
 func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 	if db.Instance == nil {
@@ -107,6 +174,11 @@ func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if txn := db.Instance.Where("session_id = ?", id).Delete(new(session.Slot)); txn.Error != nil {
+		jsonError(w, http.StatusBadRequest, txn.Error)
+		return
+	}
+
 	jsonResult(w, res)
 }
 
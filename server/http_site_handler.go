@@ -219,6 +219,38 @@ func updateBatteryMode(site site.API) http.HandlerFunc {
 	}
 }
 
+// updateBatteryScheduleOverride sets or removes the battery mode of a battery schedule slot
+func updateBatteryScheduleOverride(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		ts, err := time.Parse(time.RFC3339, vars["time"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		var val api.BatteryMode
+
+		if r.Method != http.MethodDelete {
+			s, err := api.BatteryModeString(vars["value"])
+			if err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+
+			val = s
+		}
+
+		if err := site.SetBatteryScheduleOverride(ts, val); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, site.GetBatterySchedule())
+	}
+}
+
 // stateHandler returns the combined state
 func stateHandler(cache *util.ParamCache) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -1,13 +1,18 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util/locale"
 	"github.com/gorilla/mux"
 )
 
@@ -170,3 +175,37 @@ func planSocRemoveHandler(site site.API) http.HandlerFunc {
 		jsonResult(w, res)
 	}
 }
+
+// vehicleHistoryHandler returns the vehicle's logbook of trips between charging sessions
+func vehicleHistoryHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if db.Instance == nil {
+			jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+			return
+		}
+
+		vars := mux.Vars(r)
+
+		v, err := site.Vehicles().ByName(vars["name"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		var entries []session.LogbookEntry
+		if txn := db.Instance.Where("vehicle = ?", v.Name()).Order("created").Find(&entries); txn.Error != nil {
+			jsonError(w, http.StatusInternalServerError, txn.Error)
+			return
+		}
+
+		res := session.NewTrips(entries, v.Instance().Capacity())
+
+		if r.URL.Query().Get("format") == "csv" {
+			ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+			csvResult(ctx, w, &res, "history-"+v.Name())
+			return
+		}
+
+		jsonResult(w, res)
+	}
+}
@@ -254,6 +254,18 @@ func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.
 			}
 			return err
 		}},
+		{"planTemperature", func(payload string) error {
+			var plan struct {
+				Time         time.Time `json:"time"`
+				Precondition int64     `json:"precondition"`
+				Value        float64   `json:"value"`
+			}
+			err := json.Unmarshal([]byte(payload), &plan)
+			if err == nil {
+				err = lp.SetPlanTemperature(plan.Time, time.Duration(plan.Precondition)*time.Second, plan.Value)
+			}
+			return err
+		}},
 		{"vehicle", func(payload string) error {
 			// https://github.com/evcc-io/evcc/issues/11184 empty payload is swallowed by listener
 			if isEmpty(payload) {
@@ -12,6 +12,9 @@ idtag: {{ .idtag }}
 {{- if and .remotestart (ne .remotestart "false") }}
 remotestart: {{ .remotestart }}
 {{- end }}
+{{- if and .whitelist (ne .whitelist "false") }}
+whitelist: {{ .whitelist }}
+{{- end }}
 {{- if .metervalues }}
 metervalues: {{ .metervalues }}
 {{- end }}
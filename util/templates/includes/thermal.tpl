@@ -0,0 +1,18 @@
+{{ define "thermal" }}
+{{- if .volume }}
+thermal:
+  volume: {{ .volume }}
+  {{- if .mintemp }}
+  mintemp: {{ .mintemp }}
+  {{- end }}
+  {{- if .maxtemp }}
+  maxtemp: {{ .maxtemp }}
+  {{- end }}
+  {{- if .heatloss }}
+  heatloss: {{ .heatloss }}
+  {{- end }}
+  {{- if .cop }}
+  cop: {{ .cop }}
+  {{- end }}
+{{- end }}
+{{- end }}
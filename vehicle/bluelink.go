@@ -80,7 +80,7 @@ func newBluelinkFromConfig(brand string, other map[string]interface{}, settings
 		return nil, err
 	}
 
-	api := bluelink.NewAPI(log, settings.URI, identity.Request)
+	api := bluelink.NewAPI(log, settings.URI, cc.User, identity.Request)
 
 	vehicle, err := ensureVehicleEx(
 		cc.VIN, api.Vehicles,
@@ -10,6 +10,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/transport"
+	"github.com/evcc-io/evcc/vehicle/scheduler"
 )
 
 const (
@@ -34,7 +35,7 @@ type API struct {
 }
 
 // New creates a new BlueLink API
-func NewAPI(log *util.Logger, baseURI string, decorator func(*http.Request) error) *API {
+func NewAPI(log *util.Logger, baseURI, user string, decorator func(*http.Request) error) *API {
 	v := &API{
 		Helper:  request.NewHelper(log),
 		baseURI: strings.TrimSuffix(baseURI, "/api/v1/spa") + "/api/v1/spa",
@@ -49,7 +50,7 @@ func NewAPI(log *util.Logger, baseURI string, decorator func(*http.Request) erro
 
 	v.Client.Transport = &transport.Decorator{
 		Decorator: decorator,
-		Base:      v.Client.Transport,
+		Base:      scheduler.Get("bluelink", user).Transport(v.Client.Transport),
 	}
 
 	return v
@@ -62,7 +62,7 @@ func NewBMWMiniFromConfig(brand string, other map[string]interface{}) (api.Vehic
 		return nil, err
 	}
 
-	api := bmw.NewAPI(log, brand, cc.Region, ts)
+	api := bmw.NewAPI(log, brand, cc.Region, cc.User, ts)
 
 	vehicle, err := ensureVehicleEx(
 		cc.VIN, api.Vehicles,
@@ -9,6 +9,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/transport"
+	"github.com/evcc-io/evcc/vehicle/scheduler"
 	"golang.org/x/oauth2"
 )
 
@@ -22,7 +23,7 @@ type API struct {
 }
 
 // NewAPI creates a new vehicle
-func NewAPI(log *util.Logger, brand, region string, identity oauth2.TokenSource) *API {
+func NewAPI(log *util.Logger, brand, region, user string, identity oauth2.TokenSource) *API {
 	v := &API{
 		Helper: request.NewHelper(log),
 		region: strings.ToUpper(region),
@@ -32,7 +33,7 @@ func NewAPI(log *util.Logger, brand, region string, identity oauth2.TokenSource)
 	v.Client.Transport = &transport.Decorator{
 		Base: &oauth2.Transport{
 			Source: identity,
-			Base:   v.Client.Transport,
+			Base:   scheduler.Get("bmw", user).Transport(v.Client.Transport),
 		},
 		Decorator: transport.DecorateHeaders(map[string]string{
 			"X-User-Agent": fmt.Sprintf("android(SP1A.210812.016.C1);%s;99.0.0(99999);row", brand),
@@ -68,10 +69,12 @@ func (v *API) Status(vin string) (VehicleStatus, error) {
 }
 
 const (
-	CHARGE_START = "start-charging"
-	CHARGE_STOP  = "stop-charging"
-	DOOR_LOCK    = "door-lock"
-	LIGHT_FLASH  = "light-flash"
+	CHARGE_START  = "start-charging"
+	CHARGE_STOP   = "stop-charging"
+	CLIMATE_START = "climate-now?action=START"
+	CLIMATE_STOP  = "climate-now?action=STOP"
+	DOOR_LOCK     = "door-lock"
+	LIGHT_FLASH   = "light-flash"
 
 	REMOTE_SERVICE_BASE_URL   = "eadrax-vrccs/v3/presentation/remote-commands"
 	VEHICLE_CHARGING_BASE_URL = "eadrax-crccs/v1/vehicles"
@@ -129,3 +129,11 @@ func (v *Provider) ChargeEnable(enable bool) error {
 	action := map[bool]string{true: CHARGE_START, false: CHARGE_STOP}
 	return v.actionS(action[enable])
 }
+
+var _ api.VehicleClimateController = (*Provider)(nil)
+
+// Climatize implements the api.VehicleClimateController interface
+func (v *Provider) Climatize(enable bool) error {
+	action := map[bool]string{true: CLIMATE_START, false: CLIMATE_STOP}
+	return v.actionS(action[enable])
+}
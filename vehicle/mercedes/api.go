@@ -7,6 +7,7 @@ import (
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/transport"
 	protos "github.com/evcc-io/evcc/vehicle/mercedes/pb"
+	"github.com/evcc-io/evcc/vehicle/scheduler"
 	"golang.org/x/oauth2"
 	"google.golang.org/protobuf/proto"
 )
@@ -24,7 +25,7 @@ func NewAPI(log *util.Logger, identity *Identity) *API {
 	client.Transport = &transport.Decorator{
 		Base: &oauth2.Transport{
 			Source: identity,
-			Base:   client.Transport,
+			Base:   scheduler.Get("mercedes", identity.account).Transport(client.Transport),
 		},
 		Decorator: transport.DecorateHeaders(mbheaders(false, identity.region)),
 	}
@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// DefaultBudget is the daily request budget of brands without known limits
+const DefaultBudget = 1000
+
+// budgets are the daily request budgets of cloud apis known to block accounts when polled too often
+var budgets = map[string]int{
+	"bluelink": 200,
+	"bmw":      100,
+	"mercedes": 500,
+}
+
+// forbidden are the brands known to signal rate limiting by 403 instead of 429.
+// Other brands' 403 responses usually indicate expired authorization and must not trigger backoff.
+var forbidden = map[string]bool{
+	"bmw": true,
+}
+
+const (
+	minBackoff = 5 * time.Minute
+	maxBackoff = 6 * time.Hour
+)
+
+var (
+	mu         sync.Mutex
+	schedulers = make(map[string]*Scheduler)
+)
+
+// Scheduler limits an account's vehicle api requests to a daily budget and backs off when rate limited.
+// The budget is spread over the day such that frequent polling cannot exhaust it early.
+type Scheduler struct {
+	mu        sync.Mutex
+	log       *util.Logger
+	clock     clock.Clock
+	brand     string
+	budget    int
+	forbidden bool          // 403 indicates rate limiting
+	day       time.Time     // start of budget day
+	used      int           // requests used today
+	backoff   time.Duration // current backoff duration
+	until     time.Time     // backoff end
+}
+
+// Get returns the shared scheduler of the given brand's user account
+func Get(brand, user string) *Scheduler {
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := brand + "/" + user
+	if s, ok := schedulers[key]; ok {
+		return s
+	}
+
+	budget, ok := budgets[brand]
+	if !ok {
+		budget = DefaultBudget
+	}
+
+	s := New(brand, budget, clock.New())
+	s.forbidden = forbidden[brand]
+	schedulers[key] = s
+
+	return s
+}
+
+// New creates a scheduler with given daily request budget
+func New(brand string, budget int, clock clock.Clock) *Scheduler {
+	return &Scheduler{
+		log:    util.NewLogger(brand),
+		clock:  clock,
+		brand:  brand,
+		budget: budget,
+	}
+}
+
+// rollover resets the budget at the start of a new day
+func (s *Scheduler) rollover(now time.Time) {
+	if day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()); !day.Equal(s.day) {
+		s.day = day
+		s.used = 0
+	}
+}
+
+// Allow reserves a request from the budget. It returns api.ErrMustRetry if the budget is
+// exhausted or the api is backing off.
+func (s *Scheduler) Allow() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	s.rollover(now)
+
+	if err := s.backingOff(now); err != nil {
+		return err
+	}
+
+	// allow an initial burst and spread the remaining budget over the day
+	allowed := s.budget/4 + int(float64(s.budget)*now.Sub(s.day).Hours()/24)
+	if s.used >= min(s.budget, allowed) {
+		return fmt.Errorf("%s api request budget exhausted (%d/%d): %w", s.brand, s.used, s.budget, api.ErrMustRetry)
+	}
+
+	s.used++
+
+	return nil
+}
+
+// AllowCommand checks if a command may be sent. Commands are not subject to the request budget
+// such that exhaustive polling cannot block them. It returns api.ErrMustRetry if the api is backing off.
+func (s *Scheduler) AllowCommand() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.backingOff(s.clock.Now())
+}
+
+// backingOff returns api.ErrMustRetry while the api is backing off
+func (s *Scheduler) backingOff(now time.Time) error {
+	if now.Before(s.until) {
+		return fmt.Errorf("%s api backoff until %v: %w", s.brand, s.until.Round(time.Second).Local(), api.ErrMustRetry)
+	}
+
+	return nil
+}
+
+// Update backs off if the response indicates rate limiting
+func (s *Scheduler) Update(resp *http.Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if resp.StatusCode != http.StatusTooManyRequests && (!s.forbidden || resp.StatusCode != http.StatusForbidden) {
+		s.backoff = 0
+		return
+	}
+
+	s.backoff = min(maxBackoff, max(minBackoff, 2*s.backoff))
+
+	// honor server provided retry delay
+	if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		s.backoff = min(maxBackoff, max(s.backoff, time.Duration(sec)*time.Second))
+	}
+
+	s.until = s.clock.Now().Add(s.backoff)
+	s.log.WARN.Printf("api rate limited (%d), backing off for %v", resp.StatusCode, s.backoff)
+}
+
+// Transport returns a RoundTripper that applies the request budget to the base RoundTripper.
+// Non-GET requests are considered commands and only subject to backoff.
+func (s *Scheduler) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &roundTripper{
+		scheduler: s,
+		base:      base,
+	}
+}
+
+type roundTripper struct {
+	scheduler *Scheduler
+	base      http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	allow := t.scheduler.Allow
+	if req.Method != http.MethodGet {
+		allow = t.scheduler.AllowCommand
+	}
+
+	if err := allow(); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.scheduler.Update(resp)
+	}
+
+	return resp, err
+}
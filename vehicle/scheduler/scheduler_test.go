@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget(t *testing.T) {
+	clk := clock.NewMock()
+	clk.Set(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local))
+
+	s := New("test", 96, clk)
+
+	// initial burst
+	for range 24 {
+		require.NoError(t, s.Allow())
+	}
+	assert.ErrorIs(t, s.Allow(), api.ErrMustRetry)
+
+	// budget spread over the day
+	clk.Add(time.Hour)
+	for range 4 {
+		require.NoError(t, s.Allow())
+	}
+	assert.ErrorIs(t, s.Allow(), api.ErrMustRetry)
+
+	// budget capped
+	clk.Add(22 * time.Hour)
+	for range 68 {
+		require.NoError(t, s.Allow())
+	}
+	assert.ErrorIs(t, s.Allow(), api.ErrMustRetry)
+
+	// next day
+	clk.Add(2 * time.Hour)
+	require.NoError(t, s.Allow())
+	assert.Equal(t, 1, s.used)
+}
+
+func TestBackoff(t *testing.T) {
+	clk := clock.NewMock()
+	s := New("test", DefaultBudget, clk)
+
+	s.Update(&http.Response{StatusCode: http.StatusTooManyRequests})
+	assert.Equal(t, minBackoff, s.backoff)
+	assert.ErrorIs(t, s.Allow(), api.ErrMustRetry)
+
+	clk.Add(minBackoff)
+	require.NoError(t, s.Allow())
+
+	// exponential backoff
+	s.Update(&http.Response{StatusCode: http.StatusTooManyRequests})
+	assert.Equal(t, 2*minBackoff, s.backoff)
+
+	// retry-after
+	s.Update(&http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"3600"}},
+	})
+	assert.Equal(t, time.Hour, s.backoff)
+
+	clk.Add(time.Hour - time.Second)
+	assert.ErrorIs(t, s.Allow(), api.ErrMustRetry)
+
+	clk.Add(time.Second)
+	require.NoError(t, s.Allow())
+
+	// success resets backoff
+	s.Update(&http.Response{StatusCode: http.StatusOK})
+	assert.Zero(t, s.backoff)
+}
+
+func TestForbidden(t *testing.T) {
+	clk := clock.NewMock()
+	s := New("test", DefaultBudget, clk)
+
+	// expired authorization
+	s.Update(&http.Response{StatusCode: http.StatusForbidden})
+	assert.Zero(t, s.backoff)
+	require.NoError(t, s.Allow())
+
+	// brand known to signal rate limiting by 403
+	s.forbidden = true
+	s.Update(&http.Response{StatusCode: http.StatusForbidden})
+	assert.Equal(t, minBackoff, s.backoff)
+	assert.ErrorIs(t, s.Allow(), api.ErrMustRetry)
+}
+
+func TestGet(t *testing.T) {
+	assert.Same(t, Get("bmw", "foo"), Get("bmw", "foo"))
+	assert.NotSame(t, Get("bmw", "foo"), Get("bmw", "bar"))
+	assert.True(t, Get("bmw", "foo").forbidden)
+	assert.False(t, Get("mercedes", "foo").forbidden)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransportCommand(t *testing.T) {
+	clk := clock.NewMock()
+	s := New("test", 4, clk)
+
+	status := http.StatusOK
+	client := &http.Client{Transport: s.Transport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+	}))}
+
+	// exhaust budget by polling
+	_, err := client.Get("http://example.com")
+	require.NoError(t, err)
+	_, err = client.Get("http://example.com")
+	assert.ErrorIs(t, err, api.ErrMustRetry)
+
+	// commands are not subject to budget
+	_, err = client.Post("http://example.com", "", http.NoBody)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.used)
+
+	// commands are subject to backoff
+	status = http.StatusTooManyRequests
+	_, err = client.Post("http://example.com", "", http.NoBody)
+	require.NoError(t, err)
+
+	_, err = client.Post("http://example.com", "", http.NoBody)
+	assert.ErrorIs(t, err, api.ErrMustRetry)
+}
@@ -52,3 +52,20 @@ func (v *Controller) ChargeEnable(enable bool) error {
 
 	return err
 }
+
+var _ api.VehicleClimateController = (*Controller)(nil)
+
+// Climatize implements the api.VehicleClimateController interface
+func (v *Controller) Climatize(enable bool) error {
+	if enable {
+		return apiError(v.vehicle.StartAirConditioning())
+	}
+	return apiError(v.vehicle.StopAirConditioning())
+}
+
+var _ api.SocLimitSetter = (*Controller)(nil)
+
+// SetLimitSoc implements the api.SocLimitSetter interface
+func (v *Controller) SetLimitSoc(soc int64) error {
+	return apiError(v.vehicle.SetChargeLimit(int(soc)))
+}
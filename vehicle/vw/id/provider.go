@@ -11,8 +11,9 @@ import (
 
 // Provider is an api.Vehicle implementation for VW ID cars
 type Provider struct {
-	statusG func() (Status, error)
-	action  func(action, value string) error
+	statusG   func() (Status, error)
+	action    func(action, value string) error
+	settingsS func(targetSoc int64) error
 }
 
 // NewProvider creates a vehicle api provider
@@ -24,6 +25,9 @@ func NewProvider(api *API, vin string, cache time.Duration) *Provider {
 		action: func(action, value string) error {
 			return api.Action(vin, action, value)
 		},
+		settingsS: func(targetSoc int64) error {
+			return api.ChargeSettings(vin, targetSoc)
+		},
 	}
 	return impl
 }
@@ -156,6 +160,13 @@ func (v *Provider) GetLimitSoc() (int64, error) {
 	return int64(*res.Charging.ChargingSettings.Value.TargetSOCPct), nil
 }
 
+var _ api.SocLimitSetter = (*Provider)(nil)
+
+// SetLimitSoc implements the api.SocLimitSetter interface
+func (v *Provider) SetLimitSoc(soc int64) error {
+	return v.settingsS(soc)
+}
+
 var _ api.ChargeController = (*Provider)(nil)
 
 // ChargeEnable implements the api.ChargeController interface
@@ -164,6 +175,14 @@ func (v *Provider) ChargeEnable(enable bool) error {
 	return v.action(ActionCharge, action[enable])
 }
 
+var _ api.VehicleClimateController = (*Provider)(nil)
+
+// Climatize implements the api.VehicleClimateController interface
+func (v *Provider) Climatize(enable bool) error {
+	action := map[bool]string{true: ActionClimatisationStart, false: ActionClimatisationStop}
+	return v.action(ActionClimatisation, action[enable])
+}
+
 var _ api.Resurrector = (*Provider)(nil)
 
 // WakeUp implements the api.Resurrector interface
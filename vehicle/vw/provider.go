@@ -172,6 +172,14 @@ func (v *Provider) ChargeEnable(enable bool) error {
 	return v.action(ActionCharge, action[enable])
 }
 
+var _ api.VehicleClimateController = (*Provider)(nil)
+
+// Climatize implements the api.VehicleClimateController interface
+func (v *Provider) Climatize(enable bool) error {
+	action := map[bool]string{true: ActionClimatisationStart, false: ActionClimatisationStop}
+	return v.action(ActionClimatisation, action[enable])
+}
+
 var _ api.Diagnosis = (*Provider)(nil)
 
 // Diagnose implements the api.Diagnosis interface